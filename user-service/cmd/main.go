@@ -2,9 +2,11 @@ package main
 
 import (
 	"context"
+	"errors"
 	"log"
+	"maps"
 	"net"
-	"strings"
+	"net/http"
 	"time"
 
 	"user-service/internal/cache/redis"
@@ -12,6 +14,9 @@ import (
 	"user-service/internal/domain"
 	"user-service/internal/event/kafka"
 	grpcserver "user-service/internal/handler/grpc"
+	httpserver "user-service/internal/handler/http"
+	"user-service/internal/middleware"
+	"user-service/internal/oidc"
 	"user-service/internal/repository/postgres"
 	service "user-service/internal/service/user"
 	"user-service/internal/storage"
@@ -20,14 +25,11 @@ import (
 	pb "user-service/protos/user"
 
 	"google.golang.org/grpc"
-	"google.golang.org/grpc/codes"
-	"google.golang.org/grpc/metadata"
 	"google.golang.org/grpc/reflection"
-	"google.golang.org/grpc/status"
+	reflectionv1 "google.golang.org/grpc/reflection/grpc_reflection_v1"
+	reflectionv1alpha "google.golang.org/grpc/reflection/grpc_reflection_v1alpha"
 )
 
-var ErrUnauthenticated = status.Error(codes.Unauthenticated, "unauthenticated")
-
 func main() {
 	// 1. Config yuklash
 	config.LoadConfig()
@@ -48,13 +50,19 @@ func main() {
 
 	// 4. Redis client
 	redisClient := redis.NewRedisClient(cfg)
+	accountStatuses := redis.NewAccountStatusCache(redisClient)
+	profileCache := redis.NewProfileCache(redisClient, cfg.ProfileCacheTTL)
 
-	// 5. JWT Provider
+	// 5. JWT Provider (asimmetrik kalitlar + grace-period kalitlari)
+	keySet, err := loadKeySet(cfg)
+	if err != nil {
+		log.Fatalf("❌ Failed to load JWT signing keys: %v", err)
+	}
 	tokenProvider := utils.NewJWTProvider(
-		cfg.JWT.AccessSecret,
+		keySet,
 		cfg.JWT.RefreshSecret,
-		time.Minute*15,
-		time.Hour*24*7,
+		config.AccessTokenTTL,
+		config.RefreshTokenTTL,
 		redisClient,
 	)
 
@@ -62,18 +70,134 @@ func main() {
 	userRepo := postgres.NewUserRepository(db)
 
 	// 7. Service layer
-	userService := service.NewUserService(userRepo, tokenProvider, kafkaProducer)
+	presenceService := service.NewPresenceService(
+		userRepo,
+		redis.NewPresenceStore(redisClient, cfg.Presence.TTL),
+		service.PresenceConfig{TTL: cfg.Presence.TTL, SweepInterval: cfg.Presence.SweepInterval},
+	)
+	userService := service.NewUserService(
+		service.Deps{
+			Repo:       userRepo,
+			Tokens:     tokenProvider,
+			Kafka:      kafkaProducer,
+			Limiter:    redis.NewRateLimiter(redisClient),
+			LoginCodes: redis.NewLoginCodeStore(redisClient),
+			Identities: oidc.NewClient(oauthProviders(cfg), nil),
+			Attempts:   redis.NewLoginAttemptTracker(redisClient),
+			Hasher: utils.NewPasswordHasher(utils.Argon2Params{
+				Memory:      uint32(cfg.PasswordHash.MemoryKiB),
+				Iterations:  uint32(cfg.PasswordHash.Iterations),
+				Parallelism: uint8(cfg.PasswordHash.Parallelism),
+				SaltLength:  utils.DefaultArgon2Params.SaltLength,
+				KeyLength:   utils.DefaultArgon2Params.KeyLength,
+			}),
+			Breached: loadBreachedPasswords(cfg),
+			Geo:      utils.NewGeoIPLocator(3 * time.Second),
+			Profiles: profileCache,
+			Presence: presenceService,
+			Statuses: accountStatuses,
+		},
+		service.Config{
+			PasswordResetURL:     cfg.Links.PasswordResetURL,
+			EmailVerificationURL: cfg.Links.EmailVerificationURL,
+			LoginLinkURL:         cfg.Links.LoginLinkURL,
+			EmailLockout: domain.LockoutPolicy{
+				MaxFailures:   cfg.Lockout.EmailMaxFailures,
+				FailureWindow: cfg.Lockout.FailureWindow,
+				BaseLockout:   cfg.Lockout.BaseLockout,
+				MaxLockout:    cfg.Lockout.MaxLockout,
+			},
+			IPLockout: domain.LockoutPolicy{
+				MaxFailures:   cfg.Lockout.IPMaxFailures,
+				FailureWindow: cfg.Lockout.FailureWindow,
+				BaseLockout:   cfg.Lockout.BaseLockout,
+				MaxLockout:    cfg.Lockout.MaxLockout,
+			},
+			PasswordPolicy: service.PasswordPolicy{
+				MinLength:     cfg.PasswordPolicy.MinLength,
+				MaxLength:     cfg.PasswordPolicy.MaxLength,
+				RequireUpper:  cfg.PasswordPolicy.RequireUpper,
+				RequireLower:  cfg.PasswordPolicy.RequireLower,
+				RequireDigit:  cfg.PasswordPolicy.RequireDigit,
+				RequireSymbol: cfg.PasswordPolicy.RequireSymbol,
+			},
+			UsernamePolicy: service.UsernamePolicy{
+				MinLength:      cfg.UsernamePolicy.MinLength,
+				MaxLength:      cfg.UsernamePolicy.MaxLength,
+				Reserved:       cfg.UsernamePolicy.Reserved,
+				ChangeCooldown: cfg.UsernamePolicy.ChangeCooldown,
+			},
+			ReauthMaxAge:  cfg.ReauthMaxAge,
+			DeletionGrace: cfg.AccountDeletion.Grace,
+			PurgeInterval: cfg.AccountDeletion.PurgeInterval,
+		},
+	)
+
+	// 8. gRPC server + klient IP, auth va rate limit interceptorlari
+	rateLimiter := middleware.NewRateLimiter(
+		middleware.NewFallbackLimiter(redis.NewSlidingWindowLimiter(redisClient), middleware.NewMemoryLimiter()),
+		methodRateLimits(cfg),
+		middleware.Limit{Requests: cfg.RateLimit.Default.Requests, Window: cfg.RateLimit.Default.Window},
+	)
+	authPolicy := middleware.Policy{
+		reflectionv1.ServerReflection_ServerReflectionInfo_FullMethodName:      middleware.Public,
+		reflectionv1alpha.ServerReflection_ServerReflectionInfo_FullMethodName: middleware.Public,
+	}
+	maps.Copy(authPolicy, grpcserver.UserServicePolicy)
+	maps.Copy(authPolicy, grpcserver.AdminServicePolicy)
+	authenticator := middleware.NewAuthenticator(tokenProvider, accountStatuses, userRepo, authPolicy, cfg.ServiceTokens)
+
+	trustedProxies, err := utils.ParseTrustedProxies(cfg.TrustedProxies)
+	if err != nil {
+		log.Fatalf("❌ Invalid TRUSTED_PROXIES: %v", err)
+	}
+	clientIP := middleware.NewClientIP(trustedProxies)
 
-	// 8. gRPC server + Auth interceptor
 	grpcServer := grpc.NewServer(
-		grpc.UnaryInterceptor(authInterceptor(tokenProvider)),
+		grpc.ChainUnaryInterceptor(clientIP.Unary(), authenticator.Unary(), rateLimiter.Unary()),
+		grpc.ChainStreamInterceptor(clientIP.Stream(), authenticator.Stream(), rateLimiter.Stream()),
 	)
-	pb.RegisterUserServiceServer(grpcServer, grpcserver.NewUserServer(userService))
+	pb.RegisterUserServiceServer(grpcServer, grpcserver.NewUserServer(userService, presenceService))
+	pb.RegisterAdminServiceServer(grpcServer, grpcserver.NewAdminServer(
+		service.NewAdminService(userRepo, tokenProvider, kafkaProducer, accountStatuses, profileCache, service.AdminConfig{
+			ImpersonationTTL: cfg.Admin.ImpersonationTTL,
+		}),
+	))
+
+	// Presence: Redis pub/sub tinglovchi va offline tozalovchi
+	go func() {
+		if err := presenceService.Run(context.Background()); err != nil {
+			log.Printf("⚠️ Presence listener stopped: %v", err)
+		}
+	}()
+
+	// DeleteAccount muddati o'tgan accountlarni o'chiruvchi
+	go func() {
+		if err := userService.RunAccountPurge(context.Background()); err != nil {
+			log.Printf("⚠️ Account purge stopped: %v", err)
+		}
+	}()
 
 	// 9. Reflection (grpcurl uchun)
 	reflection.Register(grpcServer)
 
-	// 10. TCP listener
+	// Har bir ro'yxatdan o'tgan metodning auth siyosati bo'lishi shart
+	if err := authPolicy.Verify(grpcServer.GetServiceInfo()); err != nil {
+		log.Fatalf("❌ %v", err)
+	}
+
+	// 10. JWKS HTTP endpoint (/.well-known/jwks.json)
+	if cfg.Http.JWKSPort != "" {
+		jwksServer := httpserver.NewServer(cfg.Http.Host+":"+cfg.Http.JWKSPort, tokenProvider)
+		go func() {
+			log.Printf("✅ JWKS endpoint is running at %s", jwksServer.Addr)
+			if err := jwksServer.ListenAndServe(); err != nil && err != http.ErrServerClosed {
+				log.Fatalf("❌ Failed to serve JWKS endpoint: %v", err)
+			}
+		}()
+	}
+
+	// 11. TCP listener
 	addr := cfg.Http.Host + ":" + cfg.Http.Port
 	listener, err := net.Listen("tcp", addr)
 	if err != nil {
@@ -82,42 +206,83 @@ func main() {
 
 	log.Printf("✅ gRPC User Service is running at %s", addr)
 
-	// 11. Serverni ishga tushirish
+	// 12. Serverni ishga tushirish
 	if err := grpcServer.Serve(listener); err != nil {
 		log.Fatalf("❌ Failed to serve gRPC server: %v", err)
 	}
 }
 
-// Auth interceptor — barcha RPC chaqiriqlarda tokenni tekshiradi
-func authInterceptor(tokenProvider domain.TokenProvider) grpc.UnaryServerInterceptor {
-	return func(
-		ctx context.Context,
-		req interface{},
-		info *grpc.UnaryServerInfo,
-		handler grpc.UnaryHandler,
-	) (interface{}, error) {
-
-		// Login va Register endpointlarini token tekshirishdan chiqaramiz
-		if strings.Contains(info.FullMethod, "Login") ||
-			strings.Contains(info.FullMethod, "Register") {
-			return handler(ctx, req)
-		}
+// methodRateLimits — config dagi qisqa metod nomlarini to'liq gRPC nomiga o'giradi
+func methodRateLimits(cfg config.Config) map[string]middleware.Limit {
+	known := make(map[string]bool)
+	for _, m := range pb.UserService_ServiceDesc.Methods {
+		known[m.MethodName] = true
+	}
+	for _, m := range pb.UserService_ServiceDesc.Streams {
+		known[m.StreamName] = true
+	}
 
-		md, ok := metadata.FromIncomingContext(ctx)
-		if !ok {
-			return nil, ErrUnauthenticated
+	limits := make(map[string]middleware.Limit, len(cfg.RateLimit.Methods))
+	for name, rule := range cfg.RateLimit.Methods {
+		if !known[name] {
+			log.Printf("⚠️ Rate limit configured for unknown method %q", name)
+			continue
 		}
+		fullMethod := "/" + pb.UserService_ServiceDesc.ServiceName + "/" + name
+		limits[fullMethod] = middleware.Limit{Requests: rule.Requests, Window: rule.Window}
+	}
+	return limits
+}
 
-		tokens := md["authorization"]
-		if len(tokens) == 0 {
-			return nil, ErrUnauthenticated
-		}
+// loadBreachedPasswords — BREACHED_PASSWORDS_PATH bo'sh bo'lsa tekshiruv o'chiq
+func loadBreachedPasswords(cfg config.Config) domain.BreachedPasswordChecker {
+	if cfg.PasswordPolicy.BreachedPasswordsPath == "" {
+		return nil
+	}
+	checker, err := utils.NewBreachedPasswordChecker(cfg.PasswordPolicy.BreachedPasswordsPath)
+	if err != nil {
+		log.Fatalf("❌ Failed to open breached passwords list: %v", err)
+	}
+	return checker
+}
 
-		userID, err := tokenProvider.ValidateAccessToken(strings.TrimPrefix(tokens[0], "Bearer "))
-		if err != nil {
-			return nil, ErrUnauthenticated
+// oauthProviders — config dagi OAuth provayderlarini OIDC klient formatiga o'giradi
+func oauthProviders(cfg config.Config) []oidc.ProviderConfig {
+	providers := make([]oidc.ProviderConfig, 0, len(cfg.OAuth.Providers))
+	for _, p := range cfg.OAuth.Providers {
+		providers = append(providers, oidc.ProviderConfig{
+			Name:         p.Name,
+			Issuer:       p.Issuer,
+			ClientID:     p.ClientID,
+			ClientSecret: p.ClientSecret,
+			TokenURL:     p.TokenURL,
+			JWKSURL:      p.JWKSURL,
+			UserInfoURL:  p.UserInfoURL,
+		})
+	}
+	return providers
+}
+
+// loadKeySet — config dagi PEM fayllardan faol va tekshirish kalitlarini yuklaydi
+func loadKeySet(cfg config.Config) (*utils.KeySet, error) {
+	var active *utils.SigningKey
+	var err error
+	if cfg.JWT.SigningKeyFile == "" {
+		if !cfg.JWT.AllowEphemeralKey {
+			return nil, errors.New("JWT_SIGNING_KEY_FILE is required (set JWT_ALLOW_EPHEMERAL_KEY=true for local development)")
 		}
-		// Typed context key ishlatamiz
-		return handler(context.WithValue(ctx, "userID", userID), req)
+		log.Println("⚠️ JWT_SIGNING_KEY_FILE is not set, using an ephemeral signing key (local development only)")
+		active, err = utils.GenerateEphemeralKey()
+	} else {
+		active, err = utils.LoadSigningKey(cfg.JWT.SigningKeyID, cfg.JWT.SigningKeyFile)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	verification, err := utils.ParseVerificationKeys(cfg.JWT.VerificationKeys)
+	if err != nil {
+		return nil, err
 	}
+	return utils.NewKeySet(active, verification...)
 }
@@ -0,0 +1,66 @@
+package redis
+
+import (
+	"context"
+	"strconv"
+	"time"
+
+	"user-service/internal/domain"
+
+	"github.com/go-redis/redis/v8"
+)
+
+type accountStatusCache struct {
+	client *redis.Client
+}
+
+// NewAccountStatusCache — account_restriction:<userID> hash (status, reason, until).
+// Vaqtinchalik suspension kaliti until da o'zi o'chadi.
+func NewAccountStatusCache(client *redis.Client) domain.AccountStatusCache {
+	return &accountStatusCache{client: client}
+}
+
+func (c *accountStatusCache) Restriction(ctx context.Context, userID string) (*domain.AccountRestrictedError, error) {
+	vals, err := c.client.HGetAll(ctx, "account_restriction:"+userID).Result()
+	if err != nil {
+		return nil, err
+	}
+	if len(vals) == 0 {
+		return nil, nil
+	}
+
+	r := &domain.AccountRestrictedError{
+		Status: domain.UserStatus(vals["status"]),
+		Reason: vals["reason"],
+	}
+	if v := vals["until"]; v != "" {
+		sec, err := strconv.ParseInt(v, 10, 64)
+		if err != nil {
+			return nil, err
+		}
+		until := time.Unix(sec, 0)
+		r.Until = &until
+	}
+	return r, nil
+}
+
+func (c *accountStatusCache) SetRestriction(ctx context.Context, userID string, r *domain.AccountRestrictedError) error {
+	key := "account_restriction:" + userID
+	until := ""
+	if r.Until != nil {
+		until = strconv.FormatInt(r.Until.Unix(), 10)
+	}
+
+	pipe := c.client.TxPipeline()
+	pipe.Del(ctx, key)
+	pipe.HSet(ctx, key, "status", string(r.Status), "reason", r.Reason, "until", until)
+	if r.Until != nil {
+		pipe.ExpireAt(ctx, key, *r.Until)
+	}
+	_, err := pipe.Exec(ctx)
+	return err
+}
+
+func (c *accountStatusCache) ClearRestriction(ctx context.Context, userID string) error {
+	return c.client.Del(ctx, "account_restriction:"+userID).Err()
+}
@@ -0,0 +1,59 @@
+package redis
+
+import (
+	"context"
+	"time"
+
+	"user-service/internal/domain"
+
+	"github.com/go-redis/redis/v8"
+)
+
+type loginAttemptTracker struct {
+	client *redis.Client
+}
+
+// NewLoginAttemptTracker — login_failures:<key> hisoblagichi va login_lock:<key> bloklash kaliti
+func NewLoginAttemptTracker(client *redis.Client) domain.LoginAttemptTracker {
+	return &loginAttemptTracker{client: client}
+}
+
+func (t *loginAttemptTracker) LockedFor(ctx context.Context, key string) (time.Duration, error) {
+	ttl, err := t.client.PTTL(ctx, "login_lock:"+key).Result()
+	if err != nil {
+		return 0, err
+	}
+	// -2 => kalit yo'q, -1 => TTL siz (bo'lmasligi kerak)
+	if ttl < 0 {
+		return 0, nil
+	}
+	return ttl, nil
+}
+
+func (t *loginAttemptTracker) RecordFailure(ctx context.Context, key string, policy domain.LockoutPolicy) (int, time.Duration, error) {
+	counterKey := "login_failures:" + key
+
+	pipe := t.client.TxPipeline()
+	count := pipe.Incr(ctx, counterKey)
+	pipe.PExpire(ctx, counterKey, policy.FailureWindow)
+	if _, err := pipe.Exec(ctx); err != nil {
+		return 0, 0, err
+	}
+
+	failures := int(count.Val())
+	lockout := policy.LockoutFor(failures)
+	if lockout > 0 {
+		if err := t.client.Set(ctx, "login_lock:"+key, failures, lockout).Err(); err != nil {
+			return failures, 0, err
+		}
+		// Bloklash tugagandan keyin ham hisoblagich saqlansin, aks holda backoff qayta boshlanadi
+		if lockout > policy.FailureWindow {
+			t.client.PExpire(ctx, counterKey, lockout+policy.FailureWindow)
+		}
+	}
+	return failures, lockout, nil
+}
+
+func (t *loginAttemptTracker) Reset(ctx context.Context, key string) error {
+	return t.client.Del(ctx, "login_failures:"+key, "login_lock:"+key).Err()
+}
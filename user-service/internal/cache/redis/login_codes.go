@@ -0,0 +1,54 @@
+package redis
+
+import (
+	"context"
+	"time"
+
+	"user-service/internal/domain"
+
+	"github.com/go-redis/redis/v8"
+)
+
+type loginCodeStore struct {
+	client *redis.Client
+}
+
+func NewLoginCodeStore(client *redis.Client) domain.LoginCodeStore {
+	return &loginCodeStore{client: client}
+}
+
+func (s *loginCodeStore) Save(ctx context.Context, email, codeHash string, ttl time.Duration) error {
+	key := "login_code:" + email
+	pipe := s.client.TxPipeline()
+	pipe.Del(ctx, key)
+	pipe.HSet(ctx, key, "hash", codeHash, "attempts", 0)
+	pipe.Expire(ctx, key, ttl)
+	_, err := pipe.Exec(ctx)
+	return err
+}
+
+// verifyLoginCodeScript — tekshirish va urinishlar hisobini atomar bajaradi.
+// 1 => to'g'ri, 0 => noto'g'ri, -1 => kod yo'q (muddati o'tgan yoki bekor qilingan)
+var verifyLoginCodeScript = redis.NewScript(`
+local hash = redis.call('HGET', KEYS[1], 'hash')
+if not hash then
+	return -1
+end
+if hash == ARGV[1] then
+	redis.call('DEL', KEYS[1])
+	return 1
+end
+local attempts = redis.call('HINCRBY', KEYS[1], 'attempts', 1)
+if attempts >= tonumber(ARGV[2]) then
+	redis.call('DEL', KEYS[1])
+end
+return 0
+`)
+
+func (s *loginCodeStore) Verify(ctx context.Context, email, codeHash string, maxAttempts int) (bool, error) {
+	res, err := verifyLoginCodeScript.Run(ctx, s.client, []string{"login_code:" + email}, codeHash, maxAttempts).Int()
+	if err != nil {
+		return false, err
+	}
+	return res == 1, nil
+}
@@ -0,0 +1,206 @@
+package redis
+
+import (
+	"context"
+	"encoding/json"
+	"log"
+	"strconv"
+	"time"
+
+	"user-service/internal/domain"
+
+	"github.com/go-redis/redis/v8"
+)
+
+const (
+	presenceDevicesKey = "presence:devices" // ZSET: "<userID>|<deviceID>" -> oxirgi heartbeat (ms)
+	presenceChannel    = "presence:events"
+)
+
+type presenceStore struct {
+	client *redis.Client
+	ttl    time.Duration
+}
+
+// NewPresenceStore — presence:<userID> ZSET (deviceID -> oxirgi heartbeat, ms).
+// ttl ichida heartbeat yubormagan qurilma offline hisoblanadi.
+func NewPresenceStore(client *redis.Client, ttl time.Duration) domain.PresenceStore {
+	return &presenceStore{client: client, ttl: ttl}
+}
+
+func presenceKey(userID string) string {
+	return "presence:" + userID
+}
+
+// heartbeatScript — 1 => user shu heartbeat bilan online bo'ldi (boshqa tirik qurilmasi yo'q edi)
+var heartbeatScript = redis.NewScript(`
+redis.call('ZREMRANGEBYSCORE', KEYS[1], '-inf', ARGV[2])
+local before = redis.call('ZCARD', KEYS[1])
+redis.call('ZADD', KEYS[1], ARGV[1], ARGV[3])
+redis.call('PEXPIRE', KEYS[1], ARGV[5])
+redis.call('ZADD', KEYS[2], ARGV[1], ARGV[4] .. '|' .. ARGV[3])
+if before == 0 then
+	return 1
+end
+return 0
+`)
+
+func (s *presenceStore) Heartbeat(ctx context.Context, userID, deviceID string) (bool, error) {
+	now := time.Now()
+	res, err := heartbeatScript.Run(ctx, s.client,
+		[]string{presenceKey(userID), presenceDevicesKey},
+		now.UnixMilli(), now.Add(-s.ttl).UnixMilli(), deviceID, userID, s.ttl.Milliseconds(),
+	).Int()
+	return res == 1, err
+}
+
+// disconnectScript — 1 => o'chirilgan qurilma userning oxirgi tirik qurilmasi edi
+var disconnectScript = redis.NewScript(`
+local removed = redis.call('ZREM', KEYS[1], ARGV[2])
+redis.call('ZREM', KEYS[2], ARGV[3] .. '|' .. ARGV[2])
+redis.call('ZREMRANGEBYSCORE', KEYS[1], '-inf', ARGV[1])
+if removed == 1 and redis.call('ZCARD', KEYS[1]) == 0 then
+	return 1
+end
+return 0
+`)
+
+func (s *presenceStore) Disconnect(ctx context.Context, userID, deviceID string) (bool, error) {
+	res, err := disconnectScript.Run(ctx, s.client,
+		[]string{presenceKey(userID), presenceDevicesKey},
+		time.Now().Add(-s.ttl).UnixMilli(), deviceID, userID,
+	).Int()
+	return res == 1, err
+}
+
+// expireDevicesScript — muddati o'tgan qurilmalarni olib tashlaydi va offline bo'lib qolgan
+// userlar uchun {userID, deviceID, lastHeartbeat} uchliklarini qaytaradi.
+// Atomar: bir nechta replika parallel ishlasa ham har bir qurilma faqat bir marta qaytadi.
+var expireDevicesScript = redis.NewScript(`
+local expired = redis.call('ZRANGEBYSCORE', KEYS[1], '-inf', ARGV[1], 'WITHSCORES', 'LIMIT', 0, ARGV[2])
+local result = {}
+for i = 1, #expired, 2 do
+	local member, score = expired[i], expired[i + 1]
+	redis.call('ZREM', KEYS[1], member)
+	local sep = string.find(member, '|', 1, true)
+	local user, device = string.sub(member, 1, sep - 1), string.sub(member, sep + 1)
+	local key = 'presence:' .. user
+	redis.call('ZREM', key, device)
+	redis.call('ZREMRANGEBYSCORE', key, '-inf', ARGV[1])
+	if redis.call('ZCARD', key) == 0 then
+		table.insert(result, user)
+		table.insert(result, device)
+		table.insert(result, score)
+	end
+end
+return result
+`)
+
+func (s *presenceStore) ExpireDevices(ctx context.Context, limit int) ([]domain.DeviceExpiry, error) {
+	vals, err := expireDevicesScript.Run(ctx, s.client,
+		[]string{presenceDevicesKey},
+		time.Now().Add(-s.ttl).UnixMilli(), limit,
+	).StringSlice()
+	if err != nil {
+		return nil, err
+	}
+
+	// Bir userning bir nechta qurilmasi bir vaqtda eskirishi mumkin — eng oxirgi heartbeat olinadi
+	var expired []domain.DeviceExpiry
+	index := make(map[string]int)
+	for i := 0; i+2 < len(vals); i += 3 {
+		ms, err := strconv.ParseInt(vals[i+2], 10, 64)
+		if err != nil {
+			return nil, err
+		}
+		e := domain.DeviceExpiry{UserID: vals[i], DeviceID: vals[i+1], LastSeen: time.UnixMilli(ms)}
+		if j, ok := index[e.UserID]; ok {
+			if e.LastSeen.After(expired[j].LastSeen) {
+				expired[j] = e
+			}
+			continue
+		}
+		index[e.UserID] = len(expired)
+		expired = append(expired, e)
+	}
+	return expired, nil
+}
+
+func (s *presenceStore) Online(ctx context.Context, userIDs []string) (map[string]bool, error) {
+	cutoff := strconv.FormatInt(time.Now().Add(-s.ttl).UnixMilli(), 10)
+	pipe := s.client.Pipeline()
+	counts := make([]*redis.IntCmd, len(userIDs))
+	for i, id := range userIDs {
+		counts[i] = pipe.ZCount(ctx, presenceKey(id), "("+cutoff, "+inf")
+	}
+	if _, err := pipe.Exec(ctx); err != nil {
+		return nil, err
+	}
+
+	online := make(map[string]bool, len(userIDs))
+	for i, id := range userIDs {
+		online[id] = counts[i].Val() > 0
+	}
+	return online, nil
+}
+
+type presenceMessage struct {
+	UserID   string `json:"user_id"`
+	Online   bool   `json:"online"`
+	LastSeen int64  `json:"last_seen,omitempty"` // unix sekund; 0 => yo'q
+	Hidden   bool   `json:"hidden,omitempty"`
+}
+
+func (s *presenceStore) Publish(ctx context.Context, p domain.Presence) error {
+	msg := presenceMessage{UserID: p.UserID, Online: p.Online, Hidden: p.Hidden}
+	if p.LastSeen != nil {
+		msg.LastSeen = p.LastSeen.Unix()
+	}
+	raw, err := json.Marshal(msg)
+	if err != nil {
+		return err
+	}
+	return s.client.Publish(ctx, presenceChannel, raw).Err()
+}
+
+func (s *presenceStore) Subscribe(ctx context.Context) (<-chan domain.Presence, error) {
+	sub := s.client.Subscribe(ctx, presenceChannel)
+	// Obuna tasdiqlanguncha kutiladi — aks holda birinchi xabarlar yo'qolishi mumkin
+	if _, err := sub.Receive(ctx); err != nil {
+		sub.Close()
+		return nil, err
+	}
+
+	out := make(chan domain.Presence)
+	go func() {
+		defer close(out)
+		defer sub.Close()
+		messages := sub.Channel()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case m, ok := <-messages:
+				if !ok {
+					return
+				}
+				var msg presenceMessage
+				if err := json.Unmarshal([]byte(m.Payload), &msg); err != nil {
+					log.Printf("⚠️ Invalid presence message: %v", err)
+					continue
+				}
+				p := domain.Presence{UserID: msg.UserID, Online: msg.Online, Hidden: msg.Hidden}
+				if msg.LastSeen != 0 {
+					t := time.Unix(msg.LastSeen, 0)
+					p.LastSeen = &t
+				}
+				select {
+				case out <- p:
+				case <-ctx.Done():
+					return
+				}
+			}
+		}
+	}()
+	return out, nil
+}
@@ -0,0 +1,98 @@
+package redis
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"user-service/internal/domain"
+
+	"github.com/go-redis/redis/v8"
+)
+
+type profileCache struct {
+	client *redis.Client
+	ttl    time.Duration
+}
+
+// NewProfileCache — profile:<userID> JSON qiymatlari. TTL invalidatsiya o'tkazib yuborilgan
+// holatda eskirgan ma'lumot qancha yashashini chegaralaydi.
+func NewProfileCache(client *redis.Client, ttl time.Duration) domain.ProfileCache {
+	return &profileCache{client: client, ttl: ttl}
+}
+
+type cachedProfile struct {
+	ID        string  `json:"id"`
+	Username  string  `json:"username"`
+	FullName  *string `json:"full_name,omitempty"`
+	AvatarURL *string `json:"avatar_url,omitempty"`
+}
+
+func profileKey(userID string) string {
+	return "profile:" + userID
+}
+
+func (c *profileCache) GetProfiles(ctx context.Context, ids []string) (map[string]domain.PublicProfile, error) {
+	if len(ids) == 0 {
+		return nil, nil
+	}
+	keys := make([]string, len(ids))
+	for i, id := range ids {
+		keys[i] = profileKey(id)
+	}
+	vals, err := c.client.MGet(ctx, keys...).Result()
+	if err != nil {
+		return nil, err
+	}
+
+	profiles := make(map[string]domain.PublicProfile, len(ids))
+	for _, v := range vals {
+		raw, ok := v.(string)
+		if !ok {
+			continue // kesh miss
+		}
+		var p cachedProfile
+		if err := json.Unmarshal([]byte(raw), &p); err != nil {
+			continue // buzilgan yozuv DB dan qayta o'qiladi
+		}
+		profiles[p.ID] = domain.PublicProfile{
+			ID:        p.ID,
+			Username:  p.Username,
+			FullName:  p.FullName,
+			AvatarURL: p.AvatarURL,
+		}
+	}
+	return profiles, nil
+}
+
+func (c *profileCache) SetProfiles(ctx context.Context, profiles []domain.PublicProfile) error {
+	if len(profiles) == 0 {
+		return nil
+	}
+	pipe := c.client.Pipeline()
+	for _, p := range profiles {
+		raw, err := json.Marshal(cachedProfile{
+			ID:        p.ID,
+			Username:  p.Username,
+			FullName:  p.FullName,
+			AvatarURL: p.AvatarURL,
+		})
+		if err != nil {
+			return err
+		}
+		pipe.Set(ctx, profileKey(p.ID), raw, c.ttl)
+	}
+	_, err := pipe.Exec(ctx)
+	return err
+}
+
+func (c *profileCache) Invalidate(ctx context.Context, userIDs ...string) error {
+	if len(userIDs) == 0 {
+		return nil
+	}
+	keys := make([]string, len(userIDs))
+	for i, id := range userIDs {
+		keys[i] = profileKey(id)
+	}
+	return c.client.Del(ctx, keys...).Err()
+}
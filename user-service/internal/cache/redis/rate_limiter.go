@@ -0,0 +1,37 @@
+package redis
+
+import (
+	"context"
+	"time"
+
+	"user-service/internal/domain"
+
+	"github.com/go-redis/redis/v8"
+)
+
+type rateLimiter struct {
+	client *redis.Client
+}
+
+// NewRateLimiter — Redis dagi fixed-window hisoblagichga asoslangan limiter
+func NewRateLimiter(client *redis.Client) domain.RateLimiter {
+	return &rateLimiter{client: client}
+}
+
+func (l *rateLimiter) Allow(ctx context.Context, key string, limit int, window time.Duration) (bool, time.Duration, error) {
+	key = "ratelimit:" + key
+
+	// SetNX oyna boshida TTL bilan kalit yaratadi, Incr esa TTL ni saqlab qoladi
+	pipe := l.client.TxPipeline()
+	pipe.SetNX(ctx, key, 0, window)
+	count := pipe.Incr(ctx, key)
+	ttl := pipe.PTTL(ctx, key)
+	if _, err := pipe.Exec(ctx); err != nil {
+		return false, 0, err
+	}
+
+	if count.Val() > int64(limit) {
+		return false, ttl.Val(), nil
+	}
+	return true, 0, nil
+}
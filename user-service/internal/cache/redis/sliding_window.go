@@ -0,0 +1,53 @@
+package redis
+
+import (
+	"context"
+	"time"
+
+	"user-service/internal/domain"
+
+	"github.com/go-redis/redis/v8"
+	"github.com/google/uuid"
+)
+
+type slidingWindowLimiter struct {
+	client *redis.Client
+}
+
+// NewSlidingWindowLimiter — sorted set asosidagi sliding-window limiter (har so'rov — bitta a'zo).
+// Fixed window dan farqli ravishda oyna chegarasida limitni ikki baravar oshirib bo'lmaydi.
+func NewSlidingWindowLimiter(client *redis.Client) domain.RateLimiter {
+	return &slidingWindowLimiter{client: client}
+}
+
+// slidingWindowScript — eski yozuvlarni tozalash, sanash va qo'shishni atomar bajaradi.
+// Qaytaradi: {1, 0} — ruxsat; {0, retry_after_ms} — rad.
+var slidingWindowScript = redis.NewScript(`
+local now = tonumber(ARGV[1])
+local window = tonumber(ARGV[2])
+local limit = tonumber(ARGV[3])
+
+redis.call('ZREMRANGEBYSCORE', KEYS[1], '-inf', now - window)
+if redis.call('ZCARD', KEYS[1]) < limit then
+	redis.call('ZADD', KEYS[1], now, ARGV[4])
+	redis.call('PEXPIRE', KEYS[1], window)
+	return {1, 0}
+end
+
+local oldest = redis.call('ZRANGE', KEYS[1], 0, 0, 'WITHSCORES')
+return {0, tonumber(oldest[2]) + window - now}
+`)
+
+func (l *slidingWindowLimiter) Allow(ctx context.Context, key string, limit int, window time.Duration) (bool, time.Duration, error) {
+	now := time.Now().UnixMilli()
+	res, err := slidingWindowScript.Run(ctx, l.client, []string{"ratelimit:sw:" + key},
+		now, window.Milliseconds(), limit, uuid.New().String(),
+	).Int64Slice()
+	if err != nil {
+		return false, 0, err
+	}
+	if res[0] == 1 {
+		return true, 0, nil
+	}
+	return false, time.Duration(res[1]) * time.Millisecond, nil
+}
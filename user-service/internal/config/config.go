@@ -1,16 +1,28 @@
 package config
 
 import (
+	"fmt"
 	"log"
 	"os"
+	"strconv"
+	"strings"
+	"time"
 
 	"github.com/joho/godotenv"
 )
 
+// Token umrlari. Access token bekor qilish kalitlari (revoked_session:*, tokens_invalid_before:*)
+// Redis da AccessTokenTTL davomida saqlanadi — undan uzoq yashaydigan access token chiqarilmasligi kerak.
+const (
+	AccessTokenTTL  = 15 * time.Minute
+	RefreshTokenTTL = 7 * 24 * time.Hour
+)
+
 type Config struct {
 	Http struct {
-		Host string
-		Port string
+		Host     string
+		Port     string
+		JWKSPort string
 	}
 
 	Database struct {
@@ -34,10 +46,113 @@ type Config struct {
 	}
 
 	JWT struct {
-		AccessSecret  string
-		RefreshSecret string
+		RefreshSecret    string
+		SigningKeyID     string
+		SigningKeyFile   string
+		VerificationKeys string // "kid=path.pem,kid2=path2.pem" — grace-period kalitlari
+		// AllowEphemeralKey — JWT_SIGNING_KEY_FILE bo'sh bo'lsa vaqtinchalik kalit (faqat lokal;
+		// bir nechta replika yoki restartdan keyin tokenlar tekshirilmay qoladi)
+		AllowEphemeralKey bool
+	}
+
+	Links struct {
+		PasswordResetURL     string // klient sahifasi, token ?token= bilan qo'shiladi
+		EmailVerificationURL string
+		LoginLinkURL         string
+	}
+
+	OAuth struct {
+		Providers []OAuthProvider
+	}
+
+	// Login brute-force himoyasi (LOGIN_* o'zgaruvchilari, bo'sh bo'lsa standart qiymatlar)
+	Lockout struct {
+		EmailMaxFailures int           // shu sondagi xatodan keyin email bloklanadi
+		IPMaxFailures    int           // IP uchun (NAT ortidagi ko'p userlar sababli kattaroq)
+		FailureWindow    time.Duration // hisoblagich umri
+		BaseLockout      time.Duration // birinchi bloklash, keyin har xatoda x2
+		MaxLockout       time.Duration
 	}
 
+	// gRPC rate limit: RATE_LIMIT_DEFAULT=60/1m, RATE_LIMIT_METHODS=Register=5/1h,ForgotPassword=3/15m.
+	// RATE_LIMIT_METHODS standart metod limitlarini faqat ko'rsatilgan metodlar uchun almashtiradi
+	RateLimit struct {
+		Default RateLimitRule
+		Methods map[string]RateLimitRule // metod nomi (masalan "Register") => limit
+	}
+
+	// Argon2id parametrlari (ARGON2_*); o'zgartirilsa eski hashlar keyingi loginda qayta hashlanadi
+	PasswordHash struct {
+		MemoryKiB   int
+		Iterations  int
+		Parallelism int
+	}
+
+	// Parol talablari (PASSWORD_*); BreachedPasswordsPath — HIBP fayli yoki range-fayllar katalogi
+	PasswordPolicy struct {
+		MinLength             int
+		MaxLength             int
+		RequireUpper          bool
+		RequireLower          bool
+		RequireDigit          bool
+		RequireSymbol         bool
+		BreachedPasswordsPath string
+	}
+
+	// Username talablari (USERNAME_*); Reserved — standart ro'yxatga qo'shimcha band nomlar
+	UsernamePolicy struct {
+		MinLength      int
+		MaxLength      int
+		Reserved       []string
+		ChangeCooldown time.Duration
+	}
+
+	// Service-to-service tokenlar: SERVICE_TOKENS=chat-service=<token>,notification-service=<token>
+	ServiceTokens map[string]string
+
+	// x-forwarded-for ga ishoniladigan proxylar: TRUSTED_PROXIES=10.0.0.0/8,172.16.0.0/12 (bo'sh => sarlavha e'tiborsiz)
+	TrustedProxies []string
+
+	// Nozik amallar oldidan talab qilinadigan qayta autentifikatsiya oynasi
+	ReauthMaxAge time.Duration
+
+	// BatchGetUsers uchun Redis dagi ochiq profil keshining yashash muddati
+	ProfileCacheTTL time.Duration
+
+	// Presence: TTL ichida heartbeat kelmagan qurilma offline; SweepInterval — tekshirish oralig'i
+	Presence struct {
+		TTL           time.Duration
+		SweepInterval time.Duration
+	}
+
+	Admin struct {
+		ImpersonationTTL time.Duration
+	}
+
+	// DeleteAccount: Grace davomida account pending_deletion (tiklash mumkin), keyin o'chiriladi
+	AccountDeletion struct {
+		Grace         time.Duration
+		PurgeInterval time.Duration
+	}
+}
+
+// RateLimitRule — Window ichida ko'pi bilan Requests ta so'rov ("5/1m")
+type RateLimitRule struct {
+	Requests int
+	Window   time.Duration
+}
+
+// OAuthProvider — OAUTH_PROVIDERS=google,github ro'yxatidagi har bir provayder uchun
+// OAUTH_<NAME>_CLIENT_ID, _CLIENT_SECRET, _ISSUER, _TOKEN_URL, _JWKS_URL, _USERINFO_URL.
+// Google, Apple va GitHub uchun bo'sh endpointlar standart qiymatlar bilan to'ldiriladi.
+type OAuthProvider struct {
+	Name         string
+	Issuer       string
+	ClientID     string
+	ClientSecret string
+	TokenURL     string
+	JWKSURL      string
+	UserInfoURL  string
 }
 
 var AppConfig Config
@@ -53,11 +168,13 @@ func LoadConfig() {
 
 	AppConfig = Config{
 		Http: struct {
-			Host string
-			Port string
+			Host     string
+			Port     string
+			JWKSPort string
 		}{
-			Host: os.Getenv("HTTP_HOST"),
-			Port: os.Getenv("HTTP_PORT"),
+			Host:     os.Getenv("HTTP_HOST"),
+			Port:     os.Getenv("HTTP_PORT"),
+			JWKSPort: os.Getenv("JWKS_HTTP_PORT"),
 		},
 		Database: struct {
 			Host     string
@@ -72,9 +189,9 @@ func LoadConfig() {
 			Password: os.Getenv("DB_PASSWORD"),
 			Name:     os.Getenv("DB_NAME"),
 		},
-		Redis: struct{
-			Host string;
-			 Port string
+		Redis: struct {
+			Host string
+			Port string
 		}{
 			Host: os.Getenv("REDIS_HOST"),
 			Port: os.Getenv("REDIS_PORT"),
@@ -91,11 +208,215 @@ func LoadConfig() {
 			Topic: os.Getenv("KAFKA_TOPIC"),
 		},
 		JWT: struct {
-			AccessSecret  string
-			RefreshSecret string
+			RefreshSecret     string
+			SigningKeyID      string
+			SigningKeyFile    string
+			VerificationKeys  string
+			AllowEphemeralKey bool
 		}{
-			AccessSecret:  os.Getenv("JWT_ACCESS_SECRET"),
-			RefreshSecret: os.Getenv("JWT_REFRESH_SECRET"),
+			RefreshSecret:     os.Getenv("JWT_REFRESH_SECRET"),
+			SigningKeyID:      os.Getenv("JWT_SIGNING_KEY_ID"),
+			SigningKeyFile:    os.Getenv("JWT_SIGNING_KEY_FILE"),
+			VerificationKeys:  os.Getenv("JWT_VERIFICATION_KEYS"),
+			AllowEphemeralKey: getEnvBool("JWT_ALLOW_EPHEMERAL_KEY", false),
 		},
+		Links: struct {
+			PasswordResetURL     string
+			EmailVerificationURL string
+			LoginLinkURL         string
+		}{
+			PasswordResetURL:     os.Getenv("PASSWORD_RESET_URL"),
+			EmailVerificationURL: os.Getenv("EMAIL_VERIFICATION_URL"),
+			LoginLinkURL:         os.Getenv("LOGIN_LINK_URL"),
+		},
+	}
+	AppConfig.OAuth.Providers = loadOAuthProviders(os.Getenv("OAUTH_PROVIDERS"))
+
+	AppConfig.Lockout.EmailMaxFailures = getEnvInt("LOGIN_EMAIL_MAX_FAILURES", 5)
+	AppConfig.Lockout.IPMaxFailures = getEnvInt("LOGIN_IP_MAX_FAILURES", 20)
+	AppConfig.Lockout.FailureWindow = getEnvDuration("LOGIN_FAILURE_WINDOW", 15*time.Minute)
+	AppConfig.Lockout.BaseLockout = getEnvDuration("LOGIN_BASE_LOCKOUT", time.Minute)
+	AppConfig.Lockout.MaxLockout = getEnvDuration("LOGIN_MAX_LOCKOUT", time.Hour)
+
+	AppConfig.RateLimit.Default = loadRateLimitDefault(os.Getenv("RATE_LIMIT_DEFAULT"))
+	AppConfig.RateLimit.Methods = loadRateLimitMethods(os.Getenv("RATE_LIMIT_METHODS"))
+
+	AppConfig.ServiceTokens = loadServiceTokens(os.Getenv("SERVICE_TOKENS"))
+	AppConfig.TrustedProxies = loadList(os.Getenv("TRUSTED_PROXIES"))
+
+	AppConfig.ReauthMaxAge = getEnvDuration("REAUTH_MAX_AGE", 10*time.Minute)
+	AppConfig.ProfileCacheTTL = getEnvDuration("PROFILE_CACHE_TTL", 10*time.Minute)
+	AppConfig.Presence.TTL = getEnvDuration("PRESENCE_TTL", time.Minute)
+	AppConfig.Presence.SweepInterval = getEnvDuration("PRESENCE_SWEEP_INTERVAL", 10*time.Second)
+	AppConfig.Admin.ImpersonationTTL = getEnvDuration("IMPERSONATION_TTL", 10*time.Minute)
+	if ttl := AppConfig.Admin.ImpersonationTTL; ttl <= 0 || ttl > AccessTokenTTL {
+		log.Fatalf("IMPERSONATION_TTL must be between 0 and %s (access token revocation window), got %s", AccessTokenTTL, ttl)
+	}
+	AppConfig.AccountDeletion.Grace = getEnvDuration("ACCOUNT_DELETION_GRACE", 30*24*time.Hour)
+	AppConfig.AccountDeletion.PurgeInterval = getEnvDuration("ACCOUNT_PURGE_INTERVAL", time.Hour)
+	if AppConfig.AccountDeletion.Grace < 0 || AppConfig.AccountDeletion.PurgeInterval <= 0 {
+		log.Fatalf("ACCOUNT_DELETION_GRACE must not be negative and ACCOUNT_PURGE_INTERVAL must be positive")
+	}
+
+	AppConfig.PasswordHash.MemoryKiB = getEnvInt("ARGON2_MEMORY_KIB", 64*1024)
+	AppConfig.PasswordHash.Iterations = getEnvInt("ARGON2_ITERATIONS", 3)
+	AppConfig.PasswordHash.Parallelism = getEnvInt("ARGON2_PARALLELISM", 2)
+
+	AppConfig.PasswordPolicy.MinLength = getEnvInt("PASSWORD_MIN_LENGTH", 8)
+	AppConfig.PasswordPolicy.MaxLength = getEnvInt("PASSWORD_MAX_LENGTH", 128)
+	AppConfig.PasswordPolicy.RequireUpper = getEnvBool("PASSWORD_REQUIRE_UPPER", false)
+	AppConfig.PasswordPolicy.RequireLower = getEnvBool("PASSWORD_REQUIRE_LOWER", false)
+	AppConfig.PasswordPolicy.RequireDigit = getEnvBool("PASSWORD_REQUIRE_DIGIT", false)
+	AppConfig.PasswordPolicy.RequireSymbol = getEnvBool("PASSWORD_REQUIRE_SYMBOL", false)
+	AppConfig.PasswordPolicy.BreachedPasswordsPath = os.Getenv("BREACHED_PASSWORDS_PATH")
+
+	AppConfig.UsernamePolicy.MinLength = getEnvInt("USERNAME_MIN_LENGTH", 3)
+	AppConfig.UsernamePolicy.MaxLength = getEnvInt("USERNAME_MAX_LENGTH", 30)
+	AppConfig.UsernamePolicy.Reserved = loadUsernameList(os.Getenv("USERNAME_RESERVED"))
+	AppConfig.UsernamePolicy.ChangeCooldown = getEnvDuration("USERNAME_CHANGE_COOLDOWN", 30*24*time.Hour)
+}
+
+func getEnvBool(key string, def bool) bool {
+	v, err := strconv.ParseBool(os.Getenv(key))
+	if err != nil {
+		return def
+	}
+	return v
+}
+
+func loadUsernameList(value string) []string {
+	var names []string
+	for _, name := range strings.Split(value, ",") {
+		if name = strings.ToLower(strings.TrimSpace(name)); name != "" {
+			names = append(names, name)
+		}
+	}
+	return names
+}
+
+func loadList(value string) []string {
+	var items []string
+	for _, item := range strings.Split(value, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
+
+func loadServiceTokens(value string) map[string]string {
+	tokens := make(map[string]string)
+	for _, entry := range strings.Split(value, ",") {
+		name, token, ok := strings.Cut(strings.TrimSpace(entry), "=")
+		if !ok || name == "" || token == "" {
+			continue
+		}
+		tokens[name] = token
+	}
+	return tokens
+}
+
+// Standart limitlar — env bo'sh bo'lsa ham registratsiya, parol tiklash va username
+// o'zgartirish cheklanmagan holda qolmaydi
+var (
+	defaultRateLimit        = RateLimitRule{Requests: 60, Window: time.Minute}
+	defaultRateLimitMethods = map[string]RateLimitRule{
+		"Register":         {Requests: 5, Window: time.Hour},
+		"ForgotPassword":   {Requests: 3, Window: 15 * time.Minute},
+		"RequestLoginCode": {Requests: 5, Window: 15 * time.Minute},
+		"UpdateUsername":   {Requests: 10, Window: time.Hour},
+	}
+)
+
+func loadRateLimitDefault(value string) RateLimitRule {
+	if strings.TrimSpace(value) == "" {
+		return defaultRateLimit
+	}
+	r, err := parseRateLimitRule(value)
+	if err != nil {
+		log.Fatalf("Invalid RATE_LIMIT_DEFAULT %q: %v", value, err)
+	}
+	return r
+}
+
+// loadRateLimitMethods — standart metod limitlari ustiga env dagilar yoziladi
+func loadRateLimitMethods(value string) map[string]RateLimitRule {
+	methods := make(map[string]RateLimitRule, len(defaultRateLimitMethods))
+	for name, r := range defaultRateLimitMethods {
+		methods[name] = r
+	}
+	for _, entry := range strings.Split(value, ",") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+		name, rule, ok := strings.Cut(entry, "=")
+		if !ok || strings.TrimSpace(name) == "" {
+			log.Fatalf("Invalid RATE_LIMIT_METHODS entry %q: expected <method>=<requests>/<window>", entry)
+		}
+		r, err := parseRateLimitRule(rule)
+		if err != nil {
+			log.Fatalf("Invalid RATE_LIMIT_METHODS entry %q: %v", entry, err)
+		}
+		methods[strings.TrimSpace(name)] = r
+	}
+	return methods
+}
+
+// parseRateLimitRule — "<requests>/<window>", ikkalasi ham musbat bo'lishi shart
+func parseRateLimitRule(value string) (RateLimitRule, error) {
+	n, w, ok := strings.Cut(strings.TrimSpace(value), "/")
+	if !ok {
+		return RateLimitRule{}, fmt.Errorf("expected <requests>/<window>")
+	}
+	requests, err := strconv.Atoi(n)
+	if err != nil {
+		return RateLimitRule{}, err
+	}
+	window, err := time.ParseDuration(w)
+	if err != nil {
+		return RateLimitRule{}, err
+	}
+	if requests <= 0 || window <= 0 {
+		return RateLimitRule{}, fmt.Errorf("requests and window must be positive")
+	}
+	return RateLimitRule{Requests: requests, Window: window}, nil
+}
+
+func getEnvInt(key string, def int) int {
+	v, err := strconv.Atoi(os.Getenv(key))
+	if err != nil {
+		return def
+	}
+	return v
+}
+
+// getEnvDuration — "15m", "1h" kabi qiymatlar
+func getEnvDuration(key string, def time.Duration) time.Duration {
+	v, err := time.ParseDuration(os.Getenv(key))
+	if err != nil {
+		return def
+	}
+	return v
+}
+
+func loadOAuthProviders(names string) []OAuthProvider {
+	var providers []OAuthProvider
+	for _, name := range strings.Split(names, ",") {
+		name = strings.ToLower(strings.TrimSpace(name))
+		if name == "" {
+			continue
+		}
+		prefix := "OAUTH_" + strings.ToUpper(name) + "_"
+		providers = append(providers, OAuthProvider{
+			Name:         name,
+			Issuer:       os.Getenv(prefix + "ISSUER"),
+			ClientID:     os.Getenv(prefix + "CLIENT_ID"),
+			ClientSecret: os.Getenv(prefix + "CLIENT_SECRET"),
+			TokenURL:     os.Getenv(prefix + "TOKEN_URL"),
+			JWKSURL:      os.Getenv(prefix + "JWKS_URL"),
+			UserInfoURL:  os.Getenv(prefix + "USERINFO_URL"),
+		})
 	}
+	return providers
 }
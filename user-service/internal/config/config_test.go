@@ -0,0 +1,60 @@
+package config
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParseRateLimitRule(t *testing.T) {
+	tests := []struct {
+		value   string
+		want    RateLimitRule
+		wantErr bool
+	}{
+		{"60/1m", RateLimitRule{Requests: 60, Window: time.Minute}, false},
+		{" 5/1h ", RateLimitRule{Requests: 5, Window: time.Hour}, false},
+		{"60/1min", RateLimitRule{}, true},
+		{"60", RateLimitRule{}, true},
+		{"abc/1m", RateLimitRule{}, true},
+		{"0/1m", RateLimitRule{}, true},
+		{"-1/1m", RateLimitRule{}, true},
+		{"5/0s", RateLimitRule{}, true},
+		{"", RateLimitRule{}, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.value, func(t *testing.T) {
+			got, err := parseRateLimitRule(tt.value)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("err = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("rule = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRateLimitDefaults(t *testing.T) {
+	if got := loadRateLimitDefault(""); got != (RateLimitRule{Requests: 60, Window: time.Minute}) {
+		t.Errorf("default = %+v, want 60/1m", got)
+	}
+
+	methods := loadRateLimitMethods("")
+	for _, name := range []string{"Register", "ForgotPassword", "UpdateUsername"} {
+		if r, ok := methods[name]; !ok || r.Requests <= 0 {
+			t.Errorf("%s has no default limit: %+v", name, r)
+		}
+	}
+
+	// Env dagi qiymat faqat o'z metodini almashtiradi, qolgan standartlar saqlanadi
+	methods = loadRateLimitMethods("Register=2/1h, Login=20/1m")
+	if got := methods["Register"]; got != (RateLimitRule{Requests: 2, Window: time.Hour}) {
+		t.Errorf("Register = %+v, want override 2/1h", got)
+	}
+	if got := methods["Login"]; got != (RateLimitRule{Requests: 20, Window: time.Minute}) {
+		t.Errorf("Login = %+v, want 20/1m", got)
+	}
+	if got := methods["ForgotPassword"]; got != (RateLimitRule{Requests: 3, Window: 15 * time.Minute}) {
+		t.Errorf("ForgotPassword = %+v, want default 3/15m", got)
+	}
+}
@@ -0,0 +1,51 @@
+package domain
+
+import (
+	"context"
+	"time"
+)
+
+// UserStatus — users.status ustuni
+type UserStatus string
+
+const (
+	UserStatusActive          UserStatus = "active"
+	UserStatusSuspended       UserStatus = "suspended" // suspended_until gacha (nil => muddatsiz)
+	UserStatusBanned          UserStatus = "banned"
+	UserStatusPendingDeletion UserStatus = "pending_deletion" // suspended_until da butunlay o'chiriladi
+)
+
+func (s UserStatus) Valid() bool {
+	switch s {
+	case UserStatusActive, UserStatusSuspended, UserStatusBanned, UserStatusPendingDeletion:
+		return true
+	}
+	return false
+}
+
+// Restriction — user hozir tizimga kira olmasa cheklov, aks holda nil.
+// Muddati o'tgan suspension faol holat hisoblanadi (status qatori keyingi o'zgarishgacha qoladi).
+func (u *User) Restriction(now time.Time) *AccountRestrictedError {
+	switch u.Status {
+	case UserStatusActive, "":
+		return nil
+	case UserStatusSuspended:
+		if u.SuspendedUntil != nil && !now.Before(*u.SuspendedUntil) {
+			return nil
+		}
+	}
+	r := &AccountRestrictedError{Status: u.Status, Until: u.SuspendedUntil}
+	if u.StatusReason != nil {
+		r.Reason = *u.StatusReason
+	}
+	return r
+}
+
+// AccountStatusCache — auth interceptor har so'rovda DB ga bormasligi uchun
+// cheklangan accountlar ro'yxati. Status o'zgarganda servis tomonidan yangilanadi.
+type AccountStatusCache interface {
+	// Restriction — keshda cheklov bo'lmasa nil, nil
+	Restriction(ctx context.Context, userID string) (*AccountRestrictedError, error)
+	SetRestriction(ctx context.Context, userID string, r *AccountRestrictedError) error
+	ClearRestriction(ctx context.Context, userID string) error
+}
@@ -0,0 +1,123 @@
+package domain
+
+import (
+	"errors"
+	"strings"
+	"time"
+)
+
+// ======================
+// DOMAIN ERRORS
+// ======================
+var (
+	ErrSessionNotFound     = errors.New("session not found")
+	ErrInvalidRefreshToken = errors.New("invalid refresh token")
+	ErrInvalidResetToken   = errors.New("invalid or expired reset token")
+
+	ErrInvalidVerificationToken = errors.New("invalid or expired verification token")
+	ErrEmailAlreadyVerified     = errors.New("email already verified")
+	ErrEmailTaken               = errors.New("email already registered")
+	ErrUsernameTaken            = errors.New("username already taken")
+	ErrUsernameChangeTooSoon    = errors.New("username was changed recently, try again later")
+	ErrTooManyRequests          = errors.New("too many requests, try again later")
+
+	ErrInvalidLoginCode    = errors.New("invalid or expired login code")
+	ErrInvalidMFAToken     = errors.New("invalid or expired mfa token")
+	ErrInvalidMFACode      = errors.New("invalid verification code")
+	ErrTOTPAlreadyEnabled  = errors.New("two-factor authentication already enabled")
+	ErrTOTPNotEnabled      = errors.New("two-factor authentication is not enabled")
+	ErrTOTPSetupNotStarted = errors.New("two-factor authentication setup not started")
+
+	ErrUnknownProvider    = errors.New("unknown identity provider")
+	ErrProviderAuthFailed = errors.New("identity provider authentication failed")
+	ErrIdentityNotFound   = errors.New("identity not found")
+	ErrIdentityLinked     = errors.New("identity already linked to another account")
+	ErrProviderLinked     = errors.New("provider already linked to this account")
+	ErrLastLoginMethod    = errors.New("cannot remove the only remaining login method")
+	ErrAccountExists      = errors.New("an account with this email already exists, log in and link the provider")
+
+	ErrUserNotFound     = errors.New("user not found")
+	ErrPermissionDenied = errors.New("permission denied")
+	ErrSelfAction       = errors.New("this action cannot be performed on your own account")
+	ErrInvalidRole      = errors.New("invalid role")
+	ErrInvalidPageToken = errors.New("invalid page token")
+	ErrInvalidStatus    = errors.New("invalid status")
+
+	ErrInvalidPassword = errors.New("invalid password")
+	ErrPasswordNotSet  = errors.New("password login is not set up for this account")
+
+	ErrPresenceWatchLagging = errors.New("presence subscription fell behind, resubscribe")
+
+	// ErrServiceUnavailable — Redis/DB kabi infratuzilma javob bermadi; "token yaroqsiz" bilan adashtirilmasin
+	ErrServiceUnavailable = errors.New("service temporarily unavailable")
+)
+
+// RefreshTokenReuseError — allaqachon almashtirilgan (iste'mol qilingan) refresh token
+// qayta taqdim etilganda qaytadi. Bu vaqtda token oilasi (sessiya) butunlay bekor qilingan bo'ladi.
+type RefreshTokenReuseError struct {
+	UserID    string
+	SessionID string
+}
+
+func (e *RefreshTokenReuseError) Error() string {
+	return "refresh token reuse detected"
+}
+
+// AccountLockedError — juda ko'p muvaffaqiyatsiz login urinishlaridan keyin vaqtincha bloklash
+type AccountLockedError struct {
+	RetryAfter time.Duration
+}
+
+func (e *AccountLockedError) Error() string {
+	return "account temporarily locked due to too many failed login attempts"
+}
+
+// AccountRestrictedError — account suspended, banned yoki o'chirilish navbatida bo'lgani uchun
+// login, refresh va himoyalangan RPC lar rad etiladi (klient suspension ekranini ko'rsatadi)
+type AccountRestrictedError struct {
+	Status UserStatus
+	Reason string
+	Until  *time.Time // vaqtinchalik suspension tugashi yoki pending_deletion uchun o'chirish vaqti
+}
+
+func (e *AccountRestrictedError) Error() string {
+	switch e.Status {
+	case UserStatusBanned:
+		return "account banned"
+	case UserStatusPendingDeletion:
+		return "account scheduled for deletion"
+	}
+	if e.Until != nil {
+		return "account suspended until " + e.Until.UTC().Format(time.RFC3339)
+	}
+	return "account suspended"
+}
+
+// ReauthenticationRequiredError — nozik amal uchun sessiyada so'nggi MaxAge ichida
+// parol yoki TOTP tasdiqlanmagan; klient Reauthenticate chaqirib, so'rovni takrorlaydi
+type ReauthenticationRequiredError struct {
+	MaxAge time.Duration
+}
+
+func (e *ReauthenticationRequiredError) Error() string {
+	return "recent authentication required"
+}
+
+// FieldViolation — bitta maydon bo'yicha buzilgan qoida
+type FieldViolation struct {
+	Field       string
+	Description string
+}
+
+// ValidationError — kiritilgan ma'lumotlar bir nechta qoidani buzganda (hammasi birdan qaytariladi)
+type ValidationError struct {
+	Violations []FieldViolation
+}
+
+func (e *ValidationError) Error() string {
+	msgs := make([]string, len(e.Violations))
+	for i, v := range e.Violations {
+		msgs[i] = v.Field + ": " + v.Description
+	}
+	return "invalid argument: " + strings.Join(msgs, "; ")
+}
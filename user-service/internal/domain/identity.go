@@ -0,0 +1,44 @@
+package domain
+
+import (
+	"context"
+	"time"
+)
+
+// ======================
+// EXTERNAL IDENTITIES (OAuth2 / OIDC)
+// ======================
+
+// UserIdentity — users qatoriga bog'langan tashqi hisob (bitta userda bir nechta provayder)
+type UserIdentity struct {
+	ID        string
+	UserID    string
+	Provider  string
+	Subject   string
+	Email     string
+	CreatedAt time.Time
+}
+
+// ExternalIdentity — provayder tasdiqlagan foydalanuvchi ma'lumotlari
+type ExternalIdentity struct {
+	Provider      string
+	Subject       string
+	Email         string
+	EmailVerified bool
+	Name          string
+	AvatarURL     string
+}
+
+// ProviderAuthRequest — klient provayderdan olgan authorization code
+type ProviderAuthRequest struct {
+	Provider     string
+	Code         string
+	RedirectURI  string
+	CodeVerifier string // PKCE
+	Nonce        string
+}
+
+// IdentityProvider — authorization code ni tasdiqlangan tashqi identity ga almashtiradi
+type IdentityProvider interface {
+	Exchange(ctx context.Context, req ProviderAuthRequest) (*ExternalIdentity, error)
+}
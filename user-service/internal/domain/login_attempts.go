@@ -0,0 +1,41 @@
+package domain
+
+import (
+	"context"
+	"time"
+)
+
+// LockoutPolicy — muvaffaqiyatsiz urinishlar chegarasi va bloklash muddati.
+// MaxFailures ga yetgach har bir keyingi xato bloklash muddatini ikki baravar oshiradi
+// (BaseLockout, 2*BaseLockout, ... MaxLockout gacha).
+type LockoutPolicy struct {
+	MaxFailures   int
+	FailureWindow time.Duration // hisoblagich oxirgi xatodan keyin shuncha vaqt saqlanadi
+	BaseLockout   time.Duration
+	MaxLockout    time.Duration
+}
+
+// LockoutFor — failures-chi xatodan keyingi bloklash muddati (0 => bloklanmaydi)
+func (p LockoutPolicy) LockoutFor(failures int) time.Duration {
+	if p.MaxFailures <= 0 || failures < p.MaxFailures {
+		return 0
+	}
+	d := p.BaseLockout
+	for i := p.MaxFailures; i < failures && d < p.MaxLockout; i++ {
+		d *= 2
+	}
+	if p.MaxLockout > 0 && d > p.MaxLockout {
+		d = p.MaxLockout
+	}
+	return d
+}
+
+// LoginAttemptTracker — kalit (email yoki IP) bo'yicha login xatolarini hisoblaydi va bloklaydi
+type LoginAttemptTracker interface {
+	// LockedFor — kalit bloklangan bo'lsa qolgan vaqt, aks holda 0
+	LockedFor(ctx context.Context, key string) (time.Duration, error)
+	// RecordFailure — xatoni hisoblaydi; chegaradan oshsa kalitni bloklaydi
+	RecordFailure(ctx context.Context, key string, policy LockoutPolicy) (failures int, lockedFor time.Duration, err error)
+	// Reset — muvaffaqiyatli logindan keyin hisoblagichni tozalaydi
+	Reset(ctx context.Context, key string) error
+}
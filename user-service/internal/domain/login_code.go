@@ -0,0 +1,15 @@
+package domain
+
+import (
+	"context"
+	"time"
+)
+
+// LoginCodeStore — parolsiz kirish uchun bir martalik kodlar (faqat hash saqlanadi)
+type LoginCodeStore interface {
+	// Save — email uchun yangi kod saqlaydi, oldingisi bekor bo'ladi
+	Save(ctx context.Context, email, codeHash string, ttl time.Duration) error
+	// Verify — kod to'g'ri bo'lsa uni o'chiradi va true qaytaradi. Noto'g'ri urinishlar
+	// maxAttempts ga yetganda kod ham o'chiriladi.
+	Verify(ctx context.Context, email, codeHash string, maxAttempts int) (bool, error)
+}
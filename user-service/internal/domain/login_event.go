@@ -0,0 +1,66 @@
+package domain
+
+import (
+	"context"
+	"time"
+)
+
+// LoginOutcome — login_events.outcome
+type LoginOutcome string
+
+const (
+	LoginSuccess     LoginOutcome = "success"
+	LoginMFARequired LoginOutcome = "mfa_required" // birinchi faktor o'tdi, VerifyMFA kutilmoqda
+	LoginFailure     LoginOutcome = "failure"
+	LoginLocked      LoginOutcome = "locked"  // brute-force lockout
+	LoginBlocked     LoginOutcome = "blocked" // suspended/banned account
+)
+
+// LoginEvent — login_events qatori. UserID noma'lum email bilan urinishlarda bo'sh.
+type LoginEvent struct {
+	ID            string
+	UserID        string
+	Email         string
+	Method        string // password, login_code, mfa, register, provider:<name>
+	Outcome       LoginOutcome
+	FailureReason string
+	IPAddress     string
+	UserAgent     string
+	Location      string // "Country, City"
+	CountryCode   string
+	Platform      string
+	DeviceID      string
+	CreatedAt     time.Time
+}
+
+// LoginHistoryPage — GetLoginHistory natijasi; NextPageToken bo'sh => oxirgi sahifa
+type LoginHistoryPage struct {
+	Events        []LoginEvent
+	NextPageToken string
+}
+
+// KnownLoginContext — userning oldingi muvaffaqiyatli loginlari bo'yicha
+type KnownLoginContext struct {
+	HasHistory  bool
+	DeviceSeen  bool
+	CountrySeen bool
+}
+
+// GeoLocation — IP manzil bo'yicha taxminiy joylashuv
+type GeoLocation struct {
+	Country     string
+	CountryCode string
+	City        string
+}
+
+func (l *GeoLocation) String() string {
+	if l.City == "" {
+		return l.Country
+	}
+	return l.Country + ", " + l.City
+}
+
+// GeoLocator — IP => joylashuv. Aniqlab bo'lmasa (lokal/xususiy IP) nil, nil.
+type GeoLocator interface {
+	Locate(ctx context.Context, ip string) (*GeoLocation, error)
+}
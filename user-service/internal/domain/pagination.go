@@ -0,0 +1,16 @@
+package domain
+
+import "time"
+
+// PageCursor — keyset pagination pozitsiyasi: (vaqt DESC, id DESC) tartibida oxirgi qaytarilgan qator.
+// ListUsers da registered_at, login tarixida created_at.
+type PageCursor struct {
+	At time.Time
+	ID string
+}
+
+// SearchCursor — qidiruvda (rank DESC, id DESC) tartibidagi oxirgi qator
+type SearchCursor struct {
+	Rank float32
+	ID   string
+}
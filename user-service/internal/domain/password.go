@@ -0,0 +1,16 @@
+package domain
+
+import "context"
+
+// PasswordHasher — parol hashlash algoritmi (standart: Argon2id, PHC formatida)
+type PasswordHasher interface {
+	Hash(password string) (string, error)
+	// Verify — parol mos kelsa ok=true; needsRehash=true bo'lsa hash eski algoritm
+	// yoki eski parametrlar bilan yaratilgan va joriy sozlamalar bilan qayta hashlanishi kerak
+	Verify(password, encoded string) (ok, needsRehash bool, err error)
+}
+
+// BreachedPasswordChecker — parol ma'lum sizib chiqqan parollar ro'yxatida bormi
+type BreachedPasswordChecker interface {
+	IsBreached(ctx context.Context, password string) (bool, error)
+}
@@ -0,0 +1,65 @@
+package domain
+
+import (
+	"context"
+	"time"
+)
+
+// Presence — WatchPresence obunachilariga yuboriladigan holat
+type Presence struct {
+	UserID   string
+	Online   bool
+	LastSeen *time.Time // nil => hech qachon ko'rilmagan yoki yashirilgan
+	Hidden   bool       // egasi "hide last seen" ni yoqqan
+}
+
+// NewPresence — "hide last seen" yoqilgan bo'lsa online holati ham, oxirgi ko'rilgan vaqt ham berilmaydi
+func NewPresence(userID string, online bool, lastSeen *time.Time, hideLastSeen bool) Presence {
+	if hideLastSeen {
+		return Presence{UserID: userID, Hidden: true}
+	}
+	return Presence{UserID: userID, Online: online, LastSeen: lastSeen}
+}
+
+// PresenceRecord — Postgres dagi oxirgi ko'rilgan vaqt va maxfiylik sozlamasi
+type PresenceRecord struct {
+	UserID       string
+	LastSeenAt   *time.Time
+	HideLastSeen bool
+}
+
+// DeviceExpiry — heartbeat TTL i o'tib, userning oxirgi qurilmasi bo'lgani uchun offline bo'lgan qurilma
+type DeviceExpiry struct {
+	UserID   string
+	DeviceID string
+	LastSeen time.Time // oxirgi heartbeat
+}
+
+// PresenceStore — qurilma heartbeatlari (TTL bilan) va replikalar orasidagi pub/sub
+type PresenceStore interface {
+	// Heartbeat — user offline dan online ga o'tgan bo'lsa true
+	Heartbeat(ctx context.Context, userID, deviceID string) (cameOnline bool, err error)
+	// Disconnect — qurilma userning oxirgi online qurilmasi bo'lsa true
+	Disconnect(ctx context.Context, userID, deviceID string) (wentOffline bool, err error)
+	// ExpireDevices — TTL i o'tgan qurilmalarni atomar olib tashlaydi (har birini faqat bitta replika oladi);
+	// faqat offline bo'lib qolgan userlar qaytariladi
+	ExpireDevices(ctx context.Context, limit int) ([]DeviceExpiry, error)
+	Online(ctx context.Context, userIDs []string) (map[string]bool, error)
+
+	Publish(ctx context.Context, p Presence) error
+	// Subscribe — ctx tugaganda kanal yopiladi
+	Subscribe(ctx context.Context) (<-chan Presence, error)
+}
+
+// PresenceService — heartbeat, obuna va offline bo'lganda last seen ni saqlash
+type PresenceService interface {
+	// Heartbeat — offline=true ilova yopilayotganda: qurilma darhol offline bo'ladi.
+	// Qaytgan interval — keyingi heartbeatgacha kutish vaqti
+	Heartbeat(ctx context.Context, userID, deviceID string, offline bool) (interval time.Duration, err error)
+	// Watch — avval joriy holatlar, keyin o'zgarishlar send ga beriladi; ctx tugaguncha bloklanadi
+	Watch(ctx context.Context, userIDs []string, send func(Presence) error) error
+	// Refresh — maxfiylik sozlamasi o'zgarganda obunachilarga yangi holatni yuboradi
+	Refresh(ctx context.Context, userID string) error
+	// Run — pub/sub tinglovchi va TTL tozalovchi; ctx tugaguncha ishlaydi
+	Run(ctx context.Context) error
+}
@@ -0,0 +1,81 @@
+package domain
+
+import "context"
+
+// PublicProfile — boshqa foydalanuvchilarga ko'rsatiladigan maydonlar.
+// Email, IP, qurilma va joylashuv bu turga umuman kirmaydi; faqat NewPublicProfile orqali yaratiladi.
+type PublicProfile struct {
+	ID        string
+	Username  string
+	FullName  *string
+	AvatarURL *string
+}
+
+// NewPublicProfile — ixtiyoriy maydonlar egasining maxfiylik sozlamalariga ko'ra yashiriladi
+func NewPublicProfile(id, username string, fullName, avatarURL *string, privacy PrivacySettings) PublicProfile {
+	p := PublicProfile{ID: id, Username: username}
+	if privacy.ShowFullName {
+		p.FullName = fullName
+	}
+	if privacy.ShowAvatar {
+		p.AvatarURL = avatarURL
+	}
+	return p
+}
+
+// PublicProfile — userning boshqalarga ko'rinadigan proyeksiyasi
+func (u *User) PublicProfile() PublicProfile {
+	var username string
+	if u.Username != nil {
+		username = *u.Username
+	}
+	return NewPublicProfile(u.ID, username, u.FullName, u.AvatarURL, u.Privacy)
+}
+
+// PrivacySettings — foydalanuvchi boshqalarga nimani ko'rsatishini boshqaradi
+type PrivacySettings struct {
+	Discoverable bool // false => SearchUsers natijalarida chiqmaydi
+	ShowFullName bool
+	ShowAvatar   bool
+	HideLastSeen bool // true => online holati va oxirgi ko'rilgan vaqt boshqalarga berilmaydi
+}
+
+// PrivacySettingsUpdate — nil maydonlar o'zgarmaydi
+type PrivacySettingsUpdate struct {
+	Discoverable *bool
+	ShowFullName *bool
+	ShowAvatar   *bool
+	HideLastSeen *bool
+}
+
+// ProfileLookup — GetUserProfile uchun: ID yoki username dan bittasi
+type ProfileLookup struct {
+	ID       string
+	Username string
+}
+
+// UserSearchHit — qidiruv natijasi va uning tartiblash bahosi (cursor uchun)
+type UserSearchHit struct {
+	Profile PublicProfile
+	Rank    float32
+}
+
+type UserSearchPage struct {
+	Profiles      []PublicProfile
+	NextPageToken string
+}
+
+// BatchProfiles — so'rovdagi tartibda topilgan profillar; topilmagan (yoki noto'g'ri) IDlar alohida
+type BatchProfiles struct {
+	Profiles   []PublicProfile
+	MissingIDs []string
+}
+
+// ProfileCache — PublicProfile uchun read-through kesh.
+// Profil maydonlari o'zgarganda Invalidate chaqiriladi.
+type ProfileCache interface {
+	// GetProfiles — keshda yo'q IDlar natijaga kirmaydi
+	GetProfiles(ctx context.Context, ids []string) (map[string]PublicProfile, error)
+	SetProfiles(ctx context.Context, profiles []PublicProfile) error
+	Invalidate(ctx context.Context, userIDs ...string) error
+}
@@ -0,0 +1,12 @@
+package domain
+
+import (
+	"context"
+	"time"
+)
+
+// RateLimiter — kalit bo'yicha belgilangan vaqt oynasida so'rovlar sonini cheklaydi
+type RateLimiter interface {
+	// Allow — so'rov ruxsat etilsa true; aks holda qancha kutish kerakligini qaytaradi
+	Allow(ctx context.Context, key string, limit int, window time.Duration) (allowed bool, retryAfter time.Duration, err error)
+}
@@ -0,0 +1,105 @@
+package domain
+
+import (
+	"context"
+	"time"
+)
+
+// ======================
+// ROLES & PERMISSIONS
+// ======================
+
+// Role — users.role ustuni. Rollar ierarxik: user < moderator < admin.
+type Role string
+
+const (
+	RoleUser      Role = "user"
+	RoleModerator Role = "moderator"
+	RoleAdmin     Role = "admin"
+)
+
+// Permission — admin RPC lari uchun ruxsatlar (rollarga kod ichida biriktirilgan)
+type Permission string
+
+const (
+	PermUsersRead      Permission = "users:read"
+	PermUsersSuspend   Permission = "users:suspend"
+	PermUsersBan       Permission = "users:ban"
+	PermImpersonate    Permission = "users:impersonate"
+	PermSessionsRevoke Permission = "sessions:revoke"
+	PermRolesManage    Permission = "roles:manage"
+)
+
+var rolePermissions = map[Role][]Permission{
+	RoleModerator: {PermUsersRead, PermUsersSuspend, PermSessionsRevoke},
+	RoleAdmin:     {PermUsersRead, PermUsersSuspend, PermUsersBan, PermImpersonate, PermSessionsRevoke, PermRolesManage},
+}
+
+var roleRank = map[Role]int{RoleUser: 1, RoleModerator: 2, RoleAdmin: 3}
+
+func (r Role) Valid() bool {
+	_, ok := roleRank[r]
+	return ok
+}
+
+func (r Role) Can(p Permission) bool {
+	for _, perm := range rolePermissions[r] {
+		if perm == p {
+			return true
+		}
+	}
+	return false
+}
+
+// Outranks — r o'zidan past rolga ega userlar ustida amal bajara oladi
+func (r Role) Outranks(other Role) bool {
+	return roleRank[r] > roleRank[other]
+}
+
+// ======================
+// ADMIN
+// ======================
+
+// UserFilter — ListUsers filtrlari (bo'sh maydonlar hisobga olinmaydi)
+type UserFilter struct {
+	Query  string // username yoki email bo'yicha qism-qidiruv
+	Role   Role
+	Status UserStatus
+}
+
+// UserPage — ListUsers natijasi; NextPageToken bo'sh => oxirgi sahifa
+type UserPage struct {
+	Users         []User
+	NextPageToken string
+}
+
+// AuditEntry — admin_audit_log qatori
+type AuditEntry struct {
+	ID           string
+	ActorID      string
+	Action       string
+	TargetUserID string
+	Details      map[string]string
+	CreatedAt    time.Time
+}
+
+// Impersonation — support xodimi user nomidan ishlashi uchun berilgan token
+type Impersonation struct {
+	AccessToken string
+	ExpiresAt   time.Time
+	User        *User
+}
+
+// AdminService — support tooling uchun; har bir amal aktyor roli bo'yicha tekshiriladi va auditga yoziladi
+type AdminService interface {
+	ListUsers(ctx context.Context, actorID string, filter UserFilter, pageSize int, pageToken string) (*UserPage, error)
+	GetUser(ctx context.Context, actorID, userID string) (*User, error)
+	// SuspendUser — until nil => muddatsiz
+	SuspendUser(ctx context.Context, actorID, userID, reason string, until *time.Time) (*User, error)
+	BanUser(ctx context.Context, actorID, userID, reason string) (*User, error)
+	// UnsuspendUser — suspension yoki banni bekor qilib, statusni active ga qaytaradi
+	UnsuspendUser(ctx context.Context, actorID, userID string) (*User, error)
+	ForceLogout(ctx context.Context, actorID, userID string) error
+	SetRole(ctx context.Context, actorID, userID string, role Role) (*User, error)
+	Impersonate(ctx context.Context, actorID, userID, reason string) (*Impersonation, error)
+}
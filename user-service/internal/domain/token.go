@@ -1,9 +1,78 @@
 package domain
 
+import "time"
+
 // TokenProvider — JWT yoki boshqa token generatsiya qiluvchi abstraksiya
 type TokenProvider interface {
-	GenerateTokens(userID string) (string, string, error)
-	RevokeRefreshToken(tokenStr string) error 
-	ValidateAccessToken(tokenStr string) (userID string, err error)
-	ValidateRefreshToken(tokenStr string) (string, error)
+	// GenerateTokens — roles access token ichiga "roles" claim sifatida yoziladi
+	GenerateTokens(userID, sessionID string, roles []string) (*TokenPair, error)
+	RevokeRefreshToken(tokenStr string) error
+	RevokeRefreshJTI(jti string) error
+	ValidateAccessToken(tokenStr string) (*AccessClaims, error)
+	RevokeSessionAccessTokens(sessionID string) error
+	RevokeUserAccessTokens(userID string) error
+	ValidateRefreshToken(tokenStr string) (*RefreshClaims, error)
+
+	// ConsumeRefreshToken — taqdim etilgan refresh tokenni bir martalik iste'mol qiladi;
+	// chaqiruvchi shu sessiya uchun GenerateTokens bilan yangi juftlik beradi (rollar DB dan olinadi).
+	// Ishlatilgan token qayta kelsa butun oila bekor qilinadi va *RefreshTokenReuseError qaytadi.
+	ConsumeRefreshToken(tokenStr string) (*RefreshClaims, error)
+
+	// GenerateImpersonationToken — support xodimi uchun qisqa muddatli access token:
+	// sub = userID, act = actorID. Refresh token berilmaydi.
+	GenerateImpersonationToken(userID, actorID string, ttl time.Duration) (token string, expiresAt time.Time, err error)
+
+	// JWKS — access tokenlarni tekshirish uchun ochiq kalitlar
+	JWKS() []JWK
+
+	// MFA challenge — parol tekshirilgandan keyin, ikkinchi faktor kutilayotgan holat
+	GenerateMFAToken(challenge MFAChallenge) (string, error)
+	ValidateMFAToken(tokenStr string) (*MFAChallenge, error)
+	RevokeMFAToken(jti string) error
+}
+
+// MFAChallenge — Login da boshlangan va VerifyMFA da yakunlanadigan kirish urinishi
+type MFAChallenge struct {
+	UserID   string
+	JTI      string
+	Platform string
+	DeviceID string
+}
+
+// TokenPair — bitta sessiyaga bog'langan access/refresh tokenlar
+type TokenPair struct {
+	AccessToken  string
+	RefreshToken string
+	RefreshJTI   string
+}
+
+// AccessClaims — access tokendan olingan ma'lumotlar
+type AccessClaims struct {
+	UserID    string
+	SessionID string
+	JTI       string
+	Roles     []string
+	ActorID   string // bo'sh bo'lmasa — impersonatsiya tokeni (act claimi)
+	IssuedAt  time.Time
+	ExpiresAt time.Time
+}
+
+// RefreshClaims — refresh tokendan olingan ma'lumotlar.
+// Token oilasi sessiyaga teng: bitta sessiya ichidagi barcha almashtirishlar bitta oila.
+type RefreshClaims struct {
+	UserID    string
+	SessionID string
+	JTI       string
+}
+
+// JWK — boshqa servislar access tokenlarni offline tekshirishi uchun ochiq kalit (RFC 7517)
+type JWK struct {
+	Kty string `json:"kty"`
+	Kid string `json:"kid"`
+	Use string `json:"use"`
+	Alg string `json:"alg"`
+	N   string `json:"n,omitempty"`
+	E   string `json:"e,omitempty"`
+	Crv string `json:"crv,omitempty"`
+	X   string `json:"x,omitempty"`
 }
@@ -9,20 +9,39 @@ import (
 // ENTITY
 // ======================
 type User struct {
-	ID            string
-	Username      *string
-	Email         *string
-	PasswordHash  string
-	FullName      *string
-	AvatarURL     *string
-	Language      *string
-	Platform      string
-	DeviceID      string
-	RegisteredIP  *string
-	UserAgent     string
-	Location      *string
-	RegisteredAt  time.Time
-	UpdatedAt     time.Time
+	ID                string
+	Username          *string
+	Email             *string
+	PasswordHash      string
+	FullName          *string
+	AvatarURL         *string
+	Language          *string
+	Platform          string
+	DeviceID          string
+	RegisteredIP      *string
+	UserAgent         string
+	Location          *string
+	EmailVerifiedAt   *time.Time
+	PendingEmail      *string // tasdiqlanishini kutayotgan yangi email
+	TOTPSecret        *string
+	TOTPEnabledAt     *time.Time // nil => 2FA o'chiq
+	Role              Role
+	Status            UserStatus
+	StatusReason      *string
+	SuspendedUntil    *time.Time // suspended: tugash vaqti (nil => muddatsiz); pending_deletion: o'chirish vaqti
+	UsernameChangedAt *time.Time
+	Privacy           PrivacySettings
+	LastSeenAt        *time.Time // oxirgi marta offline bo'lgan payt (online holat Redis da)
+	RegisteredAt      time.Time
+	UpdatedAt         time.Time
+}
+
+// Roles — access token "roles" claimi uchun
+func (u *User) Roles() []string {
+	if u.Role == "" {
+		return nil
+	}
+	return []string{string(u.Role)}
 }
 
 // ======================
@@ -35,14 +54,78 @@ type UserRepository interface {
 
 	// Update one specific field
 	UpdateField(ctx context.Context, userID string, field string, value *string) error
+	UpdateUsername(ctx context.Context, userID, username string) error
+	// TakenUsernames — registrga befarq; kalitlar kichik harfda
+	TakenUsernames(ctx context.Context, candidates []string) (map[string]bool, error)
+
+	UpdatePrivacySettings(ctx context.Context, userID string, settings PrivacySettings) error
+
+	// Directory search — faqat PublicProfile maydonlari o'qiladi
+	SearchUsers(ctx context.Context, query, excludeUserID string, limit int, after *SearchCursor) ([]UserSearchHit, error)
+	GetByUsername(ctx context.Context, username string) (*User, error)
+	// GetPublicProfiles — bitta so'rov; topilmagan va cheklangan (suspended/banned) IDlar natijada bo'lmaydi
+	GetPublicProfiles(ctx context.Context, ids []string) ([]PublicProfile, error)
 
 	ChangePassword(ctx context.Context, id, newHash string) error
 	ResetPassword(ctx context.Context, id, newHash string) error
-	Delete(ctx context.Context, id string) error
+	// RehashPassword — hash faqat oldHash o'zgarmagan bo'lsa yangilanadi (parallel parol o'zgarishini yo'qotmaslik uchun)
+	RehashPassword(ctx context.Context, id, oldHash, newHash string) error
+	// ScheduleDeletion — status pending_deletion ga o'tadi, purgeAt suspended_until ga yoziladi
+	ScheduleDeletion(ctx context.Context, id string, purgeAt time.Time) error
+	// PurgeDeletedAccounts — muddati o'tgan pending_deletion accountlarni o'chiradi (ko'pi bilan limit ta)
+	PurgeDeletedAccounts(ctx context.Context, limit int) ([]string, error)
+
+	// Password reset tokens (faqat hash saqlanadi)
+	CreatePasswordResetToken(ctx context.Context, email, tokenHash string, expiresAt time.Time) error
+	ConsumePasswordResetToken(ctx context.Context, tokenHash string) (email string, err error)
+
+	// Email verification
+	SetPendingEmail(ctx context.Context, userID string, email *string) error
+	MarkEmailVerified(ctx context.Context, userID, email string) error
+	CreateEmailVerificationToken(ctx context.Context, userID, email, tokenHash string, expiresAt time.Time) error
+	ConsumeEmailVerificationToken(ctx context.Context, tokenHash string) (userID, email string, err error)
+
+	// Two-factor auth
+	SetTOTPSecret(ctx context.Context, userID, secret string) error
+	EnableTOTP(ctx context.Context, userID string, recoveryCodeHashes []string) error
+	DisableTOTP(ctx context.Context, userID string) error
+	ConsumeRecoveryCode(ctx context.Context, userID, codeHash string) (bool, error)
+
+	// External identities (OAuth2 / OIDC)
+	CreateUserWithIdentity(ctx context.Context, u *User, identity *UserIdentity) error
+	CreateIdentity(ctx context.Context, identity *UserIdentity) error
+	GetIdentity(ctx context.Context, provider, subject string) (*UserIdentity, error)
+	ListIdentities(ctx context.Context, userID string) ([]UserIdentity, error)
+	DeleteIdentity(ctx context.Context, userID, provider string) error
+
+	// Admin
+	ListUsers(ctx context.Context, filter UserFilter, limit int, after *PageCursor) ([]User, error)
+	// SetRole va SetStatus — audit yozuvi o'zgarish bilan bitta tranzaksiyada saqlanadi
+	SetRole(ctx context.Context, userID string, role Role, audit *AuditEntry) error
+	// SetStatus — active ga qaytarilganda reason va until tozalanadi
+	SetStatus(ctx context.Context, userID string, status UserStatus, reason *string, until *time.Time, audit *AuditEntry) error
+	CreateAuditEntry(ctx context.Context, entry *AuditEntry) error
+
+	// Presence
+	SetLastSeen(ctx context.Context, userID, sessionID string, at time.Time) error
+	GetPresenceRecords(ctx context.Context, ids []string) ([]PresenceRecord, error)
+
+	// Login history
+	CreateLoginEvent(ctx context.Context, e *LoginEvent) error
+	ListLoginEvents(ctx context.Context, userID string, limit int, after *PageCursor) ([]LoginEvent, error)
+	// KnownLoginContext — deviceID/countryCode bo'sh bo'lsa mos maydon false qoladi
+	KnownLoginContext(ctx context.Context, userID, deviceID, countryCode string) (*KnownLoginContext, error)
 
 	// Session management
+	UpsertSession(ctx context.Context, s *Session) error
+	UpdateSessionRefresh(ctx context.Context, sessionID, refreshJTI, ipAddress, userAgent string) error
+	SetSessionAuthTime(ctx context.Context, sessionID string, authTime time.Time) error
+	GetSessionByID(ctx context.Context, sessionID string) (*Session, error)
+	GetSessionByDevice(ctx context.Context, userID, deviceID string) (*Session, error)
 	GetSessions(ctx context.Context, userID string) ([]Session, error)
 	DeleteSession(ctx context.Context, userID, deviceID string) error
+	DeleteSessionByID(ctx context.Context, sessionID string) error
+	DeleteOtherSessions(ctx context.Context, userID, keepSessionID string) error
 	DeleteAllSessions(ctx context.Context, userID string) error
 }
 
@@ -52,28 +135,64 @@ type UserRepository interface {
 type UserService interface {
 	// Auth
 	Register(ctx context.Context, req RegisterDTO) (*AuthResult, error)
-	Login(ctx context.Context, email, password, platform, deviceID string) (*AuthResult, error)
-	RefreshToken(ctx context.Context, refreshToken string) (*AuthResult, error)
-	Logout(ctx context.Context, userID, deviceID string) error
+	Login(ctx context.Context, req LoginDTO) (*AuthResult, error)
+	VerifyMFA(ctx context.Context, mfaToken, code, ipAddress, userAgent string) (*AuthResult, error)
+	RequestLoginCode(ctx context.Context, email string) error
+	VerifyLoginCode(ctx context.Context, req LoginCodeDTO) (*AuthResult, error)
+	LoginWithProvider(ctx context.Context, req ProviderLoginDTO) (*AuthResult, error)
+	RefreshToken(ctx context.Context, refreshToken, ipAddress, userAgent string) (*AuthResult, error)
+	Logout(ctx context.Context, userID, sessionID string) error
 
 	// Profile
 	GetProfile(ctx context.Context, userID string) (*User, error)
 	UpdateUsername(ctx context.Context, userID, username string) (*User, error)
-	UpdateEmail(ctx context.Context, userID, email string) (*User, error)
+	CheckUsernameAvailability(ctx context.Context, username string) (*UsernameAvailability, error)
+	UpdateEmail(ctx context.Context, userID, sessionID, email string) (*User, error)
 	UpdateFullName(ctx context.Context, userID, fullName string) (*User, error)
 	UpdateAvatar(ctx context.Context, userID, avatarURL string) (*User, error)
 	UpdateLanguage(ctx context.Context, userID, language string) (*User, error)
+	UpdatePrivacySettings(ctx context.Context, userID string, update PrivacySettingsUpdate) (*User, error)
+
+	// Directory
+	// GetUserProfile — boshqa userning ochiq profili (maxfiylik sozlamalari qo'llanadi)
+	GetUserProfile(ctx context.Context, lookup ProfileLookup) (*PublicProfile, error)
+	// SearchUsers — callerID natijalardan chiqariladi
+	SearchUsers(ctx context.Context, callerID, query string, pageSize int, pageToken string) (*UserSearchPage, error)
+	// BatchGetUsers — service-to-service (chat ro'yxatlari, bildirishnomalar)
+	BatchGetUsers(ctx context.Context, ids []string) (*BatchProfiles, error)
 
 	// Security
-	ChangePassword(ctx context.Context, userID, oldPassword, newPassword string) error
+	// Reauthenticate — parol yoki TOTP (tiklash kodi) bilan sessiyaning auth_time ini yangilaydi.
+	// validUntil — nozik amallar ruxsat etiladigan oxirgi vaqt (tekshiruv o'chiq bo'lsa nol)
+	Reauthenticate(ctx context.Context, userID, sessionID, password, totpCode string) (authTime, validUntil time.Time, err error)
+	ChangePassword(ctx context.Context, userID, sessionID, oldPassword, newPassword string) error
 	ForgotPassword(ctx context.Context, email string) error
 	ResetPassword(ctx context.Context, token, newPassword string) error
+	SendVerificationEmail(ctx context.Context, userID string) error
+	VerifyEmail(ctx context.Context, token string) error
+	EnableTOTP(ctx context.Context, userID string) (*TOTPSetup, error)
+	ConfirmTOTP(ctx context.Context, userID, code string) (recoveryCodes []string, err error)
+	DisableTOTP(ctx context.Context, userID, code string) error
+	LinkIdentity(ctx context.Context, userID string, req ProviderAuthRequest) (*UserIdentity, error)
+	UnlinkIdentity(ctx context.Context, userID, provider string) error
+	GetIdentities(ctx context.Context, userID string) ([]UserIdentity, error)
 
 	// Account
-	DeleteAccount(ctx context.Context, userID string) error
+	DeleteAccount(ctx context.Context, userID, sessionID string) error
+	// RunAccountPurge — o'chirish muddati o'tgan accountlarni davriy o'chiradi; ctx tugaguncha ishlaydi
+	RunAccountPurge(ctx context.Context) error
 
 	// Sessions
 	GetSessions(ctx context.Context, userID string) ([]Session, error)
+	GetLoginHistory(ctx context.Context, userID string, pageSize int, pageToken string) (*LoginHistoryPage, error)
+	RevokeSession(ctx context.Context, userID, deviceID string) error
+	RevokeAllOtherSessions(ctx context.Context, userID, currentSessionID string) error
+
+	// Keys
+	GetJWKS(ctx context.Context) []JWK
+	// IntrospectToken — token yaroqsiz, bekor qilingan yoki account cheklangan bo'lsa nil, nil.
+	// Redis/DB ishlamasa ErrServiceUnavailable (token "inactive" deb keshlanmasligi uchun).
+	IntrospectToken(ctx context.Context, accessToken string) (*AccessClaims, error)
 }
 
 // ======================
@@ -90,17 +209,56 @@ type RegisterDTO struct {
 	DeviceID     string
 	RegisteredIP *string
 	UserAgent    string
-	Location     *string
+}
+
+type LoginDTO struct {
+	Email     string
+	Password  string
+	Platform  string
+	DeviceID  string
+	IPAddress string
+	UserAgent string
+}
+
+type LoginCodeDTO struct {
+	Email     string
+	Code      string
+	Platform  string
+	DeviceID  string
+	IPAddress string
+	UserAgent string
+}
+
+type ProviderLoginDTO struct {
+	Auth      ProviderAuthRequest
+	Platform  string
+	DeviceID  string
+	IPAddress string
+	UserAgent string
+	Language  *string
+}
+
+// UsernameAvailability — Available false bo'lsa Reason sababini, Suggestions bo'sh variantlarni beradi
+type UsernameAvailability struct {
+	Available   bool
+	Reason      string
+	Suggestions []string
 }
 
 // ======================
 // SESSION
 // ======================
 type Session struct {
-	DeviceID  string
-	Platform  string
-	IPAddress string
-	LastSeen  time.Time
+	ID         string
+	UserID     string
+	DeviceID   string
+	Platform   string
+	IPAddress  string
+	UserAgent  string
+	RefreshJTI string
+	CreatedAt  time.Time
+	LastSeen   time.Time
+	AuthTime   time.Time // oxirgi to'liq login yoki Reauthenticate; refresh uni yangilamaydi
 }
 
 // ======================
@@ -110,4 +268,16 @@ type AuthResult struct {
 	AccessToken  string
 	RefreshToken string
 	User         *User
+
+	// 2FA yoqilgan bo'lsa tokenlar o'rniga qisqa muddatli challenge token qaytadi
+	MFARequired bool
+	MFAToken    string
+}
+
+// ======================
+// TWO-FACTOR AUTH
+// ======================
+type TOTPSetup struct {
+	Secret     string
+	OTPAuthURI string
 }
@@ -0,0 +1,154 @@
+package grpc
+
+import (
+	"context"
+	"time"
+
+	"user-service/internal/domain"
+	"user-service/internal/utils"
+	userpb "user-service/protos/user"
+)
+
+type AdminServer struct {
+	userpb.UnimplementedAdminServiceServer
+	adminService domain.AdminService
+}
+
+func NewAdminServer(adminService domain.AdminService) *AdminServer {
+	return &AdminServer{
+		adminService: adminService,
+	}
+}
+
+// =====================
+// LIST USERS
+// =====================
+func (s *AdminServer) ListUsers(ctx context.Context, req *userpb.ListUsersRequest) (*userpb.ListUsersResponse, error) {
+	actorID, ok := utils.UserIDFromContext(ctx)
+	if !ok {
+		return nil, ErrUnauthenticated
+	}
+
+	filter := domain.UserFilter{
+		Query:  req.Query,
+		Role:   domain.Role(req.Role),
+		Status: domain.UserStatus(req.Status),
+	}
+	page, err := s.adminService.ListUsers(ctx, actorID, filter, int(req.PageSize), req.PageToken)
+	if err != nil {
+		return nil, toGRPCError(err)
+	}
+
+	users := make([]*userpb.User, len(page.Users))
+	for i := range page.Users {
+		users[i] = toUserPB(&page.Users[i])
+	}
+	return &userpb.ListUsersResponse{Users: users, NextPageToken: page.NextPageToken}, nil
+}
+
+// =====================
+// GET USER
+// =====================
+func (s *AdminServer) GetUser(ctx context.Context, req *userpb.AdminUserRequest) (*userpb.User, error) {
+	actorID, ok := utils.UserIDFromContext(ctx)
+	if !ok {
+		return nil, ErrUnauthenticated
+	}
+	user, err := s.adminService.GetUser(ctx, actorID, req.UserId)
+	if err != nil {
+		return nil, toGRPCError(err)
+	}
+	return toUserPB(user), nil
+}
+
+// =====================
+// SUSPEND / BAN / UNSUSPEND
+// =====================
+func (s *AdminServer) SuspendUser(ctx context.Context, req *userpb.SuspendUserRequest) (*userpb.User, error) {
+	actorID, ok := utils.UserIDFromContext(ctx)
+	if !ok {
+		return nil, ErrUnauthenticated
+	}
+	var until *time.Time
+	if req.Until != nil {
+		t := req.Until.AsTime()
+		until = &t
+	}
+	user, err := s.adminService.SuspendUser(ctx, actorID, req.UserId, req.Reason, until)
+	if err != nil {
+		return nil, toGRPCError(err)
+	}
+	return toUserPB(user), nil
+}
+
+func (s *AdminServer) BanUser(ctx context.Context, req *userpb.BanUserRequest) (*userpb.User, error) {
+	actorID, ok := utils.UserIDFromContext(ctx)
+	if !ok {
+		return nil, ErrUnauthenticated
+	}
+	user, err := s.adminService.BanUser(ctx, actorID, req.UserId, req.Reason)
+	if err != nil {
+		return nil, toGRPCError(err)
+	}
+	return toUserPB(user), nil
+}
+
+func (s *AdminServer) UnsuspendUser(ctx context.Context, req *userpb.AdminUserRequest) (*userpb.User, error) {
+	actorID, ok := utils.UserIDFromContext(ctx)
+	if !ok {
+		return nil, ErrUnauthenticated
+	}
+	user, err := s.adminService.UnsuspendUser(ctx, actorID, req.UserId)
+	if err != nil {
+		return nil, toGRPCError(err)
+	}
+	return toUserPB(user), nil
+}
+
+// =====================
+// FORCE LOGOUT
+// =====================
+func (s *AdminServer) ForceLogout(ctx context.Context, req *userpb.AdminUserRequest) (*userpb.Empty, error) {
+	actorID, ok := utils.UserIDFromContext(ctx)
+	if !ok {
+		return nil, ErrUnauthenticated
+	}
+	if err := s.adminService.ForceLogout(ctx, actorID, req.UserId); err != nil {
+		return nil, toGRPCError(err)
+	}
+	return &userpb.Empty{}, nil
+}
+
+// =====================
+// SET ROLE
+// =====================
+func (s *AdminServer) SetRole(ctx context.Context, req *userpb.SetRoleRequest) (*userpb.User, error) {
+	actorID, ok := utils.UserIDFromContext(ctx)
+	if !ok {
+		return nil, ErrUnauthenticated
+	}
+	user, err := s.adminService.SetRole(ctx, actorID, req.UserId, domain.Role(req.Role))
+	if err != nil {
+		return nil, toGRPCError(err)
+	}
+	return toUserPB(user), nil
+}
+
+// =====================
+// IMPERSONATE
+// =====================
+func (s *AdminServer) Impersonate(ctx context.Context, req *userpb.ImpersonateRequest) (*userpb.ImpersonateResponse, error) {
+	actorID, ok := utils.UserIDFromContext(ctx)
+	if !ok {
+		return nil, ErrUnauthenticated
+	}
+	imp, err := s.adminService.Impersonate(ctx, actorID, req.UserId, req.Reason)
+	if err != nil {
+		return nil, toGRPCError(err)
+	}
+	return &userpb.ImpersonateResponse{
+		AccessToken: imp.AccessToken,
+		ExpiresAt:   toProtoTime(imp.ExpiresAt),
+		User:        toUserPB(imp.User),
+	}, nil
+}
@@ -0,0 +1,139 @@
+package grpc
+
+import (
+	"errors"
+	"log"
+	"strconv"
+
+	"user-service/internal/domain"
+	"user-service/internal/middleware"
+
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/durationpb"
+)
+
+// toGRPCError — domain xatolarini mos gRPC status kodlariga o'giradi.
+// Tanilmagan xatolar o'zgarishsiz qaytariladi.
+func toGRPCError(err error) error {
+	var reuse *domain.RefreshTokenReuseError
+	var locked *domain.AccountLockedError
+	var invalid *domain.ValidationError
+	var restricted *domain.AccountRestrictedError
+	var reauth *domain.ReauthenticationRequiredError
+	switch {
+	case errors.As(err, &reauth):
+		return reauthenticationRequiredError(reauth)
+	case errors.As(err, &restricted):
+		return middleware.AccountRestrictedError(restricted)
+	case errors.As(err, &locked):
+		return accountLockedError(locked)
+	case errors.As(err, &invalid):
+		return validationError(invalid)
+	case errors.Is(err, domain.ErrSessionNotFound),
+		errors.Is(err, domain.ErrIdentityNotFound),
+		errors.Is(err, domain.ErrUserNotFound):
+		return status.Error(codes.NotFound, err.Error())
+	case errors.Is(err, domain.ErrPermissionDenied):
+		return status.Error(codes.PermissionDenied, err.Error())
+	case errors.Is(err, domain.ErrSelfAction):
+		return status.Error(codes.FailedPrecondition, err.Error())
+	case errors.Is(err, domain.ErrInvalidRole),
+		errors.Is(err, domain.ErrInvalidStatus),
+		errors.Is(err, domain.ErrInvalidPageToken):
+		return status.Error(codes.InvalidArgument, err.Error())
+	case errors.Is(err, domain.ErrUnknownProvider):
+		return status.Error(codes.InvalidArgument, err.Error())
+	case errors.Is(err, domain.ErrProviderAuthFailed):
+		// Provayder javobidagi tafsilotlar faqat logda qoladi
+		return status.Error(codes.Unauthenticated, domain.ErrProviderAuthFailed.Error())
+	case errors.Is(err, domain.ErrIdentityLinked),
+		errors.Is(err, domain.ErrProviderLinked),
+		errors.Is(err, domain.ErrAccountExists):
+		return status.Error(codes.AlreadyExists, err.Error())
+	case errors.Is(err, domain.ErrLastLoginMethod):
+		return status.Error(codes.FailedPrecondition, err.Error())
+	case errors.Is(err, domain.ErrInvalidResetToken),
+		errors.Is(err, domain.ErrInvalidVerificationToken):
+		return status.Error(codes.InvalidArgument, err.Error())
+	case errors.Is(err, domain.ErrEmailTaken),
+		errors.Is(err, domain.ErrUsernameTaken):
+		return status.Error(codes.AlreadyExists, err.Error())
+	case errors.Is(err, domain.ErrEmailAlreadyVerified),
+		errors.Is(err, domain.ErrTOTPAlreadyEnabled),
+		errors.Is(err, domain.ErrTOTPNotEnabled),
+		errors.Is(err, domain.ErrTOTPSetupNotStarted),
+		errors.Is(err, domain.ErrPasswordNotSet),
+		errors.Is(err, domain.ErrUsernameChangeTooSoon):
+		return status.Error(codes.FailedPrecondition, err.Error())
+	case errors.Is(err, domain.ErrInvalidMFACode),
+		errors.Is(err, domain.ErrInvalidPassword):
+		return status.Error(codes.InvalidArgument, err.Error())
+	case errors.Is(err, domain.ErrInvalidMFAToken),
+		errors.Is(err, domain.ErrInvalidLoginCode):
+		return status.Error(codes.Unauthenticated, err.Error())
+	case errors.Is(err, domain.ErrTooManyRequests):
+		return status.Error(codes.ResourceExhausted, err.Error())
+	case errors.Is(err, domain.ErrPresenceWatchLagging):
+		// Klient qayta obuna bo'ladi va yangi snapshot oladi
+		return status.Error(codes.Unavailable, err.Error())
+	case errors.Is(err, domain.ErrServiceUnavailable):
+		// Ichki tafsilotlar (Redis/DB xatosi) faqat logda qoladi
+		log.Println("dependency unavailable:", err)
+		return status.Error(codes.Unavailable, domain.ErrServiceUnavailable.Error())
+	case errors.Is(err, domain.ErrInvalidRefreshToken), errors.As(err, &reuse):
+		return status.Error(codes.Unauthenticated, err.Error())
+	}
+	return err
+}
+
+// accountLockedError — ResourceExhausted + RetryInfo (klient qancha kutishini biladi)
+// va ErrorInfo{Reason: ACCOUNT_LOCKED} (oddiy rate limitdan ajratish uchun)
+func accountLockedError(e *domain.AccountLockedError) error {
+	st := status.New(codes.ResourceExhausted, e.Error())
+	detailed, err := st.WithDetails(
+		&errdetails.ErrorInfo{
+			Reason:   "ACCOUNT_LOCKED",
+			Domain:   "user-service",
+			Metadata: map[string]string{"retry_after_seconds": strconv.Itoa(int(e.RetryAfter.Seconds()))},
+		},
+		&errdetails.RetryInfo{RetryDelay: durationpb.New(e.RetryAfter)},
+	)
+	if err != nil {
+		return st.Err()
+	}
+	return detailed.Err()
+}
+
+// validationError — InvalidArgument + BadRequest (har bir buzilgan qoida alohida FieldViolation)
+func validationError(e *domain.ValidationError) error {
+	br := &errdetails.BadRequest{}
+	for _, v := range e.Violations {
+		br.FieldViolations = append(br.FieldViolations, &errdetails.BadRequest_FieldViolation{
+			Field:       v.Field,
+			Description: v.Description,
+		})
+	}
+	st := status.New(codes.InvalidArgument, e.Error())
+	detailed, err := st.WithDetails(br)
+	if err != nil {
+		return st.Err()
+	}
+	return detailed.Err()
+}
+
+// reauthenticationRequiredError — PermissionDenied + ErrorInfo{Reason: REAUTHENTICATION_REQUIRED};
+// klient parol/TOTP so'rab Reauthenticate chaqiradi va asl so'rovni takrorlaydi
+func reauthenticationRequiredError(e *domain.ReauthenticationRequiredError) error {
+	st := status.New(codes.PermissionDenied, e.Error())
+	detailed, err := st.WithDetails(&errdetails.ErrorInfo{
+		Reason:   "REAUTHENTICATION_REQUIRED",
+		Domain:   "user-service",
+		Metadata: map[string]string{"max_age_seconds": strconv.Itoa(int(e.MaxAge.Seconds()))},
+	})
+	if err != nil {
+		return st.Err()
+	}
+	return detailed.Err()
+}
@@ -0,0 +1,83 @@
+package grpc
+
+import (
+	"user-service/internal/middleware"
+	userpb "user-service/protos/user"
+)
+
+// UserServicePolicy — UserService RPC lari uchun kirish siyosati.
+// Yangi RPC qo'shilganda shu yerga yozilishi shart: siyosatsiz metod bilan server ishga tushmaydi.
+// Owner — login usullari, parol, email, username, maxfiylik va sessiyalarni o'zgartiruvchi hamda
+// userga xat yuboruvchi metodlar (impersonatsiyada yopiq).
+var UserServicePolicy = middleware.Policy{
+	// Auth & Registration
+	userpb.UserService_Register_FullMethodName:          middleware.Public,
+	userpb.UserService_Login_FullMethodName:             middleware.Public,
+	userpb.UserService_RefreshToken_FullMethodName:      middleware.Public, // access token muddati o'tganda chaqiriladi
+	userpb.UserService_Logout_FullMethodName:            middleware.Authenticated,
+	userpb.UserService_VerifyMFA_FullMethodName:         middleware.Public,
+	userpb.UserService_RequestLoginCode_FullMethodName:  middleware.Public,
+	userpb.UserService_VerifyLoginCode_FullMethodName:   middleware.Public,
+	userpb.UserService_LoginWithProvider_FullMethodName: middleware.Public,
+
+	// Identities
+	userpb.UserService_LinkIdentity_FullMethodName:   middleware.Owner,
+	userpb.UserService_UnlinkIdentity_FullMethodName: middleware.Owner,
+	userpb.UserService_GetIdentities_FullMethodName:  middleware.Authenticated,
+
+	// Profile
+	userpb.UserService_GetProfile_FullMethodName:                middleware.Authenticated,
+	userpb.UserService_UpdateUsername_FullMethodName:            middleware.Owner,
+	userpb.UserService_CheckUsernameAvailability_FullMethodName: middleware.Public,
+	userpb.UserService_UpdateEmail_FullMethodName:               middleware.Owner,
+	userpb.UserService_UpdateFullName_FullMethodName:            middleware.Authenticated,
+	userpb.UserService_UpdateAvatar_FullMethodName:              middleware.Authenticated,
+	userpb.UserService_UpdateLanguage_FullMethodName:            middleware.Authenticated,
+	userpb.UserService_UpdatePrivacySettings_FullMethodName:     middleware.Owner,
+
+	// Directory
+	userpb.UserService_GetUserProfile_FullMethodName: middleware.Authenticated,
+	userpb.UserService_SearchUsers_FullMethodName:    middleware.Authenticated,
+
+	// Security
+	userpb.UserService_Reauthenticate_FullMethodName:        middleware.Owner,
+	userpb.UserService_ChangePassword_FullMethodName:        middleware.Owner,
+	userpb.UserService_ForgotPassword_FullMethodName:        middleware.Public,
+	userpb.UserService_ResetPassword_FullMethodName:         middleware.Public,
+	userpb.UserService_SendVerificationEmail_FullMethodName: middleware.Owner,
+	userpb.UserService_VerifyEmail_FullMethodName:           middleware.Public,
+	userpb.UserService_EnableTOTP_FullMethodName:            middleware.Owner,
+	userpb.UserService_ConfirmTOTP_FullMethodName:           middleware.Owner,
+	userpb.UserService_DisableTOTP_FullMethodName:           middleware.Owner,
+
+	// Account & Sessions
+	userpb.UserService_DeleteAccount_FullMethodName:          middleware.Owner,
+	userpb.UserService_GetSessions_FullMethodName:            middleware.Authenticated,
+	userpb.UserService_RevokeSession_FullMethodName:          middleware.Owner,
+	userpb.UserService_RevokeAllOtherSessions_FullMethodName: middleware.Owner,
+	userpb.UserService_GetLoginHistory_FullMethodName:        middleware.Authenticated,
+
+	// Presence (impersonatsiya userni online ko'rsatmasligi kerak)
+	userpb.UserService_Heartbeat_FullMethodName:     middleware.Owner,
+	userpb.UserService_WatchPresence_FullMethodName: middleware.Authenticated,
+
+	// Keys
+	userpb.UserService_GetJWKS_FullMethodName: middleware.Public,
+
+	// Service-to-service
+	userpb.UserService_IntrospectToken_FullMethodName: middleware.Service,
+	userpb.UserService_BatchGetUsers_FullMethodName:   middleware.Service,
+}
+
+// AdminServicePolicy — rol bo'yicha birinchi filtr; aniq ruxsatlar (domain.Permission)
+// servis qatlamida aktyorning DB dagi roli bo'yicha tekshiriladi
+var AdminServicePolicy = middleware.Policy{
+	userpb.AdminService_ListUsers_FullMethodName:     middleware.Staff,
+	userpb.AdminService_GetUser_FullMethodName:       middleware.Staff,
+	userpb.AdminService_SuspendUser_FullMethodName:   middleware.Staff,
+	userpb.AdminService_BanUser_FullMethodName:       middleware.Admin,
+	userpb.AdminService_UnsuspendUser_FullMethodName: middleware.Staff,
+	userpb.AdminService_ForceLogout_FullMethodName:   middleware.Staff,
+	userpb.AdminService_SetRole_FullMethodName:       middleware.Admin,
+	userpb.AdminService_Impersonate_FullMethodName:   middleware.Admin,
+}
@@ -0,0 +1,58 @@
+package grpc
+
+import (
+	"slices"
+	"testing"
+
+	"user-service/internal/middleware"
+	userpb "user-service/protos/user"
+)
+
+// TestImpersonationAllowedMethods — impersonatsiya tokeni qabul qilinadigan (Authenticated) metodlar
+// ro'yxati. Yangi metod shu ro'yxatga faqat ongli ravishda qo'shiladi: login usullari, parol, email,
+// username, maxfiylik, sessiyalar va userga xat yuboruvchi amallar Owner bo'lishi kerak.
+func TestImpersonationAllowedMethods(t *testing.T) {
+	want := []string{
+		userpb.UserService_GetIdentities_FullMethodName,
+		userpb.UserService_GetLoginHistory_FullMethodName,
+		userpb.UserService_GetProfile_FullMethodName,
+		userpb.UserService_GetSessions_FullMethodName,
+		userpb.UserService_GetUserProfile_FullMethodName,
+		userpb.UserService_Logout_FullMethodName,
+		userpb.UserService_SearchUsers_FullMethodName,
+		userpb.UserService_UpdateAvatar_FullMethodName,
+		userpb.UserService_UpdateFullName_FullMethodName,
+		userpb.UserService_UpdateLanguage_FullMethodName,
+		userpb.UserService_WatchPresence_FullMethodName,
+	}
+	slices.Sort(want)
+
+	var got []string
+	for _, policy := range []middleware.Policy{UserServicePolicy, AdminServicePolicy} {
+		for method, access := range policy {
+			if access == middleware.Authenticated {
+				got = append(got, method)
+			}
+		}
+	}
+	slices.Sort(got)
+
+	if !slices.Equal(got, want) {
+		t.Errorf("methods accepting impersonation tokens:\n got  %v\n want %v", got, want)
+	}
+}
+
+func TestOwnerOnlyMethods(t *testing.T) {
+	for _, method := range []string{
+		userpb.UserService_UpdateUsername_FullMethodName,
+		userpb.UserService_UpdatePrivacySettings_FullMethodName,
+		userpb.UserService_SendVerificationEmail_FullMethodName,
+		userpb.UserService_UpdateEmail_FullMethodName,
+		userpb.UserService_ChangePassword_FullMethodName,
+		userpb.UserService_DeleteAccount_FullMethodName,
+	} {
+		if got := UserServicePolicy[method]; got != middleware.Owner {
+			t.Errorf("%s access = %d, want Owner", method, got)
+		}
+	}
+}
@@ -0,0 +1,214 @@
+package grpc
+
+import (
+	"context"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"user-service/internal/domain"
+	service "user-service/internal/service/user"
+	userpb "user-service/protos/user"
+
+	"google.golang.org/protobuf/encoding/prototext"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+const profileUserID = "33333333-3333-3333-3333-333333333333"
+
+// profileRepo — profil yo'llari uchun xotiradagi repository
+type profileRepo struct {
+	domain.UserRepository
+	user *domain.User
+}
+
+func (r profileRepo) GetByUsername(ctx context.Context, username string) (*domain.User, error) {
+	if r.user.Username != nil && *r.user.Username == username {
+		copied := *r.user
+		return &copied, nil
+	}
+	return nil, nil
+}
+
+func (r profileRepo) GetPublicProfiles(ctx context.Context, ids []string) ([]domain.PublicProfile, error) {
+	var profiles []domain.PublicProfile
+	for _, id := range ids {
+		if id == r.user.ID && r.user.Restriction(time.Now()) == nil {
+			profiles = append(profiles, r.user.PublicProfile())
+		}
+	}
+	return profiles, nil
+}
+
+type memoryProfileCache struct {
+	mu       sync.Mutex
+	profiles map[string]domain.PublicProfile
+	hits     int
+}
+
+func (c *memoryProfileCache) GetProfiles(ctx context.Context, ids []string) (map[string]domain.PublicProfile, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	found := make(map[string]domain.PublicProfile)
+	for _, id := range ids {
+		if p, ok := c.profiles[id]; ok {
+			found[id] = p
+			c.hits++
+		}
+	}
+	return found, nil
+}
+
+func (c *memoryProfileCache) SetProfiles(ctx context.Context, profiles []domain.PublicProfile) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for _, p := range profiles {
+		c.profiles[p.ID] = p
+	}
+	return nil
+}
+
+func (c *memoryProfileCache) Invalidate(ctx context.Context, userIDs ...string) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for _, id := range userIDs {
+		delete(c.profiles, id)
+	}
+	return nil
+}
+
+func strPtr(s string) *string { return &s }
+
+// fullUser — barcha maxfiy maydonlari to'ldirilgan foydalanuvchi
+func fullUser(privacy domain.PrivacySettings) *domain.User {
+	now := time.Now()
+	return &domain.User{
+		ID:              profileUserID,
+		Username:        strPtr("ali"),
+		Email:           strPtr("ali.secret@example.com"),
+		PasswordHash:    "$argon2id$v=19$m=65536,t=3,p=2$c2FsdA$aGFzaA",
+		FullName:        strPtr("Ali Valiyev"),
+		AvatarURL:       strPtr("https://cdn.example/ali.png"),
+		Language:        strPtr("uz"),
+		Platform:        "ios",
+		DeviceID:        "device-secret-42",
+		RegisteredIP:    strPtr("203.0.113.77"),
+		UserAgent:       "SecretAgent/1.0",
+		Location:        strPtr("Tashkent, Uzbekistan"),
+		EmailVerifiedAt: &now,
+		PendingEmail:    strPtr("ali.pending@example.com"),
+		TOTPSecret:      strPtr("TOTPSECRETVALUE"),
+		Role:            domain.RoleUser,
+		Status:          domain.UserStatusActive,
+		Privacy:         privacy,
+		LastSeenAt:      &now,
+		RegisteredAt:    now,
+	}
+}
+
+// profileLookups — bir xil profilni ID (BatchGetUsers keshi orqali) va username bo'yicha oladi
+func profileLookups(t *testing.T, srv *UserServer, cache *memoryProfileCache) map[string]*userpb.PublicProfile {
+	t.Helper()
+	ctx := context.Background()
+	out := make(map[string]*userpb.PublicProfile)
+
+	batch, err := srv.BatchGetUsers(ctx, &userpb.BatchGetUsersRequest{Ids: []string{profileUserID}})
+	if err != nil || len(batch.Users) != 1 {
+		t.Fatalf("BatchGetUsers (cache miss): %v, %d users", err, len(batch.GetUsers()))
+	}
+	out["batch cache miss"] = batch.Users[0]
+
+	batch, err = srv.BatchGetUsers(ctx, &userpb.BatchGetUsersRequest{Ids: []string{profileUserID}})
+	if err != nil || len(batch.Users) != 1 {
+		t.Fatalf("BatchGetUsers (cache hit): %v, %d users", err, len(batch.GetUsers()))
+	}
+	if cache.hits == 0 {
+		t.Fatal("second BatchGetUsers did not hit the profile cache")
+	}
+	out["batch cache hit"] = batch.Users[0]
+
+	byID, err := srv.GetUserProfile(ctx, &userpb.GetUserProfileRequest{Lookup: &userpb.GetUserProfileRequest_Id{Id: profileUserID}})
+	if err != nil {
+		t.Fatalf("GetUserProfile by id: %v", err)
+	}
+	out["by id"] = byID
+
+	byUsername, err := srv.GetUserProfile(ctx, &userpb.GetUserProfileRequest{Lookup: &userpb.GetUserProfileRequest_Username{Username: "ali"}})
+	if err != nil {
+		t.Fatalf("GetUserProfile by username: %v", err)
+	}
+	out["by username"] = byUsername
+	return out
+}
+
+func TestPublicProfileNeverLeaksPrivateFields(t *testing.T) {
+	tests := []struct {
+		name         string
+		privacy      domain.PrivacySettings
+		wantFullName string
+		wantAvatar   string
+	}{
+		{
+			name:         "everything shown",
+			privacy:      domain.PrivacySettings{Discoverable: true, ShowFullName: true, ShowAvatar: true},
+			wantFullName: "Ali Valiyev",
+			wantAvatar:   "https://cdn.example/ali.png",
+		},
+		{
+			name:       "full name hidden",
+			privacy:    domain.PrivacySettings{ShowAvatar: true},
+			wantAvatar: "https://cdn.example/ali.png",
+		},
+		{
+			name:         "avatar hidden",
+			privacy:      domain.PrivacySettings{ShowFullName: true},
+			wantFullName: "Ali Valiyev",
+		},
+		{
+			name:    "everything hidden",
+			privacy: domain.PrivacySettings{HideLastSeen: true},
+		},
+	}
+
+	allowed := map[protoreflect.Name]bool{"id": true, "username": true, "full_name": true, "avatar_url": true}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			user := fullUser(tt.privacy)
+			cache := &memoryProfileCache{profiles: make(map[string]domain.PublicProfile)}
+			svc := service.NewUserService(service.Deps{Repo: profileRepo{user: user}, Profiles: cache}, service.Config{})
+			srv := NewUserServer(svc, nil)
+
+			secrets := []string{
+				*user.Email, *user.PendingEmail, *user.RegisteredIP, user.UserAgent,
+				user.DeviceID, *user.Location, *user.TOTPSecret, user.PasswordHash,
+			}
+
+			for path, pb := range profileLookups(t, srv, cache) {
+				pb.ProtoReflect().Range(func(fd protoreflect.FieldDescriptor, _ protoreflect.Value) bool {
+					if !allowed[fd.Name()] {
+						t.Errorf("%s: PublicProfile carries field %q", path, fd.Name())
+					}
+					return true
+				})
+				text := prototext.Format(pb)
+				for _, secret := range secrets {
+					if strings.Contains(text, secret) {
+						t.Errorf("%s: PublicProfile leaks %q", path, secret)
+					}
+				}
+
+				if pb.Id != profileUserID || pb.Username != "ali" {
+					t.Errorf("%s: id/username = %q/%q", path, pb.Id, pb.Username)
+				}
+				if pb.FullName != tt.wantFullName {
+					t.Errorf("%s: full_name = %q, want %q", path, pb.FullName, tt.wantFullName)
+				}
+				if pb.AvatarUrl != tt.wantAvatar {
+					t.Errorf("%s: avatar_url = %q, want %q", path, pb.AvatarUrl, tt.wantAvatar)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,41 @@
+package grpc
+
+import (
+	"context"
+	"testing"
+
+	"user-service/internal/domain"
+	userpb "user-service/protos/user"
+)
+
+// registerService — Register ga kelgan DTO ni eslab qoladi
+type registerService struct {
+	domain.UserService
+	got *domain.RegisterDTO
+}
+
+func (s *registerService) Register(ctx context.Context, req domain.RegisterDTO) (*domain.AuthResult, error) {
+	s.got = &req
+	return &domain.AuthResult{User: &domain.User{ID: profileUserID, Username: strPtr(req.Username)}}, nil
+}
+
+func TestRegisterWithoutClientIP(t *testing.T) {
+	svc := &registerService{}
+	srv := NewUserServer(svc, nil)
+
+	// peer va x-forwarded-for yo'q — IP aniqlanmaydi, handler panic qilmasligi kerak
+	_, err := srv.Register(context.Background(), &userpb.RegisterRequest{
+		Username: "vali",
+		Email:    "vali@example.com",
+		Password: "Sup3r-Secret-Passphrase",
+	})
+	if err != nil {
+		t.Fatalf("Register: %v", err)
+	}
+	if svc.got == nil {
+		t.Fatal("service Register not called")
+	}
+	if svc.got.RegisteredIP != nil {
+		t.Errorf("RegisteredIP = %q, want nil", *svc.got.RegisteredIP)
+	}
+}
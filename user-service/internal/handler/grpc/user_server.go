@@ -5,6 +5,7 @@ import (
 	"time"
 
 	"user-service/internal/domain"
+	"user-service/internal/utils"
 	userpb "user-service/protos/user"
 
 	"google.golang.org/protobuf/types/known/timestamppb"
@@ -13,11 +14,13 @@ import (
 type UserServer struct {
 	userpb.UnimplementedUserServiceServer
 	userService domain.UserService
+	presence    domain.PresenceService
 }
 
-func NewUserServer(userService domain.UserService) *UserServer {
+func NewUserServer(userService domain.UserService, presence domain.PresenceService) *UserServer {
 	return &UserServer{
 		userService: userService,
+		presence:    presence,
 	}
 }
 
@@ -25,9 +28,6 @@ func NewUserServer(userService domain.UserService) *UserServer {
 // REGISTER
 // =====================
 func (s *UserServer) Register(ctx context.Context, req *userpb.RegisterRequest) (*userpb.AuthResponse, error) {
-	ip := getIPFromCtx(ctx)
-	loc, _ := GetLocationFromIP(*ip)
-
 	dto := domain.RegisterDTO{
 		Username:     req.Username,
 		Email:        req.Email,
@@ -37,14 +37,13 @@ func (s *UserServer) Register(ctx context.Context, req *userpb.RegisterRequest)
 		Language:     toPtr(req.Language),
 		Platform:     req.Platform,
 		DeviceID:     req.DeviceId,
-		RegisteredIP: ip,
+		RegisteredIP: getIPFromCtx(ctx),
 		UserAgent:    getUserAgentFromCtx(ctx),
-		Location:     toPtr(loc),
 	}
 
 	authResult, err := s.userService.Register(ctx, dto)
 	if err != nil {
-		return nil, err
+		return nil, toGRPCError(err)
 	}
 
 	return toAuthResponse(authResult), nil
@@ -54,9 +53,85 @@ func (s *UserServer) Register(ctx context.Context, req *userpb.RegisterRequest)
 // LOGIN
 // =====================
 func (s *UserServer) Login(ctx context.Context, req *userpb.LoginRequest) (*userpb.AuthResponse, error) {
-	authResult, err := s.userService.Login(ctx, req.Email, req.Password, req.Platform, req.DeviceId)
+	dto := domain.LoginDTO{
+		Email:     req.Email,
+		Password:  req.Password,
+		Platform:  req.Platform,
+		DeviceID:  req.DeviceId,
+		IPAddress: getStr(getIPFromCtx(ctx)),
+		UserAgent: getUserAgentFromCtx(ctx),
+	}
+
+	authResult, err := s.userService.Login(ctx, dto)
+	if err != nil {
+		return nil, toGRPCError(err)
+	}
+	return toAuthResponse(authResult), nil
+}
+
+// =====================
+// REQUEST LOGIN CODE
+// =====================
+func (s *UserServer) RequestLoginCode(ctx context.Context, req *userpb.RequestLoginCodeRequest) (*userpb.Empty, error) {
+	if err := s.userService.RequestLoginCode(ctx, req.Email); err != nil {
+		return nil, toGRPCError(err)
+	}
+	return &userpb.Empty{}, nil
+}
+
+// =====================
+// VERIFY LOGIN CODE
+// =====================
+func (s *UserServer) VerifyLoginCode(ctx context.Context, req *userpb.VerifyLoginCodeRequest) (*userpb.AuthResponse, error) {
+	dto := domain.LoginCodeDTO{
+		Email:     req.Email,
+		Code:      req.Code,
+		Platform:  req.Platform,
+		DeviceID:  req.DeviceId,
+		IPAddress: getStr(getIPFromCtx(ctx)),
+		UserAgent: getUserAgentFromCtx(ctx),
+	}
+
+	authResult, err := s.userService.VerifyLoginCode(ctx, dto)
+	if err != nil {
+		return nil, toGRPCError(err)
+	}
+	return toAuthResponse(authResult), nil
+}
+
+// =====================
+// LOGIN WITH PROVIDER (OAuth2 / OIDC)
+// =====================
+func (s *UserServer) LoginWithProvider(ctx context.Context, req *userpb.ProviderLoginRequest) (*userpb.AuthResponse, error) {
+	dto := domain.ProviderLoginDTO{
+		Auth: domain.ProviderAuthRequest{
+			Provider:     req.Provider,
+			Code:         req.Code,
+			RedirectURI:  req.RedirectUri,
+			CodeVerifier: req.CodeVerifier,
+			Nonce:        req.Nonce,
+		},
+		Platform:  req.Platform,
+		DeviceID:  req.DeviceId,
+		IPAddress: getStr(getIPFromCtx(ctx)),
+		UserAgent: getUserAgentFromCtx(ctx),
+		Language:  toPtr(req.Language),
+	}
+
+	authResult, err := s.userService.LoginWithProvider(ctx, dto)
 	if err != nil {
-		return nil, err
+		return nil, toGRPCError(err)
+	}
+	return toAuthResponse(authResult), nil
+}
+
+// =====================
+// VERIFY MFA
+// =====================
+func (s *UserServer) VerifyMFA(ctx context.Context, req *userpb.VerifyMFARequest) (*userpb.AuthResponse, error) {
+	authResult, err := s.userService.VerifyMFA(ctx, req.MfaToken, req.Code, getStr(getIPFromCtx(ctx)), getUserAgentFromCtx(ctx))
+	if err != nil {
+		return nil, toGRPCError(err)
 	}
 	return toAuthResponse(authResult), nil
 }
@@ -66,13 +141,13 @@ func (s *UserServer) Login(ctx context.Context, req *userpb.LoginRequest) (*user
 // =====================
 func (s *UserServer) GetProfile(ctx context.Context, _ *userpb.Empty) (*userpb.User, error) {
 
-	userID, ok := ctx.Value("userID").(string)
-	if !ok || userID == "" {
+	userID, ok := utils.UserIDFromContext(ctx)
+	if !ok {
 		return nil, ErrUnauthenticated
 	}
 	user, err := s.userService.GetProfile(ctx, userID)
 	if err != nil {
-		return nil, err
+		return nil, toGRPCError(err)
 	}
 
 	return toUserPB(user), nil
@@ -82,17 +157,14 @@ func (s *UserServer) GetProfile(ctx context.Context, _ *userpb.Empty) (*userpb.U
 // LOGOUT
 // =====================
 func (s *UserServer) Logout(ctx context.Context, _ *userpb.Empty) (*userpb.Empty, error) {
-	userID, ok := ctx.Value("userID").(string)
-	if !ok || userID == "" {
+	userID, ok := utils.UserIDFromContext(ctx)
+	if !ok {
 		return nil, ErrUnauthenticated
 	}
-	user, err := s.userService.GetProfile(ctx, userID)
-	if err != nil {
-		return nil, err
-	}
+	sessionID := utils.SessionIDFromContext(ctx)
 
-	if err := s.userService.Logout(ctx, userID, user.DeviceID); err != nil {
-		return nil, err
+	if err := s.userService.Logout(ctx, userID, sessionID); err != nil {
+		return nil, toGRPCError(err)
 	}
 	return &userpb.Empty{}, nil
 }
@@ -101,12 +173,14 @@ func (s *UserServer) Logout(ctx context.Context, _ *userpb.Empty) (*userpb.Empty
 // DELETE ACCOUNT
 // =====================
 func (s *UserServer) DeleteAccount(ctx context.Context, _ *userpb.Empty) (*userpb.Empty, error) {
-	userID, ok := ctx.Value("userID").(string)
-	if !ok || userID == "" {
+	userID, ok := utils.UserIDFromContext(ctx)
+	if !ok {
 		return nil, ErrUnauthenticated
 	}
-	if err := s.userService.DeleteAccount(ctx, userID); err != nil {
-		return nil, err
+	sessionID := utils.SessionIDFromContext(ctx)
+
+	if err := s.userService.DeleteAccount(ctx, userID, sessionID); err != nil {
+		return nil, toGRPCError(err)
 	}
 	return &userpb.Empty{}, nil
 }
@@ -115,34 +189,186 @@ func (s *UserServer) DeleteAccount(ctx context.Context, _ *userpb.Empty) (*userp
 // GET SESSIONS
 // =====================
 func (s *UserServer) GetSessions(ctx context.Context, _ *userpb.Empty) (*userpb.SessionList, error) {
-	userID, ok := ctx.Value("userID").(string)
-	if !ok || userID == "" {
+	userID, ok := utils.UserIDFromContext(ctx)
+	if !ok {
 		return nil, ErrUnauthenticated
 	}
+	sessionID := utils.SessionIDFromContext(ctx)
+
 	sessions, err := s.userService.GetSessions(ctx, userID)
 	if err != nil {
-		return nil, err
+		return nil, toGRPCError(err)
 	}
 	Sessions := make([]*userpb.Session, len(sessions))
 	for i, s := range sessions {
 		Sessions[i] = &userpb.Session{
-			DeviceId:     s.DeviceID,
-			Platform:     s.Platform,
-			IpAddress:    s.IPAddress,
-			LastSeen:     toProtoTime(s.LastSeen),
+			DeviceId:  s.DeviceID,
+			Platform:  s.Platform,
+			IpAddress: s.IPAddress,
+			LastSeen:  toProtoTime(s.LastSeen),
+			UserAgent: s.UserAgent,
+			CreatedAt: toProtoTime(s.CreatedAt),
+			Current:   s.ID == sessionID,
+			AuthTime:  toProtoTime(s.AuthTime),
 		}
 	}
 	return &userpb.SessionList{Sessions: Sessions}, nil
 }
 
+// =====================
+// LOGIN HISTORY
+// =====================
+func (s *UserServer) GetLoginHistory(ctx context.Context, req *userpb.GetLoginHistoryRequest) (*userpb.LoginHistory, error) {
+	userID, ok := utils.UserIDFromContext(ctx)
+	if !ok {
+		return nil, ErrUnauthenticated
+	}
+
+	page, err := s.userService.GetLoginHistory(ctx, userID, int(req.PageSize), req.PageToken)
+	if err != nil {
+		return nil, toGRPCError(err)
+	}
+
+	events := make([]*userpb.LoginEvent, len(page.Events))
+	for i, e := range page.Events {
+		events[i] = &userpb.LoginEvent{
+			Method:        e.Method,
+			Outcome:       string(e.Outcome),
+			FailureReason: e.FailureReason,
+			IpAddress:     e.IPAddress,
+			UserAgent:     e.UserAgent,
+			Location:      e.Location,
+			CountryCode:   e.CountryCode,
+			Platform:      e.Platform,
+			DeviceId:      e.DeviceID,
+			CreatedAt:     toProtoTime(e.CreatedAt),
+		}
+	}
+	return &userpb.LoginHistory{Events: events, NextPageToken: page.NextPageToken}, nil
+}
+
+// =====================
+// PRESENCE
+// =====================
+func (s *UserServer) Heartbeat(ctx context.Context, req *userpb.HeartbeatRequest) (*userpb.HeartbeatResponse, error) {
+	userID, ok := utils.UserIDFromContext(ctx)
+	if !ok {
+		return nil, ErrUnauthenticated
+	}
+	interval, err := s.presence.Heartbeat(ctx, userID, utils.SessionIDFromContext(ctx), req.Offline)
+	if err != nil {
+		return nil, toGRPCError(err)
+	}
+	return &userpb.HeartbeatResponse{IntervalSeconds: int32(interval / time.Second)}, nil
+}
+
+func (s *UserServer) WatchPresence(req *userpb.WatchPresenceRequest, stream userpb.UserService_WatchPresenceServer) error {
+	err := s.presence.Watch(stream.Context(), req.UserIds, func(p domain.Presence) error {
+		msg := &userpb.Presence{UserId: p.UserID, Online: p.Online, Hidden: p.Hidden}
+		if p.LastSeen != nil {
+			msg.LastSeen = toProtoTime(*p.LastSeen)
+		}
+		return stream.Send(msg)
+	})
+	if err != nil {
+		return toGRPCError(err)
+	}
+	return nil
+}
+
+// =====================
+// LINK IDENTITY
+// =====================
+func (s *UserServer) LinkIdentity(ctx context.Context, req *userpb.LinkIdentityRequest) (*userpb.Identity, error) {
+	userID, ok := utils.UserIDFromContext(ctx)
+	if !ok {
+		return nil, ErrUnauthenticated
+	}
+
+	identity, err := s.userService.LinkIdentity(ctx, userID, domain.ProviderAuthRequest{
+		Provider:     req.Provider,
+		Code:         req.Code,
+		RedirectURI:  req.RedirectUri,
+		CodeVerifier: req.CodeVerifier,
+		Nonce:        req.Nonce,
+	})
+	if err != nil {
+		return nil, toGRPCError(err)
+	}
+	return toIdentityPB(identity), nil
+}
+
+// =====================
+// UNLINK IDENTITY
+// =====================
+func (s *UserServer) UnlinkIdentity(ctx context.Context, req *userpb.UnlinkIdentityRequest) (*userpb.Empty, error) {
+	userID, ok := utils.UserIDFromContext(ctx)
+	if !ok {
+		return nil, ErrUnauthenticated
+	}
+	if err := s.userService.UnlinkIdentity(ctx, userID, req.Provider); err != nil {
+		return nil, toGRPCError(err)
+	}
+	return &userpb.Empty{}, nil
+}
+
+// =====================
+// GET IDENTITIES
+// =====================
+func (s *UserServer) GetIdentities(ctx context.Context, _ *userpb.Empty) (*userpb.IdentityList, error) {
+	userID, ok := utils.UserIDFromContext(ctx)
+	if !ok {
+		return nil, ErrUnauthenticated
+	}
+
+	identities, err := s.userService.GetIdentities(ctx, userID)
+	if err != nil {
+		return nil, toGRPCError(err)
+	}
+	list := make([]*userpb.Identity, len(identities))
+	for i := range identities {
+		list[i] = toIdentityPB(&identities[i])
+	}
+	return &userpb.IdentityList{Identities: list}, nil
+}
+
+// =====================
+// REVOKE SESSION
+// =====================
+func (s *UserServer) RevokeSession(ctx context.Context, req *userpb.RevokeSessionRequest) (*userpb.Empty, error) {
+	userID, ok := utils.UserIDFromContext(ctx)
+	if !ok {
+		return nil, ErrUnauthenticated
+	}
+	if err := s.userService.RevokeSession(ctx, userID, req.DeviceId); err != nil {
+		return nil, toGRPCError(err)
+	}
+	return &userpb.Empty{}, nil
+}
+
+// =====================
+// REVOKE ALL OTHER SESSIONS
+// =====================
+func (s *UserServer) RevokeAllOtherSessions(ctx context.Context, _ *userpb.Empty) (*userpb.Empty, error) {
+	userID, ok := utils.UserIDFromContext(ctx)
+	if !ok {
+		return nil, ErrUnauthenticated
+	}
+	sessionID := utils.SessionIDFromContext(ctx)
+
+	if err := s.userService.RevokeAllOtherSessions(ctx, userID, sessionID); err != nil {
+		return nil, toGRPCError(err)
+	}
+	return &userpb.Empty{}, nil
+}
 
 // =====================
 // REFRESH TOKEN
 // =====================
 func (s *UserServer) RefreshToken(ctx context.Context, req *userpb.RefreshTokenRequest) (*userpb.AuthResponse, error) {
-	authResult, err := s.userService.RefreshToken(ctx, req.RefreshToken)
+	authResult, err := s.userService.RefreshToken(ctx, req.RefreshToken, getStr(getIPFromCtx(ctx)), getUserAgentFromCtx(ctx))
 	if err != nil {
-		return nil, err
+		return nil, toGRPCError(err)
 	}
 	return toAuthResponse(authResult), nil
 }
@@ -151,60 +377,129 @@ func (s *UserServer) RefreshToken(ctx context.Context, req *userpb.RefreshTokenR
 // update username
 // =====================
 func (s *UserServer) UpdateUsername(ctx context.Context, req *userpb.UpdateUsernameRequest) (*userpb.User, error) {
-	userID, ok := ctx.Value("userID").(string)
-	if !ok || userID == "" {
+	userID, ok := utils.UserIDFromContext(ctx)
+	if !ok {
 		return nil, ErrUnauthenticated
 	}
 	user, err := s.userService.UpdateUsername(ctx, userID, req.Username)
 	if err != nil {
-		return nil, err
+		return nil, toGRPCError(err)
+	}
+	return toUserPB(user), nil
+}
+
+// =====================
+// check username availability
+// =====================
+func (s *UserServer) CheckUsernameAvailability(ctx context.Context, req *userpb.CheckUsernameAvailabilityRequest) (*userpb.UsernameAvailability, error) {
+	result, err := s.userService.CheckUsernameAvailability(ctx, req.Username)
+	if err != nil {
+		return nil, toGRPCError(err)
+	}
+	return &userpb.UsernameAvailability{
+		Available:   result.Available,
+		Reason:      result.Reason,
+		Suggestions: result.Suggestions,
+	}, nil
+}
+
+// =====================
+// update privacy settings
+// =====================
+func (s *UserServer) UpdatePrivacySettings(ctx context.Context, req *userpb.UpdatePrivacySettingsRequest) (*userpb.User, error) {
+	userID, ok := utils.UserIDFromContext(ctx)
+	if !ok {
+		return nil, ErrUnauthenticated
+	}
+	user, err := s.userService.UpdatePrivacySettings(ctx, userID, domain.PrivacySettingsUpdate{
+		Discoverable: req.Discoverable,
+		ShowFullName: req.ShowFullName,
+		ShowAvatar:   req.ShowAvatar,
+		HideLastSeen: req.HideLastSeen,
+	})
+	if err != nil {
+		return nil, toGRPCError(err)
 	}
 	return toUserPB(user), nil
 }
 
+// =====================
+// get user profile
+// =====================
+func (s *UserServer) GetUserProfile(ctx context.Context, req *userpb.GetUserProfileRequest) (*userpb.PublicProfile, error) {
+	profile, err := s.userService.GetUserProfile(ctx, domain.ProfileLookup{
+		ID:       req.GetId(),
+		Username: req.GetUsername(),
+	})
+	if err != nil {
+		return nil, toGRPCError(err)
+	}
+	return toPublicProfilePB(*profile), nil
+}
+
+// =====================
+// search users
+// =====================
+func (s *UserServer) SearchUsers(ctx context.Context, req *userpb.SearchUsersRequest) (*userpb.SearchUsersResponse, error) {
+	userID, ok := utils.UserIDFromContext(ctx)
+	if !ok {
+		return nil, ErrUnauthenticated
+	}
+	page, err := s.userService.SearchUsers(ctx, userID, req.Query, int(req.PageSize), req.PageToken)
+	if err != nil {
+		return nil, toGRPCError(err)
+	}
+
+	users := make([]*userpb.PublicProfile, len(page.Profiles))
+	for i, p := range page.Profiles {
+		users[i] = toPublicProfilePB(p)
+	}
+	return &userpb.SearchUsersResponse{Users: users, NextPageToken: page.NextPageToken}, nil
+}
+
 // =====================
 // update email
 // =====================
 func (s *UserServer) UpdateEmail(ctx context.Context, req *userpb.UpdateEmailRequest) (*userpb.User, error) {
-	userID, ok := ctx.Value("userID").(string)
-	if !ok || userID == "" {
+	userID, ok := utils.UserIDFromContext(ctx)
+	if !ok {
 		return nil, ErrUnauthenticated
 	}
-	user, err := s.userService.UpdateEmail(ctx, userID, req.Email)
+	sessionID := utils.SessionIDFromContext(ctx)
+
+	user, err := s.userService.UpdateEmail(ctx, userID, sessionID, req.Email)
 	if err != nil {
-		return nil, err
+		return nil, toGRPCError(err)
 	}
 	return toUserPB(user), nil
 }
 
-
 // =====================
 // update full name
 // =====================
 func (s *UserServer) UpdateFullName(ctx context.Context, req *userpb.UpdateFullNameRequest) (*userpb.User, error) {
-	userID, ok := ctx.Value("userID").(string)
-	if !ok || userID == "" {
+	userID, ok := utils.UserIDFromContext(ctx)
+	if !ok {
 		return nil, ErrUnauthenticated
 	}
 	user, err := s.userService.UpdateFullName(ctx, userID, req.FullName)
 	if err != nil {
-		return nil, err
+		return nil, toGRPCError(err)
 	}
 	return toUserPB(user), nil
 }
 
-
 // =====================
 // update avatar url
 // =====================
 func (s *UserServer) UpdateAvatar(ctx context.Context, req *userpb.UpdateAvatarRequest) (*userpb.User, error) {
-	userID, ok := ctx.Value("userID").(string)
-	if !ok || userID == "" {
+	userID, ok := utils.UserIDFromContext(ctx)
+	if !ok {
 		return nil, ErrUnauthenticated
 	}
 	user, err := s.userService.UpdateAvatar(ctx, userID, req.AvatarUrl)
 	if err != nil {
-		return nil, err
+		return nil, toGRPCError(err)
 	}
 	return toUserPB(user), nil
 }
@@ -213,28 +508,50 @@ func (s *UserServer) UpdateAvatar(ctx context.Context, req *userpb.UpdateAvatarR
 // update language
 // =====================
 func (s *UserServer) UpdateLanguage(ctx context.Context, req *userpb.UpdateLanguageRequest) (*userpb.User, error) {
-	userID, ok := ctx.Value("userID").(string)
-	if !ok || userID == "" {
+	userID, ok := utils.UserIDFromContext(ctx)
+	if !ok {
 		return nil, ErrUnauthenticated
 	}
 	user, err := s.userService.UpdateLanguage(ctx, userID, req.Language)
 	if err != nil {
-		return nil, err
+		return nil, toGRPCError(err)
 	}
 	return toUserPB(user), nil
 }
 
+// =====================
+// reauthenticate
+// =====================
+func (s *UserServer) Reauthenticate(ctx context.Context, req *userpb.ReauthenticateRequest) (*userpb.ReauthenticateResponse, error) {
+	userID, ok := utils.UserIDFromContext(ctx)
+	if !ok {
+		return nil, ErrUnauthenticated
+	}
+	sessionID := utils.SessionIDFromContext(ctx)
+
+	authTime, validUntil, err := s.userService.Reauthenticate(ctx, userID, sessionID, req.GetPassword(), req.GetTotpCode())
+	if err != nil {
+		return nil, toGRPCError(err)
+	}
+	resp := &userpb.ReauthenticateResponse{AuthTime: toProtoTime(authTime)}
+	if !validUntil.IsZero() {
+		resp.ValidUntil = toProtoTime(validUntil)
+	}
+	return resp, nil
+}
 
 // =====================
 // change password
 // =====================
 func (s *UserServer) ChangePassword(ctx context.Context, req *userpb.ChangePasswordRequest) (*userpb.Empty, error) {
-	userID, ok := ctx.Value("userID").(string)
-	if !ok || userID == "" {
+	userID, ok := utils.UserIDFromContext(ctx)
+	if !ok {
 		return nil, ErrUnauthenticated
 	}
-	if err := s.userService.ChangePassword(ctx, userID, req.OldPassword, req.NewPassword); err != nil {
-		return nil, err
+	sessionID := utils.SessionIDFromContext(ctx)
+
+	if err := s.userService.ChangePassword(ctx, userID, sessionID, req.OldPassword, req.NewPassword); err != nil {
+		return nil, toGRPCError(err)
 	}
 	return &userpb.Empty{}, nil
 }
@@ -244,22 +561,148 @@ func (s *UserServer) ChangePassword(ctx context.Context, req *userpb.ChangePassw
 // =====================
 func (s *UserServer) ForgotPassword(ctx context.Context, req *userpb.ForgotPasswordRequest) (*userpb.Empty, error) {
 	if err := s.userService.ForgotPassword(ctx, req.Email); err != nil {
-		return nil, err
+		return nil, toGRPCError(err)
 	}
 	return &userpb.Empty{}, nil
 }
 
-
 // =====================
 // reset password
 // =====================
 func (s *UserServer) ResetPassword(ctx context.Context, req *userpb.ResetPasswordRequest) (*userpb.Empty, error) {
 	if err := s.userService.ResetPassword(ctx, req.Token, req.NewPassword); err != nil {
-		return nil, err
+		return nil, toGRPCError(err)
+	}
+	return &userpb.Empty{}, nil
+}
+
+// =====================
+// send verification email
+// =====================
+func (s *UserServer) SendVerificationEmail(ctx context.Context, _ *userpb.Empty) (*userpb.Empty, error) {
+	userID, ok := utils.UserIDFromContext(ctx)
+	if !ok {
+		return nil, ErrUnauthenticated
+	}
+	if err := s.userService.SendVerificationEmail(ctx, userID); err != nil {
+		return nil, toGRPCError(err)
+	}
+	return &userpb.Empty{}, nil
+}
+
+// =====================
+// verify email
+// =====================
+func (s *UserServer) VerifyEmail(ctx context.Context, req *userpb.VerifyEmailRequest) (*userpb.Empty, error) {
+	if err := s.userService.VerifyEmail(ctx, req.Token); err != nil {
+		return nil, toGRPCError(err)
 	}
 	return &userpb.Empty{}, nil
 }
 
+// =====================
+// enable totp
+// =====================
+func (s *UserServer) EnableTOTP(ctx context.Context, _ *userpb.Empty) (*userpb.EnableTOTPResponse, error) {
+	userID, ok := utils.UserIDFromContext(ctx)
+	if !ok {
+		return nil, ErrUnauthenticated
+	}
+	setup, err := s.userService.EnableTOTP(ctx, userID)
+	if err != nil {
+		return nil, toGRPCError(err)
+	}
+	return &userpb.EnableTOTPResponse{Secret: setup.Secret, OtpauthUri: setup.OTPAuthURI}, nil
+}
+
+// =====================
+// confirm totp
+// =====================
+func (s *UserServer) ConfirmTOTP(ctx context.Context, req *userpb.ConfirmTOTPRequest) (*userpb.RecoveryCodesResponse, error) {
+	userID, ok := utils.UserIDFromContext(ctx)
+	if !ok {
+		return nil, ErrUnauthenticated
+	}
+	codes, err := s.userService.ConfirmTOTP(ctx, userID, req.Code)
+	if err != nil {
+		return nil, toGRPCError(err)
+	}
+	return &userpb.RecoveryCodesResponse{RecoveryCodes: codes}, nil
+}
+
+// =====================
+// disable totp
+// =====================
+func (s *UserServer) DisableTOTP(ctx context.Context, req *userpb.DisableTOTPRequest) (*userpb.Empty, error) {
+	userID, ok := utils.UserIDFromContext(ctx)
+	if !ok {
+		return nil, ErrUnauthenticated
+	}
+	if err := s.userService.DisableTOTP(ctx, userID, req.Code); err != nil {
+		return nil, toGRPCError(err)
+	}
+	return &userpb.Empty{}, nil
+}
+
+// =====================
+// JWKS
+// =====================
+func (s *UserServer) GetJWKS(ctx context.Context, _ *userpb.Empty) (*userpb.JWKSResponse, error) {
+	jwks := s.userService.GetJWKS(ctx)
+	keys := make([]*userpb.JsonWebKey, len(jwks))
+	for i, k := range jwks {
+		keys[i] = &userpb.JsonWebKey{
+			Kty: k.Kty,
+			Kid: k.Kid,
+			Use: k.Use,
+			Alg: k.Alg,
+			N:   k.N,
+			E:   k.E,
+			Crv: k.Crv,
+			X:   k.X,
+		}
+	}
+	return &userpb.JWKSResponse{Keys: keys}, nil
+}
+
+// =====================
+// INTROSPECT TOKEN
+// =====================
+func (s *UserServer) IntrospectToken(ctx context.Context, req *userpb.IntrospectTokenRequest) (*userpb.IntrospectTokenResponse, error) {
+	claims, err := s.userService.IntrospectToken(ctx, req.Token)
+	if err != nil {
+		return nil, toGRPCError(err)
+	}
+	if claims == nil {
+		return &userpb.IntrospectTokenResponse{Active: false}, nil
+	}
+	return &userpb.IntrospectTokenResponse{
+		Active:    true,
+		UserId:    claims.UserID,
+		SessionId: claims.SessionID,
+		Scopes:    claims.Roles,
+		IssuedAt:  toProtoTime(claims.IssuedAt),
+		ExpiresAt: toProtoTime(claims.ExpiresAt),
+		ActorId:   claims.ActorID,
+	}, nil
+}
+
+// =====================
+// BATCH GET USERS
+// =====================
+func (s *UserServer) BatchGetUsers(ctx context.Context, req *userpb.BatchGetUsersRequest) (*userpb.BatchGetUsersResponse, error) {
+	batch, err := s.userService.BatchGetUsers(ctx, req.Ids)
+	if err != nil {
+		return nil, toGRPCError(err)
+	}
+
+	users := make([]*userpb.PublicProfile, len(batch.Profiles))
+	for i, p := range batch.Profiles {
+		users[i] = toPublicProfilePB(p)
+	}
+	return &userpb.BatchGetUsersResponse{Users: users, MissingIds: batch.MissingIDs}, nil
+}
+
 // =====================
 // HELPERS
 // =====================
@@ -268,24 +711,52 @@ func toUserPB(u *domain.User) *userpb.User {
 		return nil
 	}
 
-	return &userpb.User{
-		Id:           u.ID,
-		Username:     getStr(u.Username),
-		Email:        getStr(u.Email),
-		FullName:     getStr(u.FullName),
-		AvatarUrl:    getStr(u.AvatarURL),
-		Language:     getStr(u.Language),
-		Platform:     u.Platform,
-		DeviceId:     u.DeviceID,
-		RegisteredIp: getStr(u.RegisteredIP),
-		UserAgent:    u.UserAgent,
-		Location:     getStr(u.Location),
-		RegisteredAt: toProtoTime(u.RegisteredAt),
-		UpdatedAt:    toProtoTime(u.UpdatedAt),
+	pb := &userpb.User{
+		Id:            u.ID,
+		Username:      getStr(u.Username),
+		Email:         getStr(u.Email),
+		FullName:      getStr(u.FullName),
+		AvatarUrl:     getStr(u.AvatarURL),
+		Language:      getStr(u.Language),
+		Platform:      u.Platform,
+		DeviceId:      u.DeviceID,
+		RegisteredIp:  getStr(u.RegisteredIP),
+		UserAgent:     u.UserAgent,
+		Location:      getStr(u.Location),
+		RegisteredAt:  toProtoTime(u.RegisteredAt),
+		UpdatedAt:     toProtoTime(u.UpdatedAt),
+		EmailVerified: u.EmailVerifiedAt != nil,
+		PendingEmail:  getStr(u.PendingEmail),
+		Role:          string(u.Role),
+		Status:        string(u.Status),
+		StatusReason:  getStr(u.StatusReason),
+		Privacy: &userpb.PrivacySettings{
+			Discoverable: u.Privacy.Discoverable,
+			ShowFullName: u.Privacy.ShowFullName,
+			ShowAvatar:   u.Privacy.ShowAvatar,
+			HideLastSeen: u.Privacy.HideLastSeen,
+		},
+	}
+	if u.SuspendedUntil != nil {
+		pb.SuspendedUntil = toProtoTime(*u.SuspendedUntil)
+	}
+	return pb
+}
+
+// toPublicProfilePB — boshqalarga qaytadigan yagona yo'l; domain.PublicProfile da PII maydonlari yo'q
+func toPublicProfilePB(p domain.PublicProfile) *userpb.PublicProfile {
+	return &userpb.PublicProfile{
+		Id:        p.ID,
+		Username:  p.Username,
+		FullName:  getStr(p.FullName),
+		AvatarUrl: getStr(p.AvatarURL),
 	}
 }
 
 func toAuthResponse(a *domain.AuthResult) *userpb.AuthResponse {
+	if a.MFARequired {
+		return &userpb.AuthResponse{MfaRequired: true, MfaToken: a.MFAToken}
+	}
 	return &userpb.AuthResponse{
 		AccessToken:  a.AccessToken,
 		RefreshToken: a.RefreshToken,
@@ -308,6 +779,14 @@ func getStr(s *string) string {
 	return *s
 }
 
+func toIdentityPB(i *domain.UserIdentity) *userpb.Identity {
+	return &userpb.Identity{
+		Provider:  i.Provider,
+		Email:     i.Email,
+		CreatedAt: toProtoTime(i.CreatedAt),
+	}
+}
+
 func toProtoTime(t time.Time) *timestamppb.Timestamp {
 	return timestamppb.New(t)
 }
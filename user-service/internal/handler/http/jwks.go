@@ -0,0 +1,30 @@
+package http
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"user-service/internal/domain"
+)
+
+// JWKSHandler — GET /.well-known/jwks.json
+func JWKSHandler(tokenProvider domain.TokenProvider) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet {
+			w.WriteHeader(http.StatusMethodNotAllowed)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Set("Cache-Control", "public, max-age=300")
+		_ = json.NewEncoder(w).Encode(struct {
+			Keys []domain.JWK `json:"keys"`
+		}{Keys: tokenProvider.JWKS()})
+	}
+}
+
+// NewServer — ochiq HTTP endpointlar (hozircha faqat JWKS)
+func NewServer(addr string, tokenProvider domain.TokenProvider) *http.Server {
+	mux := http.NewServeMux()
+	mux.Handle("/.well-known/jwks.json", JWKSHandler(tokenProvider))
+	return &http.Server{Addr: addr, Handler: mux}
+}
@@ -0,0 +1,60 @@
+package http
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"user-service/internal/domain"
+	"user-service/internal/utils"
+)
+
+func TestJWKSHandler(t *testing.T) {
+	active, err := utils.GenerateEphemeralKey()
+	if err != nil {
+		t.Fatal(err)
+	}
+	old, err := utils.GenerateEphemeralKey()
+	if err != nil {
+		t.Fatal(err)
+	}
+	old.ID = "old"
+	keys, err := utils.NewKeySet(active, &utils.SigningKey{ID: old.ID, Method: old.Method, Public: old.Public})
+	if err != nil {
+		t.Fatal(err)
+	}
+	srv := httptest.NewServer(NewServer("", utils.NewJWTProvider(keys, "secret", time.Minute, time.Hour, nil)).Handler)
+	defer srv.Close()
+
+	res, err := http.Get(srv.URL + "/.well-known/jwks.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer res.Body.Close()
+	if res.StatusCode != http.StatusOK || res.Header.Get("Content-Type") != "application/json" {
+		t.Fatalf("status = %d, content-type = %q", res.StatusCode, res.Header.Get("Content-Type"))
+	}
+	if cc := res.Header.Get("Cache-Control"); cc == "" {
+		t.Error("Cache-Control not set")
+	}
+	var body struct {
+		Keys []domain.JWK `json:"keys"`
+	}
+	if err := json.NewDecoder(res.Body).Decode(&body); err != nil {
+		t.Fatal(err)
+	}
+	if len(body.Keys) != 2 || body.Keys[0].Kid != active.ID || body.Keys[1].Kid != "old" {
+		t.Errorf("keys = %+v, want active then grace-period key", body.Keys)
+	}
+
+	post, err := http.Post(srv.URL+"/.well-known/jwks.json", "application/json", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	post.Body.Close()
+	if post.StatusCode != http.StatusMethodNotAllowed {
+		t.Errorf("POST status = %d, want 405", post.StatusCode)
+	}
+}
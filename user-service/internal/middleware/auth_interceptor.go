@@ -1,2 +1,237 @@
 package middleware
 
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"slices"
+	"sort"
+	"strings"
+	"time"
+
+	"user-service/internal/domain"
+	"user-service/internal/utils"
+
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// Access — RPC ga kirish darajasi
+type Access int
+
+const (
+	Public        Access = iota + 1 // token talab qilinmaydi
+	Authenticated                   // yaroqli user access tokeni (impersonatsiya tokeni ham)
+	Owner                           // yaroqli user access tokeni, impersonatsiya tokeni rad etiladi
+	Staff                           // access tokeni + "moderator" yoki "admin" roli
+	Admin                           // access tokeni + "admin" roli
+	Service                         // faqat service-to-service tokeni
+)
+
+const (
+	RoleModerator = "moderator"
+	RoleAdmin     = "admin"
+)
+
+// Policy — to'liq metod nomi (/user.UserService/Login) => kirish darajasi.
+// Jadvalda yo'q metodlar rad etiladi.
+type Policy map[string]Access
+
+// Verify — serverda ro'yxatdan o'tgan har bir metodning siyosati borligini tekshiradi
+// (startupda chaqiriladi, yangi RPC siyosatsiz qolib ketmasligi uchun)
+func (p Policy) Verify(services map[string]grpc.ServiceInfo) error {
+	var missing []string
+	for name, info := range services {
+		for _, m := range info.Methods {
+			fullMethod := "/" + name + "/" + m.Name
+			if _, ok := p[fullMethod]; !ok {
+				missing = append(missing, fullMethod)
+			}
+		}
+	}
+	if len(missing) > 0 {
+		sort.Strings(missing)
+		return fmt.Errorf("no auth policy for methods: %s", strings.Join(missing, ", "))
+	}
+	return nil
+}
+
+var (
+	errUnauthenticated  = status.Error(codes.Unauthenticated, "unauthenticated")
+	errPermissionDenied = status.Error(codes.PermissionDenied, "permission denied")
+	errImpersonation    = status.Error(codes.PermissionDenied, "not allowed with an impersonation token")
+	errUnavailable      = status.Error(codes.Unavailable, domain.ErrServiceUnavailable.Error())
+)
+
+// AccountLookup — status keshi ishlamaganda DB dagi status uchun (domain.UserRepository)
+type AccountLookup interface {
+	GetByID(ctx context.Context, id string) (*domain.User, error)
+}
+
+type Authenticator struct {
+	tokens        domain.TokenProvider
+	statuses      domain.AccountStatusCache
+	users         AccountLookup
+	policy        Policy
+	serviceTokens map[string]string // token hash => servis nomi
+}
+
+// NewAuthenticator — serviceTokens: servis nomi => token (config dagi SERVICE_TOKENS)
+func NewAuthenticator(tokens domain.TokenProvider, statuses domain.AccountStatusCache, users AccountLookup, policy Policy, serviceTokens map[string]string) *Authenticator {
+	hashed := make(map[string]string, len(serviceTokens))
+	for name, token := range serviceTokens {
+		hashed[utils.HashToken(token)] = name
+	}
+	return &Authenticator{tokens: tokens, statuses: statuses, users: users, policy: policy, serviceTokens: hashed}
+}
+
+func (a *Authenticator) Unary() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		ctx, err := a.authenticate(ctx, info.FullMethod)
+		if err != nil {
+			return nil, err
+		}
+		return handler(ctx, req)
+	}
+}
+
+func (a *Authenticator) Stream() grpc.StreamServerInterceptor {
+	return func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		ctx, err := a.authenticate(ss.Context(), info.FullMethod)
+		if err != nil {
+			return err
+		}
+		return handler(srv, &authStream{ServerStream: ss, ctx: ctx})
+	}
+}
+
+// authStream — handler ga yangilangan (user ma'lumotli) ctx ni beradi
+type authStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s *authStream) Context() context.Context {
+	return s.ctx
+}
+
+func (a *Authenticator) authenticate(ctx context.Context, method string) (context.Context, error) {
+	access, ok := a.policy[method]
+	if !ok {
+		return nil, errPermissionDenied
+	}
+	if access == Public {
+		return ctx, nil
+	}
+
+	token := bearerToken(ctx)
+	if token == "" {
+		return nil, errUnauthenticated
+	}
+
+	if access == Service {
+		name, ok := a.serviceTokens[utils.HashToken(token)]
+		if !ok {
+			return nil, errUnauthenticated
+		}
+		return utils.WithService(ctx, name), nil
+	}
+
+	claims, err := a.tokens.ValidateAccessToken(token)
+	if errors.Is(err, domain.ErrServiceUnavailable) {
+		// Redis ishlamasa tokenni "yaroqsiz" deb bo'lmaydi — klient qayta urinadi
+		log.Println("token revocation store unavailable:", err)
+		return nil, errUnavailable
+	}
+	if err != nil {
+		return nil, errUnauthenticated
+	}
+
+	// Suspended/banned userning hali amal qilayotgan access tokenlari ham rad etiladi
+	if err := a.checkAccountStatus(ctx, claims.UserID); err != nil {
+		return nil, err
+	}
+
+	// Impersonatsiya tokeni faqat Authenticated metodlarda ishlaydi; har bir chaqiruv logga yoziladi
+	if claims.ActorID != "" {
+		if access != Authenticated {
+			log.Printf("impersonation denied: actor=%s user=%s method=%s", claims.ActorID, claims.UserID, method)
+			return nil, errImpersonation
+		}
+		log.Printf("impersonation: actor=%s user=%s method=%s", claims.ActorID, claims.UserID, method)
+		ctx = utils.WithActor(ctx, claims.ActorID)
+	}
+
+	switch access {
+	case Staff:
+		if !slices.Contains(claims.Roles, RoleModerator) && !slices.Contains(claims.Roles, RoleAdmin) {
+			return nil, errPermissionDenied
+		}
+	case Admin:
+		if !slices.Contains(claims.Roles, RoleAdmin) {
+			return nil, errPermissionDenied
+		}
+	}
+	return utils.WithUser(ctx, claims.UserID, claims.SessionID, claims.Roles), nil
+}
+
+// checkAccountStatus — avval AccountStatusCache, u ishlamasa DB dagi status (IntrospectToken dagi kabi).
+// Ikkalasi ham javob bermasa so'rov o'tkazilmaydi — klient qayta urinadi.
+func (a *Authenticator) checkAccountStatus(ctx context.Context, userID string) error {
+	restriction, err := a.statuses.Restriction(ctx, userID)
+	if err != nil {
+		log.Println("account status cache unavailable, falling back to DB:", err)
+		user, err := a.users.GetByID(ctx, userID)
+		if err != nil {
+			log.Println("account status lookup failed:", err)
+			return errUnavailable
+		}
+		if user == nil {
+			return errUnauthenticated
+		}
+		restriction = user.Restriction(time.Now())
+	}
+	if restriction != nil {
+		return AccountRestrictedError(restriction)
+	}
+	return nil
+}
+
+// AccountRestrictedError — PermissionDenied + ErrorInfo{Reason: ACCOUNT_SUSPENDED | ACCOUNT_BANNED |
+// ACCOUNT_PENDING_DELETION}, klient suspension ekranini ko'rsatishi uchun. Handler xatolari ham shu formatda.
+func AccountRestrictedError(e *domain.AccountRestrictedError) error {
+	metadata := map[string]string{"status": string(e.Status)}
+	if e.Reason != "" {
+		metadata["reason"] = e.Reason
+	}
+	if e.Until != nil {
+		metadata["until"] = e.Until.UTC().Format(time.RFC3339)
+	}
+
+	st := status.New(codes.PermissionDenied, e.Error())
+	detailed, err := st.WithDetails(&errdetails.ErrorInfo{
+		Reason:   "ACCOUNT_" + strings.ToUpper(string(e.Status)),
+		Domain:   "user-service",
+		Metadata: metadata,
+	})
+	if err != nil {
+		return st.Err()
+	}
+	return detailed.Err()
+}
+
+func bearerToken(ctx context.Context) string {
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok {
+		return ""
+	}
+	vals := md.Get("authorization")
+	if len(vals) == 0 {
+		return ""
+	}
+	return strings.TrimPrefix(vals[0], "Bearer ")
+}
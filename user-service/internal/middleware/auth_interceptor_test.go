@@ -0,0 +1,222 @@
+package middleware
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"user-service/internal/domain"
+	"user-service/internal/utils"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/go-redis/redis/v8"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	testUserID  = "11111111-1111-1111-1111-111111111111"
+	testActorID = "22222222-2222-2222-2222-222222222222"
+)
+
+// testStatuses — err berilsa kesh ishlamayotgandek javob qaytaradi
+type testStatuses struct {
+	restrictions map[string]*domain.AccountRestrictedError
+	err          error
+}
+
+func (c testStatuses) Restriction(ctx context.Context, userID string) (*domain.AccountRestrictedError, error) {
+	if c.err != nil {
+		return nil, c.err
+	}
+	return c.restrictions[userID], nil
+}
+
+func (c testStatuses) SetRestriction(ctx context.Context, userID string, r *domain.AccountRestrictedError) error {
+	return c.err
+}
+
+func (c testStatuses) ClearRestriction(ctx context.Context, userID string) error { return c.err }
+
+// testUsers — DB dagi status; lookups chaqiruvlar sonini sanaydi
+type testUsers struct {
+	users   map[string]*domain.User
+	err     error
+	lookups *int
+}
+
+func (r testUsers) GetByID(ctx context.Context, id string) (*domain.User, error) {
+	if r.lookups != nil {
+		*r.lookups++
+	}
+	if r.err != nil {
+		return nil, r.err
+	}
+	return r.users[id], nil
+}
+
+func newTestTokens(t *testing.T) *utils.JWTProvider {
+	t.Helper()
+	key, err := utils.GenerateEphemeralKey()
+	if err != nil {
+		t.Fatal(err)
+	}
+	keys, err := utils.NewKeySet(key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	client := redis.NewClient(&redis.Options{Addr: miniredis.RunT(t).Addr()})
+	t.Cleanup(func() { client.Close() })
+	return utils.NewJWTProvider(keys, "refresh-secret", 15*time.Minute, time.Hour, client)
+}
+
+func accessToken(t *testing.T, tokens *utils.JWTProvider, userID string, roles ...string) string {
+	t.Helper()
+	pair, err := tokens.GenerateTokens(userID, "session-1", roles)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return pair.AccessToken
+}
+
+func withBearer(token string) context.Context {
+	return metadata.NewIncomingContext(context.Background(), metadata.Pairs("authorization", "Bearer "+token))
+}
+
+func TestAuthenticateFallsBackToDBWhenStatusCacheFails(t *testing.T) {
+	const method = "/user.UserService/GetMe"
+	cacheDown := errors.New("redis down")
+	banned := &domain.User{ID: testUserID, Status: domain.UserStatusBanned}
+	active := &domain.User{ID: testUserID, Status: domain.UserStatusActive}
+
+	tests := []struct {
+		name        string
+		statuses    testStatuses
+		users       testUsers
+		wantCode    codes.Code
+		wantLookups int
+	}{
+		{"cache hit, restricted", testStatuses{restrictions: map[string]*domain.AccountRestrictedError{testUserID: {Status: domain.UserStatusBanned}}}, testUsers{}, codes.PermissionDenied, 0},
+		{"cache hit, active", testStatuses{}, testUsers{}, codes.OK, 0},
+		{"cache down, banned in DB", testStatuses{err: cacheDown}, testUsers{users: map[string]*domain.User{testUserID: banned}}, codes.PermissionDenied, 1},
+		{"cache down, active in DB", testStatuses{err: cacheDown}, testUsers{users: map[string]*domain.User{testUserID: active}}, codes.OK, 1},
+		{"cache down, user deleted", testStatuses{err: cacheDown}, testUsers{users: map[string]*domain.User{}}, codes.Unauthenticated, 1},
+		{"cache and DB down", testStatuses{err: cacheDown}, testUsers{err: errors.New("db down")}, codes.Unavailable, 1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tokens := newTestTokens(t)
+			lookups := 0
+			tt.users.lookups = &lookups
+			a := NewAuthenticator(tokens, tt.statuses, tt.users, Policy{method: Authenticated}, nil)
+
+			_, err := a.authenticate(withBearer(accessToken(t, tokens, testUserID)), method)
+			if got := status.Code(err); got != tt.wantCode {
+				t.Errorf("code = %s, want %s (err = %v)", got, tt.wantCode, err)
+			}
+			if lookups != tt.wantLookups {
+				t.Errorf("DB lookups = %d, want %d", lookups, tt.wantLookups)
+			}
+		})
+	}
+}
+
+func TestPolicyVerify(t *testing.T) {
+	services := map[string]grpc.ServiceInfo{
+		"user.UserService": {Methods: []grpc.MethodInfo{{Name: "Login"}, {Name: "GetMe"}}},
+	}
+
+	if err := (Policy{"/user.UserService/Login": Public, "/user.UserService/GetMe": Authenticated}).Verify(services); err != nil {
+		t.Errorf("Verify with full policy: %v", err)
+	}
+
+	err := Policy{"/user.UserService/Login": Public}.Verify(services)
+	if err == nil || !strings.Contains(err.Error(), "/user.UserService/GetMe") {
+		t.Errorf("Verify with missing method: err = %v, want it to name /user.UserService/GetMe", err)
+	}
+}
+
+func TestAuthenticateAccessLevels(t *testing.T) {
+	const serviceToken = "chat-service-secret"
+	tokens := newTestTokens(t)
+	userToken := accessToken(t, tokens, testUserID)
+	moderatorToken := accessToken(t, tokens, testUserID, RoleModerator)
+	adminToken := accessToken(t, tokens, testUserID, RoleAdmin)
+	impersonationToken, _, err := tokens.GenerateImpersonationToken(testUserID, testActorID, 5*time.Minute)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	policy := Policy{
+		"/test/Public":        Public,
+		"/test/Authenticated": Authenticated,
+		"/test/Owner":         Owner,
+		"/test/Staff":         Staff,
+		"/test/Admin":         Admin,
+		"/test/Service":       Service,
+	}
+	a := NewAuthenticator(tokens, testStatuses{}, testUsers{}, policy, map[string]string{"chat-service": serviceToken})
+
+	tests := []struct {
+		name      string
+		method    string
+		token     string
+		wantCode  codes.Code
+		wantActor string
+	}{
+		{"unknown method", "/test/Missing", userToken, codes.PermissionDenied, ""},
+		{"public without token", "/test/Public", "", codes.OK, ""},
+		{"authenticated without token", "/test/Authenticated", "", codes.Unauthenticated, ""},
+		{"authenticated with garbage", "/test/Authenticated", "not-a-jwt", codes.Unauthenticated, ""},
+		{"authenticated with user token", "/test/Authenticated", userToken, codes.OK, ""},
+		{"authenticated with impersonation", "/test/Authenticated", impersonationToken, codes.OK, testActorID},
+		{"owner with user token", "/test/Owner", userToken, codes.OK, ""},
+		{"owner with impersonation", "/test/Owner", impersonationToken, codes.PermissionDenied, ""},
+		{"staff with user token", "/test/Staff", userToken, codes.PermissionDenied, ""},
+		{"staff with moderator token", "/test/Staff", moderatorToken, codes.OK, ""},
+		{"admin with moderator token", "/test/Admin", moderatorToken, codes.PermissionDenied, ""},
+		{"admin with admin token", "/test/Admin", adminToken, codes.OK, ""},
+		{"service with user token", "/test/Service", userToken, codes.Unauthenticated, ""},
+		{"service with admin token", "/test/Service", adminToken, codes.Unauthenticated, ""},
+		{"service with service token", "/test/Service", serviceToken, codes.OK, ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			if tt.token != "" {
+				ctx = withBearer(tt.token)
+			}
+
+			ctx, err := a.authenticate(ctx, tt.method)
+			if got := status.Code(err); got != tt.wantCode {
+				t.Fatalf("code = %s, want %s (err = %v)", got, tt.wantCode, err)
+			}
+			if err != nil {
+				return
+			}
+			if tt.method == "/test/Public" {
+				return
+			}
+			if tt.method == "/test/Service" {
+				if name, ok := utils.ServiceFromContext(ctx); !ok || name != "chat-service" {
+					t.Errorf("service = %q, want chat-service", name)
+				}
+				if _, ok := utils.UserIDFromContext(ctx); ok {
+					t.Error("service call carries a user id")
+				}
+				return
+			}
+			// Handler doim token egasi (sub) nomidan ishlaydi, impersonatsiyada ham
+			if userID, _ := utils.UserIDFromContext(ctx); userID != testUserID {
+				t.Errorf("user id = %q, want %q", userID, testUserID)
+			}
+			if actor, _ := utils.ActorFromContext(ctx); actor != tt.wantActor {
+				t.Errorf("actor = %q, want %q", actor, tt.wantActor)
+			}
+		})
+	}
+}
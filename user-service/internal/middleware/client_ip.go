@@ -0,0 +1,33 @@
+package middleware
+
+import (
+	"context"
+
+	"user-service/internal/utils"
+
+	"google.golang.org/grpc"
+)
+
+// ClientIP — klient IP ni so'rov boshida bir marta aniqlab ctx ga yozadi.
+// Rate limit, login lockout va login tarixi utils.GetIPFromCtx orqali shu qiymatni ishlatadi.
+type ClientIP struct {
+	proxies *utils.TrustedProxies
+}
+
+func NewClientIP(proxies *utils.TrustedProxies) *ClientIP {
+	return &ClientIP{proxies: proxies}
+}
+
+// Unary — zanjirda birinchi bo'lib ulanadi
+func (c *ClientIP) Unary() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		return handler(utils.WithClientIP(ctx, c.proxies.ClientIP(ctx)), req)
+	}
+}
+
+func (c *ClientIP) Stream() grpc.StreamServerInterceptor {
+	return func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		ctx := utils.WithClientIP(ss.Context(), c.proxies.ClientIP(ss.Context()))
+		return handler(srv, &authStream{ServerStream: ss, ctx: ctx})
+	}
+}
@@ -0,0 +1,106 @@
+package middleware
+
+import (
+	"context"
+	"log"
+	"sync"
+	"time"
+
+	"user-service/internal/domain"
+)
+
+// memorySweepThreshold — kalitlar soni shundan oshsa bo'sh oynalar tozalanadi
+const memorySweepThreshold = 10000
+
+type memoryLimiter struct {
+	mu      sync.Mutex
+	entries map[string]*memoryEntry
+}
+
+// memoryEntry — kalitning so'nggi hitlari va ular qaysi oyna bilan yozilgani
+// (sweep har kalitni o'z oynasi bo'yicha tozalashi uchun)
+type memoryEntry struct {
+	hits   []time.Time
+	window time.Duration
+}
+
+// NewMemoryLimiter — jarayon ichidagi sliding-window limiter. Bir nechta instansiyada
+// limitlar umumiy bo'lmaydi, shuning uchun faqat Redis ishlamay qolganda ishlatiladi.
+func NewMemoryLimiter() domain.RateLimiter {
+	return &memoryLimiter{entries: make(map[string]*memoryEntry)}
+}
+
+func (l *memoryLimiter) Allow(_ context.Context, key string, limit int, window time.Duration) (bool, time.Duration, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	now := time.Now()
+	if len(l.entries) > memorySweepThreshold {
+		l.sweep(now)
+	}
+
+	entry, ok := l.entries[key]
+	if !ok {
+		entry = &memoryEntry{}
+		l.entries[key] = entry
+	}
+	entry.window = window
+	entry.hits = prune(entry.hits, now.Add(-window))
+	if len(entry.hits) >= limit {
+		return false, entry.hits[0].Add(window).Sub(now), nil
+	}
+	entry.hits = append(entry.hits, now)
+	return true, 0, nil
+}
+
+// sweep — o'z oynasi butunlay o'tib ketgan kalitlarni o'chiradi
+func (l *memoryLimiter) sweep(now time.Time) {
+	for key, entry := range l.entries {
+		if len(entry.hits) == 0 || now.Sub(entry.hits[len(entry.hits)-1]) > entry.window {
+			delete(l.entries, key)
+		}
+	}
+}
+
+func prune(hits []time.Time, since time.Time) []time.Time {
+	i := 0
+	for i < len(hits) && !hits[i].After(since) {
+		i++
+	}
+	return hits[i:]
+}
+
+type fallbackLimiter struct {
+	primary  domain.RateLimiter
+	fallback domain.RateLimiter
+
+	mu        sync.Mutex
+	downUntil time.Time
+}
+
+// fallbackCooldown — primary xato bergandan keyin unga qayta murojaat qilinmaydigan vaqt
+// (har so'rovda ishlamayotgan Redis timeoutini kutmaslik uchun)
+const fallbackCooldown = 5 * time.Second
+
+// NewFallbackLimiter — primary (Redis) xato qaytarsa, fallback (jarayon ichidagi) limiter ishlaydi
+func NewFallbackLimiter(primary, fallback domain.RateLimiter) domain.RateLimiter {
+	return &fallbackLimiter{primary: primary, fallback: fallback}
+}
+
+func (l *fallbackLimiter) Allow(ctx context.Context, key string, limit int, window time.Duration) (bool, time.Duration, error) {
+	l.mu.Lock()
+	down := time.Now().Before(l.downUntil)
+	l.mu.Unlock()
+
+	if !down {
+		allowed, retryAfter, err := l.primary.Allow(ctx, key, limit, window)
+		if err == nil {
+			return allowed, retryAfter, nil
+		}
+		l.mu.Lock()
+		l.downUntil = time.Now().Add(fallbackCooldown)
+		l.mu.Unlock()
+		log.Printf("rate limiter: primary unavailable, using in-process fallback: %v", err)
+	}
+	return l.fallback.Allow(ctx, key, limit, window)
+}
@@ -0,0 +1,37 @@
+package middleware
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+)
+
+func TestMemoryLimiterSweepKeepsLongWindows(t *testing.T) {
+	ctx := context.Background()
+	l := NewMemoryLimiter().(*memoryLimiter)
+
+	if ok, _, _ := l.Allow(ctx, "long", 1, time.Hour); !ok {
+		t.Fatal("first request on long window denied")
+	}
+	expired := time.Now().Add(-time.Second)
+	for i := 0; i <= memorySweepThreshold; i++ {
+		l.entries[fmt.Sprintf("short:%d", i)] = &memoryEntry{hits: []time.Time{expired}, window: time.Millisecond}
+	}
+
+	time.Sleep(5 * time.Millisecond)
+
+	// Qisqa oynali chaqiruv sweep ni ishga tushiradi
+	l.Allow(ctx, "trigger", 1, time.Millisecond)
+
+	if n := len(l.entries); n != 2 {
+		t.Errorf("%d keys left after sweep, want 2 (long, trigger)", n)
+	}
+	ok, retryAfter, _ := l.Allow(ctx, "long", 1, time.Hour)
+	if ok {
+		t.Fatal("long-window limit reset by a sweep triggered from a short window")
+	}
+	if retryAfter < 59*time.Minute {
+		t.Errorf("retryAfter = %s, want ~1h", retryAfter)
+	}
+}
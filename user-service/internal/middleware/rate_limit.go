@@ -0,0 +1,91 @@
+package middleware
+
+import (
+	"context"
+	"time"
+
+	"user-service/internal/domain"
+	"user-service/internal/utils"
+
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/durationpb"
+)
+
+// Limit — oynada ruxsat etilgan so'rovlar soni (Requests <= 0 => cheklanmaydi)
+type Limit struct {
+	Requests int
+	Window   time.Duration
+}
+
+// RateLimiter — gRPC metodlari uchun limitlar. Kalit: metod + user ID (token bilan
+// kelgan so'rovlar) yoki klient IP (ochiq metodlar: Register, ForgotPassword, ...).
+type RateLimiter struct {
+	limiter  domain.RateLimiter
+	methods  map[string]Limit // to'liq metod nomi => limit
+	fallback Limit            // ro'yxatda bo'lmagan metodlar uchun
+}
+
+func NewRateLimiter(limiter domain.RateLimiter, methods map[string]Limit, fallback Limit) *RateLimiter {
+	return &RateLimiter{limiter: limiter, methods: methods, fallback: fallback}
+}
+
+// Unary — auth interceptordan keyin ulanadi, shunda ctx da userID bo'ladi
+func (r *RateLimiter) Unary() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		if err := r.check(ctx, info.FullMethod); err != nil {
+			return nil, err
+		}
+		return handler(ctx, req)
+	}
+}
+
+// Stream — stream ochilishini cheklaydi (stream ichidagi xabarlarni emas)
+func (r *RateLimiter) Stream() grpc.StreamServerInterceptor {
+	return func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		if err := r.check(ss.Context(), info.FullMethod); err != nil {
+			return err
+		}
+		return handler(srv, ss)
+	}
+}
+
+func (r *RateLimiter) check(ctx context.Context, method string) error {
+	limit, ok := r.methods[method]
+	if !ok {
+		limit = r.fallback
+	}
+	if limit.Requests <= 0 {
+		return nil
+	}
+
+	key := method + ":" + identity(ctx)
+	allowed, retryAfter, err := r.limiter.Allow(ctx, key, limit.Requests, limit.Window)
+	if err != nil {
+		// Limiter umuman ishlamasa so'rovni to'xtatmaymiz
+		return nil
+	}
+	if !allowed {
+		return rateLimitedError(retryAfter)
+	}
+	return nil
+}
+
+// identity — autentifikatsiya qilingan user, aks holda klient IP
+func identity(ctx context.Context) string {
+	if userID, ok := utils.UserIDFromContext(ctx); ok {
+		return "user:" + userID
+	}
+	return "ip:" + utils.GetIPFromCtx(ctx)
+}
+
+func rateLimitedError(retryAfter time.Duration) error {
+	st := status.New(codes.ResourceExhausted, "rate limit exceeded, try again later")
+	detailed, err := st.WithDetails(&errdetails.RetryInfo{RetryDelay: durationpb.New(retryAfter)})
+	if err != nil {
+		return st.Err()
+	}
+	return detailed.Err()
+}
@@ -0,0 +1,329 @@
+package oidc
+
+import (
+	"context"
+	"crypto"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"user-service/internal/domain"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+// ProviderConfig — bitta tashqi provayder (Google, Apple, GitHub yoki istalgan OIDC issuer).
+// Issuer berilsa, bo'sh endpointlar discovery orqali to'ldiriladi. ID token qaytarmaydigan
+// OAuth2 provayderlar (GitHub) uchun UserInfoURL ishlatiladi.
+type ProviderConfig struct {
+	Name         string
+	Issuer       string
+	ClientID     string
+	ClientSecret string
+	TokenURL     string
+	JWKSURL      string
+	UserInfoURL  string
+}
+
+// knownProviders — mashhur provayderlar uchun standart qiymatlar (konfiguratsiyada bo'sh qolsa)
+var knownProviders = map[string]ProviderConfig{
+	"google": {Issuer: "https://accounts.google.com"},
+	"apple":  {Issuer: "https://appleid.apple.com"},
+	"github": {
+		TokenURL:    "https://github.com/login/oauth/access_token",
+		UserInfoURL: "https://api.github.com/user",
+	},
+}
+
+// jwksRefreshInterval — noma'lum kid kelganda JWKS ni qayta yuklashlar orasidagi minimal vaqt
+const jwksRefreshInterval = time.Minute
+
+type provider struct {
+	cfg ProviderConfig
+
+	mu          sync.Mutex
+	discovered  bool
+	keys        map[string]crypto.PublicKey
+	keysFetched time.Time
+}
+
+type Client struct {
+	http      *http.Client
+	providers map[string]*provider
+}
+
+// NewClient — domain.IdentityProvider ni OIDC / OAuth2 authorization code flow orqali amalga oshiradi
+func NewClient(configs []ProviderConfig, httpClient *http.Client) *Client {
+	if httpClient == nil {
+		httpClient = &http.Client{Timeout: 10 * time.Second}
+	}
+	c := &Client{http: httpClient, providers: make(map[string]*provider, len(configs))}
+	for _, cfg := range configs {
+		if known, ok := knownProviders[cfg.Name]; ok {
+			cfg = withDefaults(cfg, known)
+		}
+		c.providers[cfg.Name] = &provider{cfg: cfg}
+	}
+	return c
+}
+
+func withDefaults(cfg, known ProviderConfig) ProviderConfig {
+	if cfg.Issuer == "" {
+		cfg.Issuer = known.Issuer
+	}
+	if cfg.TokenURL == "" {
+		cfg.TokenURL = known.TokenURL
+	}
+	if cfg.JWKSURL == "" {
+		cfg.JWKSURL = known.JWKSURL
+	}
+	if cfg.UserInfoURL == "" {
+		cfg.UserInfoURL = known.UserInfoURL
+	}
+	return cfg
+}
+
+// Exchange — authorization code ni tokenlarga almashtiradi va foydalanuvchini aniqlaydi
+func (c *Client) Exchange(ctx context.Context, req domain.ProviderAuthRequest) (*domain.ExternalIdentity, error) {
+	p, ok := c.providers[req.Provider]
+	if !ok {
+		return nil, domain.ErrUnknownProvider
+	}
+	if err := c.discover(ctx, p); err != nil {
+		return nil, fmt.Errorf("%w: discovery: %v", domain.ErrProviderAuthFailed, err)
+	}
+
+	tokens, err := c.exchangeCode(ctx, p, req)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", domain.ErrProviderAuthFailed, err)
+	}
+
+	var identity *domain.ExternalIdentity
+	switch {
+	case tokens.IDToken != "":
+		identity, err = c.verifyIDToken(ctx, p, tokens.IDToken, req.Nonce)
+	case p.cfg.UserInfoURL != "":
+		identity, err = c.fetchUserInfo(ctx, p, tokens.AccessToken)
+	default:
+		err = errors.New("provider returned no id_token")
+	}
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", domain.ErrProviderAuthFailed, err)
+	}
+	identity.Provider = p.cfg.Name
+	return identity, nil
+}
+
+// discover — .well-known/openid-configuration dan bo'sh endpointlarni to'ldiradi (bir marta)
+func (c *Client) discover(ctx context.Context, p *provider) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.discovered || p.cfg.Issuer == "" {
+		return nil
+	}
+	if p.cfg.TokenURL != "" && p.cfg.JWKSURL != "" {
+		p.discovered = true
+		return nil
+	}
+
+	var doc struct {
+		Issuer           string `json:"issuer"`
+		TokenEndpoint    string `json:"token_endpoint"`
+		JWKSURI          string `json:"jwks_uri"`
+		UserInfoEndpoint string `json:"userinfo_endpoint"`
+	}
+	wellKnown := strings.TrimSuffix(p.cfg.Issuer, "/") + "/.well-known/openid-configuration"
+	if err := c.getJSON(ctx, wellKnown, "", &doc); err != nil {
+		return err
+	}
+	if doc.Issuer != p.cfg.Issuer {
+		return fmt.Errorf("issuer mismatch: %q", doc.Issuer)
+	}
+
+	if p.cfg.TokenURL == "" {
+		p.cfg.TokenURL = doc.TokenEndpoint
+	}
+	if p.cfg.JWKSURL == "" {
+		p.cfg.JWKSURL = doc.JWKSURI
+	}
+	if p.cfg.UserInfoURL == "" {
+		p.cfg.UserInfoURL = doc.UserInfoEndpoint
+	}
+	p.discovered = true
+	return nil
+}
+
+type tokenResponse struct {
+	AccessToken string `json:"access_token"`
+	IDToken     string `json:"id_token"`
+	Error       string `json:"error"`
+}
+
+func (c *Client) exchangeCode(ctx context.Context, p *provider, req domain.ProviderAuthRequest) (*tokenResponse, error) {
+	form := url.Values{
+		"grant_type":    {"authorization_code"},
+		"code":          {req.Code},
+		"redirect_uri":  {req.RedirectURI},
+		"client_id":     {p.cfg.ClientID},
+		"client_secret": {p.cfg.ClientSecret},
+	}
+	if req.CodeVerifier != "" {
+		form.Set("code_verifier", req.CodeVerifier)
+	}
+
+	httpReq, err := http.NewRequestWithContext(ctx, http.MethodPost, p.cfg.TokenURL, strings.NewReader(form.Encode()))
+	if err != nil {
+		return nil, err
+	}
+	httpReq.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	httpReq.Header.Set("Accept", "application/json")
+
+	resp, err := c.http.Do(httpReq)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	var tokens tokenResponse
+	if err := json.NewDecoder(resp.Body).Decode(&tokens); err != nil {
+		return nil, err
+	}
+	if resp.StatusCode != http.StatusOK || tokens.Error != "" {
+		return nil, fmt.Errorf("token endpoint: status %d %s", resp.StatusCode, tokens.Error)
+	}
+	return &tokens, nil
+}
+
+// verifyIDToken — imzo (issuer JWKS), iss, aud, exp va nonce ni tekshiradi
+func (c *Client) verifyIDToken(ctx context.Context, p *provider, idToken, nonce string) (*domain.ExternalIdentity, error) {
+	claims := jwt.MapClaims{}
+	_, err := jwt.ParseWithClaims(idToken, claims, func(t *jwt.Token) (interface{}, error) {
+		kid, _ := t.Header["kid"].(string)
+		return c.lookupKey(ctx, p, kid)
+	},
+		jwt.WithValidMethods([]string{"RS256", "RS384", "RS512", "ES256", "ES384", "ES512", "EdDSA"}),
+		jwt.WithIssuer(p.cfg.Issuer),
+		jwt.WithAudience(p.cfg.ClientID),
+		jwt.WithExpirationRequired(),
+	)
+	if err != nil {
+		return nil, err
+	}
+	if nonce != "" {
+		if got, _ := claims["nonce"].(string); got != nonce {
+			return nil, errors.New("nonce mismatch")
+		}
+	}
+
+	sub, _ := claims["sub"].(string)
+	if sub == "" {
+		return nil, errors.New("id_token has no subject")
+	}
+	email, _ := claims["email"].(string)
+	name, _ := claims["name"].(string)
+	picture, _ := claims["picture"].(string)
+	return &domain.ExternalIdentity{
+		Subject:       sub,
+		Email:         strings.ToLower(email),
+		EmailVerified: parseBool(claims["email_verified"]),
+		Name:          name,
+		AvatarURL:     picture,
+	}, nil
+}
+
+// lookupKey — kid bo'yicha kalit; topilmasa (issuer kalitni almashtirgan bo'lishi mumkin)
+// JWKS qayta yuklanadi, lekin jwksRefreshInterval dan tez-tez emas
+func (c *Client) lookupKey(ctx context.Context, p *provider, kid string) (crypto.PublicKey, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if key, ok := p.keys[kid]; ok {
+		return key, nil
+	}
+	if time.Since(p.keysFetched) < jwksRefreshInterval {
+		return nil, fmt.Errorf("unknown key id %q", kid)
+	}
+
+	keys, err := fetchJWKS(ctx, c.http, p.cfg.JWKSURL)
+	if err != nil {
+		return nil, err
+	}
+	p.keys = keys
+	p.keysFetched = time.Now()
+
+	if key, ok := p.keys[kid]; ok {
+		return key, nil
+	}
+	return nil, fmt.Errorf("unknown key id %q", kid)
+}
+
+// fetchUserInfo — ID token bermaydigan provayderlar (GitHub) uchun profil.
+// Bunday provayderlar emailni tasdiqlangan deb kafolatlamaydi.
+func (c *Client) fetchUserInfo(ctx context.Context, p *provider, accessToken string) (*domain.ExternalIdentity, error) {
+	var info map[string]any
+	if err := c.getJSON(ctx, p.cfg.UserInfoURL, accessToken, &info); err != nil {
+		return nil, err
+	}
+
+	sub, _ := info["sub"].(string)
+	if sub == "" {
+		// GitHub: raqamli "id"
+		if id, ok := info["id"].(float64); ok {
+			sub = strconv.FormatInt(int64(id), 10)
+		}
+	}
+	if sub == "" {
+		return nil, errors.New("userinfo has no subject")
+	}
+
+	email, _ := info["email"].(string)
+	name, _ := info["name"].(string)
+	avatar, _ := info["picture"].(string)
+	if avatar == "" {
+		avatar, _ = info["avatar_url"].(string)
+	}
+	return &domain.ExternalIdentity{
+		Subject:       sub,
+		Email:         strings.ToLower(email),
+		EmailVerified: parseBool(info["email_verified"]),
+		Name:          name,
+		AvatarURL:     avatar,
+	}, nil
+}
+
+func (c *Client) getJSON(ctx context.Context, url, bearer string, out any) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Accept", "application/json")
+	if bearer != "" {
+		req.Header.Set("Authorization", "Bearer "+bearer)
+	}
+	resp, err := c.http.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("GET %s: unexpected status %d", url, resp.StatusCode)
+	}
+	return json.NewDecoder(resp.Body).Decode(out)
+}
+
+// parseBool — Apple email_verified ni "true" satr ko'rinishida yuboradi
+func parseBool(v any) bool {
+	switch b := v.(type) {
+	case bool:
+		return b
+	case string:
+		return b == "true"
+	}
+	return false
+}
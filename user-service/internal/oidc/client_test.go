@@ -0,0 +1,254 @@
+package oidc
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"user-service/internal/domain"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+const (
+	testProvider = "test"
+	testClientID = "client-1"
+	testNonce    = "nonce-1"
+)
+
+// fakeIssuer — discovery, token va JWKS endpointlariga ega lokal OIDC issuer
+type fakeIssuer struct {
+	*httptest.Server
+
+	mu        sync.Mutex
+	keys      map[string]*rsa.PrivateKey // JWKS da e'lon qilingan kalitlar
+	signKid   string
+	claims    jwt.MapClaims // navbatdagi id_token claimlari
+	jwksFetch int
+}
+
+func newFakeIssuer(t *testing.T) *fakeIssuer {
+	t.Helper()
+	f := &fakeIssuer{keys: make(map[string]*rsa.PrivateKey)}
+	f.addKey(t, "key-1")
+	f.signKid = "key-1"
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/.well-known/openid-configuration", func(w http.ResponseWriter, r *http.Request) {
+		writeJSON(w, map[string]string{
+			"issuer":         f.URL,
+			"token_endpoint": f.URL + "/token",
+			"jwks_uri":       f.URL + "/jwks",
+		})
+	})
+	mux.HandleFunc("/token", func(w http.ResponseWriter, r *http.Request) {
+		if err := r.ParseForm(); err != nil || r.PostForm.Get("code") == "" {
+			w.WriteHeader(http.StatusBadRequest)
+			writeJSON(w, map[string]string{"error": "invalid_request"})
+			return
+		}
+		writeJSON(w, map[string]string{"access_token": "access", "id_token": f.idToken(t)})
+	})
+	mux.HandleFunc("/jwks", func(w http.ResponseWriter, r *http.Request) {
+		f.mu.Lock()
+		defer f.mu.Unlock()
+		f.jwksFetch++
+		keys := make([]map[string]string, 0, len(f.keys))
+		for kid, k := range f.keys {
+			keys = append(keys, map[string]string{
+				"kty": "RSA",
+				"kid": kid,
+				"use": "sig",
+				"n":   base64.RawURLEncoding.EncodeToString(k.N.Bytes()),
+				"e":   base64.RawURLEncoding.EncodeToString(big.NewInt(int64(k.E)).Bytes()),
+			})
+		}
+		writeJSON(w, map[string]any{"keys": keys})
+	})
+
+	f.Server = httptest.NewServer(mux)
+	t.Cleanup(f.Close)
+	f.claims = f.validClaims()
+	return f
+}
+
+func (f *fakeIssuer) addKey(t *testing.T, kid string) {
+	t.Helper()
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+	f.mu.Lock()
+	f.keys[kid] = key
+	f.mu.Unlock()
+}
+
+func (f *fakeIssuer) validClaims() jwt.MapClaims {
+	now := time.Now()
+	return jwt.MapClaims{
+		"iss":            f.URL,
+		"aud":            testClientID,
+		"sub":            "subject-1",
+		"iat":            now.Unix(),
+		"exp":            now.Add(5 * time.Minute).Unix(),
+		"nonce":          testNonce,
+		"email":          "User@Example.com",
+		"email_verified": true,
+		"name":           "Test User",
+	}
+}
+
+func (f *fakeIssuer) idToken(t *testing.T) string {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	token := jwt.NewWithClaims(jwt.SigningMethodRS256, f.claims)
+	token.Header["kid"] = f.signKid
+	signed, err := token.SignedString(f.keys[f.signKid])
+	if err != nil {
+		t.Error(err)
+	}
+	return signed
+}
+
+func (f *fakeIssuer) fetches() int {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.jwksFetch
+}
+
+func (f *fakeIssuer) client() *Client {
+	return NewClient([]ProviderConfig{{
+		Name:         testProvider,
+		Issuer:       f.URL,
+		ClientID:     testClientID,
+		ClientSecret: "secret",
+	}}, f.Server.Client())
+}
+
+func writeJSON(w http.ResponseWriter, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(v)
+}
+
+func exchange(c *Client) (*domain.ExternalIdentity, error) {
+	return c.Exchange(context.Background(), domain.ProviderAuthRequest{
+		Provider:    testProvider,
+		Code:        "code-1",
+		RedirectURI: "https://app.example/callback",
+		Nonce:       testNonce,
+	})
+}
+
+func TestExchangeValidIDToken(t *testing.T) {
+	issuer := newFakeIssuer(t)
+
+	identity, err := exchange(issuer.client())
+	if err != nil {
+		t.Fatalf("Exchange: %v", err)
+	}
+	want := domain.ExternalIdentity{
+		Provider:      testProvider,
+		Subject:       "subject-1",
+		Email:         "user@example.com",
+		EmailVerified: true,
+		Name:          "Test User",
+	}
+	if *identity != want {
+		t.Errorf("identity = %+v, want %+v", *identity, want)
+	}
+}
+
+func TestExchangeRejectsInvalidIDToken(t *testing.T) {
+	tests := []struct {
+		name   string
+		mutate func(claims jwt.MapClaims)
+	}{
+		{"wrong issuer", func(c jwt.MapClaims) { c["iss"] = "https://evil.example" }},
+		{"wrong audience", func(c jwt.MapClaims) { c["aud"] = "another-client" }},
+		{"expired", func(c jwt.MapClaims) { c["exp"] = time.Now().Add(-time.Minute).Unix() }},
+		{"missing expiry", func(c jwt.MapClaims) { delete(c, "exp") }},
+		{"nonce mismatch", func(c jwt.MapClaims) { c["nonce"] = "replayed-nonce" }},
+		{"missing subject", func(c jwt.MapClaims) { delete(c, "sub") }},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			issuer := newFakeIssuer(t)
+			tt.mutate(issuer.claims)
+
+			_, err := exchange(issuer.client())
+			if !errors.Is(err, domain.ErrProviderAuthFailed) {
+				t.Fatalf("err = %v, want ErrProviderAuthFailed", err)
+			}
+		})
+	}
+}
+
+func TestExchangeRejectsUnknownSigningKey(t *testing.T) {
+	issuer := newFakeIssuer(t)
+	// JWKS da e'lon qilinmagan kalit bilan imzolangan token
+	rogue, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+	issuer.keys["rogue"] = rogue
+	issuer.signKid = "rogue"
+	c := issuer.client()
+	delete(issuer.keys, "rogue")
+
+	if _, err := exchange(c); !errors.Is(err, domain.ErrProviderAuthFailed) {
+		t.Fatalf("err = %v, want ErrProviderAuthFailed", err)
+	}
+}
+
+func TestUnknownKidRefetchesJWKS(t *testing.T) {
+	issuer := newFakeIssuer(t)
+	c := issuer.client()
+
+	if _, err := exchange(c); err != nil {
+		t.Fatalf("first Exchange: %v", err)
+	}
+	if got := issuer.fetches(); got != 1 {
+		t.Fatalf("JWKS fetches = %d, want 1", got)
+	}
+
+	// Issuer kalitni almashtirdi
+	issuer.addKey(t, "key-2")
+	issuer.signKid = "key-2"
+
+	// Oxirgi yuklashdan jwksRefreshInterval o'tmagan — qayta yuklanmaydi
+	if _, err := exchange(c); !errors.Is(err, domain.ErrProviderAuthFailed) {
+		t.Fatalf("Exchange within refresh interval: err = %v, want ErrProviderAuthFailed", err)
+	}
+	if got := issuer.fetches(); got != 1 {
+		t.Fatalf("JWKS fetches within refresh interval = %d, want 1", got)
+	}
+
+	p := c.providers[testProvider]
+	p.mu.Lock()
+	p.keysFetched = time.Now().Add(-2 * jwksRefreshInterval)
+	p.mu.Unlock()
+
+	if _, err := exchange(c); err != nil {
+		t.Fatalf("Exchange after key rotation: %v", err)
+	}
+	if got := issuer.fetches(); got != 2 {
+		t.Fatalf("JWKS fetches after key rotation = %d, want 2", got)
+	}
+}
+
+func TestExchangeUnknownProvider(t *testing.T) {
+	issuer := newFakeIssuer(t)
+	_, err := issuer.client().Exchange(context.Background(), domain.ProviderAuthRequest{Provider: "unknown", Code: "code"})
+	if !errors.Is(err, domain.ErrUnknownProvider) {
+		t.Fatalf("err = %v, want ErrUnknownProvider", err)
+	}
+}
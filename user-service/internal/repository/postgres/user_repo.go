@@ -3,11 +3,15 @@ package postgres
 import (
 	"context"
 	"database/sql"
+	"encoding/json"
 	"errors"
 	"fmt"
+	"strings"
+	"time"
 	"user-service/internal/domain"
 
 	"github.com/google/uuid"
+	"github.com/lib/pq"
 )
 
 type userRepository struct {
@@ -50,20 +54,33 @@ func (r *userRepository) Create(ctx context.Context, user *domain.User) error {
 		user.UserAgent,
 		user.Location,
 	)
+	return mapUserUniqueViolation(err)
+}
+
+// mapUserUniqueViolation — users jadvalidagi UNIQUE xatolarini domain xatolariga o'giradi
+// (xom Postgres xabari klientga chiqmasligi uchun)
+func mapUserUniqueViolation(err error) error {
+	var pqErr *pq.Error
+	if errors.As(err, &pqErr) && pqErr.Code == uniqueViolation {
+		switch pqErr.Constraint {
+		case "users_username_key":
+			return domain.ErrUsernameTaken
+		case "users_email_key":
+			return domain.ErrEmailTaken
+		}
+	}
 	return err
 }
 
 // ================== GET BY ID ==================
-func (r *userRepository) GetByID(ctx context.Context, id string) (*domain.User, error) {
-	query := `
-		SELECT id, username, email, password, full_name, avatar_url, language,
+const userColumns = `id, username, email, password, full_name, avatar_url, language,
 		       platform, device_id, registered_ip, user_agent, location,
-		       registered_at, updated_at
-		FROM users
-		WHERE id = $1
-	`
-	row := r.db.QueryRowContext(ctx, query, id)
+		       email_verified_at, pending_email, totp_secret, totp_enabled_at,
+		       role, status, status_reason, suspended_until, username_changed_at,
+		       discoverable, show_full_name, show_avatar, hide_last_seen, last_seen_at,
+		       registered_at, updated_at`
 
+func scanUser(row interface{ Scan(dest ...any) error }) (*domain.User, error) {
 	var user domain.User
 	err := row.Scan(
 		&user.ID,
@@ -78,53 +95,77 @@ func (r *userRepository) GetByID(ctx context.Context, id string) (*domain.User,
 		&user.RegisteredIP,
 		&user.UserAgent,
 		&user.Location,
+		&user.EmailVerifiedAt,
+		&user.PendingEmail,
+		&user.TOTPSecret,
+		&user.TOTPEnabledAt,
+		&user.Role,
+		&user.Status,
+		&user.StatusReason,
+		&user.SuspendedUntil,
+		&user.UsernameChangedAt,
+		&user.Privacy.Discoverable,
+		&user.Privacy.ShowFullName,
+		&user.Privacy.ShowAvatar,
+		&user.Privacy.HideLastSeen,
+		&user.LastSeenAt,
 		&user.RegisteredAt,
 		&user.UpdatedAt,
 	)
+	if err != nil {
+		return nil, err
+	}
+	return &user, nil
+}
+
+func (r *userRepository) GetByID(ctx context.Context, id string) (*domain.User, error) {
+	query := `
+		SELECT ` + userColumns + `
+		FROM users
+		WHERE id = $1
+	`
+	user, err := scanUser(r.db.QueryRowContext(ctx, query, id))
 	if err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
 			return nil, nil
 		}
 		return nil, err
 	}
-	return &user, nil
+	return user, nil
 }
 
 // ================== GET BY EMAIL ==================
 func (r *userRepository) GetByEmail(ctx context.Context, email string) (*domain.User, error) {
 	query := `
-		SELECT id, username, email, password, full_name, avatar_url, language,
-		       platform, device_id, registered_ip, user_agent, location,
-		       registered_at, updated_at
+		SELECT ` + userColumns + `
 		FROM users
 		WHERE email = $1
 	`
-	row := r.db.QueryRowContext(ctx, query, email)
+	user, err := scanUser(r.db.QueryRowContext(ctx, query, email))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return user, nil
+}
 
-	var user domain.User
-	err := row.Scan(
-		&user.ID,
-		&user.Username,
-		&user.Email,
-		&user.PasswordHash,
-		&user.FullName,
-		&user.AvatarURL,
-		&user.Language,
-		&user.Platform,
-		&user.DeviceID,
-		&user.RegisteredIP,
-		&user.UserAgent,
-		&user.Location,
-		&user.RegisteredAt,
-		&user.UpdatedAt,
-	)
+// GetByUsername — username CITEXT: registrga befarq
+func (r *userRepository) GetByUsername(ctx context.Context, username string) (*domain.User, error) {
+	query := `
+		SELECT ` + userColumns + `
+		FROM users
+		WHERE username = $1
+	`
+	user, err := scanUser(r.db.QueryRowContext(ctx, query, username))
 	if err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
 			return nil, nil
 		}
 		return nil, err
 	}
-	return &user, nil
+	return user, nil
 }
 
 // ================== UPDATE ONE FIELD ==================
@@ -132,8 +173,6 @@ func (r *userRepository) GetByEmail(ctx context.Context, email string) (*domain.
 func (r *userRepository) UpdateField(ctx context.Context, userID string, field string, value *string) error {
 	// Whitelist field names to avoid SQL injection
 	allowedFields := map[string]bool{
-		"username":   true,
-		"email":      true,
 		"full_name":  true,
 		"avatar_url": true,
 		"language":   true,
@@ -148,6 +187,33 @@ func (r *userRepository) UpdateField(ctx context.Context, userID string, field s
 	return err
 }
 
+// UpdateUsername — username_changed_at ham yangilanadi (cooldown uchun)
+func (r *userRepository) UpdateUsername(ctx context.Context, userID, username string) error {
+	_, err := r.db.ExecContext(ctx, `
+		UPDATE users SET username = $1, username_changed_at = NOW(), updated_at = NOW() WHERE id = $2
+	`, username, userID)
+	return mapUserUniqueViolation(err)
+}
+
+// TakenUsernames — candidates ichidan band bo'lganlari (kichik harfda)
+func (r *userRepository) TakenUsernames(ctx context.Context, candidates []string) (map[string]bool, error) {
+	rows, err := r.db.QueryContext(ctx, `SELECT lower(username) FROM users WHERE username = ANY($1::citext[])`, pq.Array(candidates))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	taken := make(map[string]bool)
+	for rows.Next() {
+		var name string
+		if err := rows.Scan(&name); err != nil {
+			return nil, err
+		}
+		taken[name] = true
+	}
+	return taken, rows.Err()
+}
+
 // ================== CHANGE PASSWORD ==================
 func (r *userRepository) ChangePassword(ctx context.Context, id, newHash string) error {
 	query := `
@@ -160,7 +226,7 @@ func (r *userRepository) ChangePassword(ctx context.Context, id, newHash string)
 	return err
 }
 
-func (r *userRepository) ResetPassword(ctx context.Context, id, newHash string) error{
+func (r *userRepository) ResetPassword(ctx context.Context, id, newHash string) error {
 	query := `
 		UPDATE users
 		SET password = $1,
@@ -171,18 +237,646 @@ func (r *userRepository) ResetPassword(ctx context.Context, id, newHash string)
 	return err
 }
 
+func (r *userRepository) RehashPassword(ctx context.Context, id, oldHash, newHash string) error {
+	_, err := r.db.ExecContext(ctx, `UPDATE users SET password = $1 WHERE id = $2 AND password = $3`, newHash, id, oldHash)
+	return err
+}
+
+// ================== PASSWORD RESET TOKENS ==================
+func (r *userRepository) CreatePasswordResetToken(ctx context.Context, email, tokenHash string, expiresAt time.Time) error {
+	query := `
+		INSERT INTO password_reset_tokens (email, token, expires_at)
+		VALUES ($1, $2, $3)
+	`
+	_, err := r.db.ExecContext(ctx, query, email, tokenHash, expiresAt)
+	return err
+}
+
+// ConsumePasswordResetToken — tokenni bir martalik ishlatadi va shu email uchun
+// qolgan barcha faol tokenlarni ham bekor qiladi
+func (r *userRepository) ConsumePasswordResetToken(ctx context.Context, tokenHash string) (string, error) {
+	query := `
+		UPDATE password_reset_tokens
+		SET used_at = NOW()
+		WHERE token = $1 AND used_at IS NULL AND expires_at > NOW()
+		RETURNING email
+	`
+	var email string
+	if err := r.db.QueryRowContext(ctx, query, tokenHash).Scan(&email); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return "", domain.ErrInvalidResetToken
+		}
+		return "", err
+	}
+
+	_, err := r.db.ExecContext(ctx,
+		`UPDATE password_reset_tokens SET used_at = NOW() WHERE email = $1 AND used_at IS NULL`, email)
+	return email, err
+}
+
+// ================== EMAIL VERIFICATION ==================
+func (r *userRepository) SetPendingEmail(ctx context.Context, userID string, email *string) error {
+	_, err := r.db.ExecContext(ctx,
+		`UPDATE users SET pending_email = $1, updated_at = NOW() WHERE id = $2`, email, userID)
+	return err
+}
+
+// MarkEmailVerified — emailni tasdiqlangan deb belgilaydi. Agar u pending_email bo'lsa,
+// asosiy email shu manzilga almashtiriladi.
+func (r *userRepository) MarkEmailVerified(ctx context.Context, userID, email string) error {
+	query := `
+		UPDATE users
+		SET email = $2,
+		    email_verified_at = NOW(),
+		    pending_email = CASE WHEN pending_email = $2 THEN NULL ELSE pending_email END,
+		    updated_at = NOW()
+		WHERE id = $1
+	`
+	// GetByEmail tekshiruvi va UPDATE orasida manzilni boshqa user egallashi mumkin
+	_, err := r.db.ExecContext(ctx, query, userID, email)
+	return mapUserUniqueViolation(err)
+}
+
+func (r *userRepository) CreateEmailVerificationToken(ctx context.Context, userID, email, tokenHash string, expiresAt time.Time) error {
+	query := `
+		INSERT INTO email_verification_tokens (user_id, email, token, expires_at)
+		VALUES ($1, $2, $3, $4)
+	`
+	_, err := r.db.ExecContext(ctx, query, userID, email, tokenHash, expiresAt)
+	return err
+}
+
+// ConsumeEmailVerificationToken — tokenni bir martalik ishlatadi; qaysi user va qaysi email
+// uchun berilganini qaytaradi
+func (r *userRepository) ConsumeEmailVerificationToken(ctx context.Context, tokenHash string) (string, string, error) {
+	query := `
+		UPDATE email_verification_tokens
+		SET used_at = NOW()
+		WHERE token = $1 AND used_at IS NULL AND expires_at > NOW()
+		RETURNING user_id, email
+	`
+	var userID, email string
+	if err := r.db.QueryRowContext(ctx, query, tokenHash).Scan(&userID, &email); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return "", "", domain.ErrInvalidVerificationToken
+		}
+		return "", "", err
+	}
+	return userID, email, nil
+}
+
+// ================== TWO-FACTOR AUTH ==================
+// SetTOTPSecret — yangi (hali tasdiqlanmagan) secretni saqlaydi
+func (r *userRepository) SetTOTPSecret(ctx context.Context, userID, secret string) error {
+	_, err := r.db.ExecContext(ctx,
+		`UPDATE users SET totp_secret = $1, totp_enabled_at = NULL, updated_at = NOW() WHERE id = $2`, secret, userID)
+	return err
+}
+
+// EnableTOTP — 2FA ni yoqadi va tiklash kodlarini yangilaydi (bitta tranzaksiyada)
+func (r *userRepository) EnableTOTP(ctx context.Context, userID string, recoveryCodeHashes []string) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.ExecContext(ctx,
+		`UPDATE users SET totp_enabled_at = NOW(), updated_at = NOW() WHERE id = $1`, userID); err != nil {
+		return err
+	}
+	if _, err := tx.ExecContext(ctx, `DELETE FROM recovery_codes WHERE user_id = $1`, userID); err != nil {
+		return err
+	}
+	for _, hash := range recoveryCodeHashes {
+		if _, err := tx.ExecContext(ctx,
+			`INSERT INTO recovery_codes (user_id, code_hash) VALUES ($1, $2)`, userID, hash); err != nil {
+			return err
+		}
+	}
+	return tx.Commit()
+}
+
+func (r *userRepository) DisableTOTP(ctx context.Context, userID string) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.ExecContext(ctx,
+		`UPDATE users SET totp_secret = NULL, totp_enabled_at = NULL, updated_at = NOW() WHERE id = $1`, userID); err != nil {
+		return err
+	}
+	if _, err := tx.ExecContext(ctx, `DELETE FROM recovery_codes WHERE user_id = $1`, userID); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+// ConsumeRecoveryCode — kod mavjud va ishlatilmagan bo'lsa uni ishlatilgan deb belgilaydi
+func (r *userRepository) ConsumeRecoveryCode(ctx context.Context, userID, codeHash string) (bool, error) {
+	res, err := r.db.ExecContext(ctx, `
+		UPDATE recovery_codes
+		SET used_at = NOW()
+		WHERE user_id = $1 AND code_hash = $2 AND used_at IS NULL
+	`, userID, codeHash)
+	if err != nil {
+		return false, err
+	}
+	n, err := res.RowsAffected()
+	return n > 0, err
+}
+
+// ================== PRIVACY ==================
+func (r *userRepository) UpdatePrivacySettings(ctx context.Context, userID string, settings domain.PrivacySettings) error {
+	_, err := r.db.ExecContext(ctx, `
+		UPDATE users
+		SET discoverable = $1, show_full_name = $2, show_avatar = $3, hide_last_seen = $4, updated_at = NOW()
+		WHERE id = $5
+	`, settings.Discoverable, settings.ShowFullName, settings.ShowAvatar, settings.HideLastSeen, userID)
+	return err
+}
+
+// ================== SEARCH ==================
+// SearchUsers — username va full_name bo'yicha prefiks + trigram (pg_trgm) qidiruv.
+// Rank: aniq username > username prefiksi > ism yoki ismdagi so'z prefiksi, keyin o'xshashlik darajasi.
+// Faqat discoverable va faol akkauntlar; keyset pagination (rank DESC, id DESC).
+func (r *userRepository) SearchUsers(ctx context.Context, query, excludeUserID string, limit int, after *domain.SearchCursor) ([]domain.UserSearchHit, error) {
+	q := strings.ToLower(query)
+	prefix := escapeLike(q) + "%"
+	args := []any{q, prefix, "% " + prefix, excludeUserID}
+
+	sqlQuery := `
+		WITH matches AS (
+			SELECT id, COALESCE(username::text, '') AS username, full_name, avatar_url,
+			       show_full_name, show_avatar,
+			       (CASE
+			            WHEN lower(username::text) = $1 THEN 3
+			            WHEN lower(username::text) LIKE $2 THEN 2
+			            WHEN lower(full_name) LIKE $2 OR lower(full_name) LIKE $3 THEN 1
+			            ELSE 0
+			        END + GREATEST(
+			            similarity(lower(username::text), $1),
+			            word_similarity($1, COALESCE(lower(full_name), ''))
+			        ))::real AS rank
+			FROM users
+			WHERE discoverable
+			  AND (status = 'active' OR (status = 'suspended' AND suspended_until <= NOW()))
+			  AND id::text <> $4
+			  AND (lower(username::text) LIKE $2
+			       OR lower(full_name) LIKE $2
+			       OR lower(full_name) LIKE $3
+			       OR lower(username::text) % $1
+			       OR $1 <% lower(full_name))
+		)
+		SELECT id, username, full_name, avatar_url, show_full_name, show_avatar, rank FROM matches`
+	if after != nil {
+		sqlQuery += ` WHERE (rank, id) < ($5::real, $6::uuid)`
+		args = append(args, float64(after.Rank), after.ID)
+	}
+	sqlQuery += fmt.Sprintf(` ORDER BY rank DESC, id DESC LIMIT $%d`, len(args)+1)
+	args = append(args, limit)
+
+	rows, err := r.db.QueryContext(ctx, sqlQuery, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var hits []domain.UserSearchHit
+	for rows.Next() {
+		var (
+			id, username        string
+			fullName, avatarURL *string
+			privacy             domain.PrivacySettings
+			rank                float32
+		)
+		if err := rows.Scan(&id, &username, &fullName, &avatarURL, &privacy.ShowFullName, &privacy.ShowAvatar, &rank); err != nil {
+			return nil, err
+		}
+		hits = append(hits, domain.UserSearchHit{
+			Profile: domain.NewPublicProfile(id, username, fullName, avatarURL, privacy),
+			Rank:    rank,
+		})
+	}
+	return hits, rows.Err()
+}
+
+func (r *userRepository) GetPublicProfiles(ctx context.Context, ids []string) ([]domain.PublicProfile, error) {
+	rows, err := r.db.QueryContext(ctx, `
+		SELECT id, COALESCE(username::text, ''), full_name, avatar_url, show_full_name, show_avatar
+		FROM users
+		WHERE id = ANY($1::uuid[])
+		  AND (status = 'active' OR (status = 'suspended' AND suspended_until <= NOW()))
+	`, pq.Array(ids))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var profiles []domain.PublicProfile
+	for rows.Next() {
+		var (
+			id, username        string
+			fullName, avatarURL *string
+			privacy             domain.PrivacySettings
+		)
+		if err := rows.Scan(&id, &username, &fullName, &avatarURL, &privacy.ShowFullName, &privacy.ShowAvatar); err != nil {
+			return nil, err
+		}
+		profiles = append(profiles, domain.NewPublicProfile(id, username, fullName, avatarURL, privacy))
+	}
+	return profiles, rows.Err()
+}
+
+// ================== PRESENCE ==================
+// SetLastSeen — qurilma offline bo'lganda; kechikib kelgan eski vaqt yangisini bosib ketmaydi
+func (r *userRepository) SetLastSeen(ctx context.Context, userID, sessionID string, at time.Time) error {
+	_, err := r.db.ExecContext(ctx, `
+		WITH session AS (
+			UPDATE sessions SET last_seen = GREATEST(last_seen, $3)
+			WHERE user_id = $1 AND id::text = $2
+		)
+		UPDATE users SET last_seen_at = GREATEST(last_seen_at, $3) WHERE id = $1
+	`, userID, sessionID, at)
+	return err
+}
+
+func (r *userRepository) GetPresenceRecords(ctx context.Context, ids []string) ([]domain.PresenceRecord, error) {
+	rows, err := r.db.QueryContext(ctx, `
+		SELECT id, last_seen_at, hide_last_seen
+		FROM users
+		WHERE id = ANY($1::uuid[])
+	`, pq.Array(ids))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var records []domain.PresenceRecord
+	for rows.Next() {
+		var rec domain.PresenceRecord
+		if err := rows.Scan(&rec.UserID, &rec.LastSeenAt, &rec.HideLastSeen); err != nil {
+			return nil, err
+		}
+		records = append(records, rec)
+	}
+	return records, rows.Err()
+}
+
+// ================== ADMIN ==================
+// ListUsers — keyset pagination: after dan keyingi (registered_at DESC, id DESC) qatorlar
+func (r *userRepository) ListUsers(ctx context.Context, filter domain.UserFilter, limit int, after *domain.PageCursor) ([]domain.User, error) {
+	var (
+		conds []string
+		args  []any
+	)
+	arg := func(v any) string {
+		args = append(args, v)
+		return fmt.Sprintf("$%d", len(args))
+	}
+
+	if filter.Query != "" {
+		p := arg("%" + escapeLike(filter.Query) + "%")
+		conds = append(conds, fmt.Sprintf("(username ILIKE %s OR email ILIKE %s)", p, p))
+	}
+	if filter.Role != "" {
+		conds = append(conds, "role = "+arg(string(filter.Role)))
+	}
+	if filter.Status != "" {
+		conds = append(conds, "status = "+arg(string(filter.Status)))
+	}
+	if after != nil {
+		conds = append(conds, fmt.Sprintf("(registered_at, id) < (%s, %s)", arg(after.At), arg(after.ID)))
+	}
+
+	query := `SELECT ` + userColumns + ` FROM users`
+	if len(conds) > 0 {
+		query += ` WHERE ` + strings.Join(conds, " AND ")
+	}
+	query += ` ORDER BY registered_at DESC, id DESC LIMIT ` + arg(limit)
+
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var users []domain.User
+	for rows.Next() {
+		u, err := scanUser(rows)
+		if err != nil {
+			return nil, err
+		}
+		users = append(users, *u)
+	}
+	return users, rows.Err()
+}
+
+// escapeLike — foydalanuvchi kiritgan % va _ belgilarini oddiy belgi sifatida qidirish
+func escapeLike(s string) string {
+	return strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`).Replace(s)
+}
+
+// SetRole — rol va audit yozuvi bitta tranzaksiyada
+func (r *userRepository) SetRole(ctx context.Context, userID string, role domain.Role, audit *domain.AuditEntry) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.ExecContext(ctx,
+		`UPDATE users SET role = $1, updated_at = NOW() WHERE id = $2`, string(role), userID); err != nil {
+		return err
+	}
+	if err := insertAuditEntry(ctx, tx, audit); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+// SetStatus — status va audit yozuvi bitta tranzaksiyada
+func (r *userRepository) SetStatus(ctx context.Context, userID string, status domain.UserStatus, reason *string, until *time.Time, audit *domain.AuditEntry) error {
+	if status == domain.UserStatusActive {
+		reason, until = nil, nil
+	}
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.ExecContext(ctx, `
+		UPDATE users
+		SET status = $1, status_reason = $2, suspended_until = $3, updated_at = NOW()
+		WHERE id = $4
+	`, string(status), reason, until, userID); err != nil {
+		return err
+	}
+	if err := insertAuditEntry(ctx, tx, audit); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+func (r *userRepository) CreateAuditEntry(ctx context.Context, entry *domain.AuditEntry) error {
+	return insertAuditEntry(ctx, r.db, entry)
+}
+
+func insertAuditEntry(ctx context.Context, db interface {
+	ExecContext(ctx context.Context, query string, args ...any) (sql.Result, error)
+}, entry *domain.AuditEntry) error {
+	if entry.ID == "" {
+		entry.ID = uuid.New().String()
+	}
+	details, err := json.Marshal(entry.Details)
+	if err != nil {
+		return err
+	}
+	_, err = db.ExecContext(ctx, `
+		INSERT INTO admin_audit_log (id, actor_id, action, target_user_id, details)
+		VALUES ($1, $2, $3, NULLIF($4, '')::uuid, $5)
+	`, entry.ID, entry.ActorID, entry.Action, entry.TargetUserID, details)
+	return err
+}
+
+// ================== LOGIN HISTORY ==================
+func (r *userRepository) CreateLoginEvent(ctx context.Context, e *domain.LoginEvent) error {
+	if e.ID == "" {
+		e.ID = uuid.New().String()
+	}
+	_, err := r.db.ExecContext(ctx, `
+		INSERT INTO login_events (
+			id, user_id, email, method, outcome, failure_reason,
+			ip_address, user_agent, location, country_code, platform, device_id
+		) VALUES (
+			$1, NULLIF($2, '')::uuid, NULLIF($3, ''), $4, $5, NULLIF($6, ''),
+			NULLIF($7, ''), NULLIF($8, ''), NULLIF($9, ''), NULLIF($10, ''), NULLIF($11, ''), NULLIF($12, '')
+		)
+	`,
+		e.ID, e.UserID, e.Email, e.Method, string(e.Outcome), e.FailureReason,
+		e.IPAddress, e.UserAgent, e.Location, e.CountryCode, e.Platform, e.DeviceID,
+	)
+	return err
+}
+
+// ListLoginEvents — keyset pagination (created_at DESC, id DESC)
+func (r *userRepository) ListLoginEvents(ctx context.Context, userID string, limit int, after *domain.PageCursor) ([]domain.LoginEvent, error) {
+	query := `
+		SELECT id, COALESCE(email, ''), method, outcome, COALESCE(failure_reason, ''),
+		       COALESCE(ip_address, ''), COALESCE(user_agent, ''), COALESCE(location, ''),
+		       COALESCE(country_code, ''), COALESCE(platform, ''), COALESCE(device_id, ''), created_at
+		FROM login_events
+		WHERE user_id = $1`
+	args := []any{userID}
+	if after != nil {
+		query += ` AND (created_at, id) < ($2, $3)`
+		args = append(args, after.At, after.ID)
+	}
+	query += fmt.Sprintf(` ORDER BY created_at DESC, id DESC LIMIT $%d`, len(args)+1)
+	args = append(args, limit)
+
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var events []domain.LoginEvent
+	for rows.Next() {
+		e := domain.LoginEvent{UserID: userID}
+		var outcome string
+		if err := rows.Scan(
+			&e.ID, &e.Email, &e.Method, &outcome, &e.FailureReason,
+			&e.IPAddress, &e.UserAgent, &e.Location,
+			&e.CountryCode, &e.Platform, &e.DeviceID, &e.CreatedAt,
+		); err != nil {
+			return nil, err
+		}
+		e.Outcome = domain.LoginOutcome(outcome)
+		events = append(events, e)
+	}
+	return events, rows.Err()
+}
+
+func (r *userRepository) KnownLoginContext(ctx context.Context, userID, deviceID, countryCode string) (*domain.KnownLoginContext, error) {
+	var known domain.KnownLoginContext
+	err := r.db.QueryRowContext(ctx, `
+		SELECT COUNT(*) > 0,
+		       COALESCE(BOOL_OR($2 <> '' AND device_id = $2), false),
+		       COALESCE(BOOL_OR($3 <> '' AND country_code = $3), false)
+		FROM login_events
+		WHERE user_id = $1 AND outcome = 'success'
+	`, userID, deviceID, countryCode).Scan(&known.HasHistory, &known.DeviceSeen, &known.CountrySeen)
+	if err != nil {
+		return nil, err
+	}
+	return &known, nil
+}
+
 // ================== DELETE ACCOUNT ==================
-func (r *userRepository) Delete(ctx context.Context, id string) error {
-	_, err := r.db.ExecContext(ctx, `DELETE FROM users WHERE id = $1`, id)
+func (r *userRepository) ScheduleDeletion(ctx context.Context, id string, purgeAt time.Time) error {
+	_, err := r.db.ExecContext(ctx, `
+		UPDATE users
+		SET status = 'pending_deletion', status_reason = NULL, suspended_until = $1, updated_at = NOW()
+		WHERE id = $2
+	`, purgeAt, id)
 	return err
 }
 
+// PurgeDeletedAccounts — sessiyalar, identity va tokenlar CASCADE bilan o'chadi
+func (r *userRepository) PurgeDeletedAccounts(ctx context.Context, limit int) ([]string, error) {
+	rows, err := r.db.QueryContext(ctx, `
+		DELETE FROM users
+		WHERE id IN (
+			SELECT id FROM users
+			WHERE status = 'pending_deletion' AND suspended_until <= NOW()
+			ORDER BY suspended_until
+			LIMIT $1
+			FOR UPDATE SKIP LOCKED
+		)
+		RETURNING id
+	`, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var ids []string
+	for rows.Next() {
+		var id string
+		if err := rows.Scan(&id); err != nil {
+			return nil, err
+		}
+		ids = append(ids, id)
+	}
+	return ids, rows.Err()
+}
+
 // ================== SESSIONS ==================
+const sessionColumns = `id, user_id, device_id, COALESCE(platform, ''), COALESCE(ip_address, ''),
+		       COALESCE(user_agent, ''), COALESCE(refresh_jti, ''), created_at, last_seen, auth_time`
+
+func scanSession(row interface{ Scan(dest ...any) error }) (*domain.Session, error) {
+	var s domain.Session
+	err := row.Scan(
+		&s.ID,
+		&s.UserID,
+		&s.DeviceID,
+		&s.Platform,
+		&s.IPAddress,
+		&s.UserAgent,
+		&s.RefreshJTI,
+		&s.CreatedAt,
+		&s.LastSeen,
+		&s.AuthTime,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return &s, nil
+}
+
+// UpsertSession — user+device uchun sessiya yaratadi, mavjud bo'lsa uni yangi sessiya bilan almashtiradi
+func (r *userRepository) UpsertSession(ctx context.Context, s *domain.Session) error {
+	query := `
+		INSERT INTO sessions (
+			id, user_id, device_id, platform, ip_address, user_agent, refresh_jti,
+			created_at, last_seen, auth_time
+		) VALUES (
+			$1, $2, $3, $4, $5, $6, $7,
+			NOW(), NOW(), NOW()
+		)
+		ON CONFLICT (user_id, device_id) DO UPDATE
+		SET id = EXCLUDED.id,
+		    platform = EXCLUDED.platform,
+		    ip_address = EXCLUDED.ip_address,
+		    user_agent = EXCLUDED.user_agent,
+		    refresh_jti = EXCLUDED.refresh_jti,
+		    created_at = NOW(),
+		    last_seen = NOW(),
+		    auth_time = NOW()
+	`
+	if s.ID == "" {
+		s.ID = uuid.New().String()
+	}
+
+	_, err := r.db.ExecContext(ctx, query,
+		s.ID,
+		s.UserID,
+		s.DeviceID,
+		s.Platform,
+		s.IPAddress,
+		s.UserAgent,
+		s.RefreshJTI,
+	)
+	return err
+}
+
+// UpdateSessionRefresh — refresh token yangilanganda jti va last_seen ni yangilaydi
+func (r *userRepository) UpdateSessionRefresh(ctx context.Context, sessionID, refreshJTI, ipAddress, userAgent string) error {
+	query := `
+		UPDATE sessions
+		SET refresh_jti = $2,
+		    ip_address = $3,
+		    user_agent = $4,
+		    last_seen = NOW()
+		WHERE id = $1
+	`
+	res, err := r.db.ExecContext(ctx, query, sessionID, refreshJTI, ipAddress, userAgent)
+	if err != nil {
+		return err
+	}
+	if n, _ := res.RowsAffected(); n == 0 {
+		return domain.ErrSessionNotFound
+	}
+	return nil
+}
+
+// SetSessionAuthTime — Reauthenticate muvaffaqiyatli bo'lganda
+func (r *userRepository) SetSessionAuthTime(ctx context.Context, sessionID string, authTime time.Time) error {
+	res, err := r.db.ExecContext(ctx, `UPDATE sessions SET auth_time = $2 WHERE id = $1`, sessionID, authTime)
+	if err != nil {
+		return err
+	}
+	if n, _ := res.RowsAffected(); n == 0 {
+		return domain.ErrSessionNotFound
+	}
+	return nil
+}
+
+func (r *userRepository) GetSessionByID(ctx context.Context, sessionID string) (*domain.Session, error) {
+	query := `SELECT ` + sessionColumns + ` FROM sessions WHERE id = $1`
+	s, err := scanSession(r.db.QueryRowContext(ctx, query, sessionID))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return s, nil
+}
+
+func (r *userRepository) GetSessionByDevice(ctx context.Context, userID, deviceID string) (*domain.Session, error) {
+	query := `SELECT ` + sessionColumns + ` FROM sessions WHERE user_id = $1 AND device_id = $2`
+	s, err := scanSession(r.db.QueryRowContext(ctx, query, userID, deviceID))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return s, nil
+}
+
 func (r *userRepository) GetSessions(ctx context.Context, userID string) ([]domain.Session, error) {
 	query := `
-		SELECT device_id, platform, ip_address, last_seen
+		SELECT ` + sessionColumns + `
 		FROM sessions
 		WHERE user_id = $1
+		ORDER BY last_seen DESC
 	`
 	rows, err := r.db.QueryContext(ctx, query, userID)
 	if err != nil {
@@ -192,14 +886,13 @@ func (r *userRepository) GetSessions(ctx context.Context, userID string) ([]doma
 
 	var sessions []domain.Session
 	for rows.Next() {
-		var s domain.Session
-		err := rows.Scan(&s.DeviceID, &s.Platform, &s.IPAddress, &s.LastSeen)
+		s, err := scanSession(rows)
 		if err != nil {
 			return nil, err
 		}
-		sessions = append(sessions, s)
+		sessions = append(sessions, *s)
 	}
-	return sessions, nil
+	return sessions, rows.Err()
 }
 
 func (r *userRepository) DeleteSession(ctx context.Context, userID, deviceID string) error {
@@ -207,7 +900,151 @@ func (r *userRepository) DeleteSession(ctx context.Context, userID, deviceID str
 	return err
 }
 
+func (r *userRepository) DeleteSessionByID(ctx context.Context, sessionID string) error {
+	_, err := r.db.ExecContext(ctx, `DELETE FROM sessions WHERE id = $1`, sessionID)
+	return err
+}
+
+func (r *userRepository) DeleteOtherSessions(ctx context.Context, userID, keepSessionID string) error {
+	_, err := r.db.ExecContext(ctx, `DELETE FROM sessions WHERE user_id = $1 AND id <> $2`, userID, keepSessionID)
+	return err
+}
+
 func (r *userRepository) DeleteAllSessions(ctx context.Context, userID string) error {
 	_, err := r.db.ExecContext(ctx, `DELETE FROM sessions WHERE user_id = $1`, userID)
 	return err
 }
+
+// ================== EXTERNAL IDENTITIES ==================
+const identityColumns = `id, user_id, provider, subject, COALESCE(email, ''), created_at`
+
+func scanIdentity(row interface{ Scan(dest ...any) error }) (*domain.UserIdentity, error) {
+	var i domain.UserIdentity
+	if err := row.Scan(&i.ID, &i.UserID, &i.Provider, &i.Subject, &i.Email, &i.CreatedAt); err != nil {
+		return nil, err
+	}
+	return &i, nil
+}
+
+// CreateIdentity — unique buzilishlari domain xatolariga aylantiriladi
+func (r *userRepository) CreateIdentity(ctx context.Context, identity *domain.UserIdentity) error {
+	return insertIdentity(ctx, r.db, identity)
+}
+
+// CreateUserWithIdentity — tashqi provayder orqali yangi user: user va identity bitta tranzaksiyada
+func (r *userRepository) CreateUserWithIdentity(ctx context.Context, user *domain.User, identity *domain.UserIdentity) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if user.ID == "" {
+		user.ID = uuid.New().String()
+	}
+	_, err = tx.ExecContext(ctx, `
+		INSERT INTO users (
+			id, username, email, password, full_name, avatar_url, language,
+			platform, device_id, registered_ip, user_agent, location,
+			email_verified_at, registered_at, updated_at
+		) VALUES (
+			$1, $2, $3, $4, $5, $6, $7,
+			$8, $9, $10, $11, $12,
+			$13, NOW(), NOW()
+		)
+	`,
+		user.ID,
+		user.Username,
+		user.Email,
+		user.PasswordHash,
+		user.FullName,
+		user.AvatarURL,
+		user.Language,
+		user.Platform,
+		user.DeviceID,
+		user.RegisteredIP,
+		user.UserAgent,
+		user.Location,
+		user.EmailVerifiedAt,
+	)
+	if err != nil {
+		return mapUserUniqueViolation(err)
+	}
+
+	identity.UserID = user.ID
+	if err := insertIdentity(ctx, tx, identity); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+const uniqueViolation = "23505"
+
+func insertIdentity(ctx context.Context, db interface {
+	ExecContext(ctx context.Context, query string, args ...any) (sql.Result, error)
+}, identity *domain.UserIdentity) error {
+	if identity.ID == "" {
+		identity.ID = uuid.New().String()
+	}
+	_, err := db.ExecContext(ctx, `
+		INSERT INTO user_identities (id, user_id, provider, subject, email)
+		VALUES ($1, $2, $3, $4, NULLIF($5, ''))
+	`, identity.ID, identity.UserID, identity.Provider, identity.Subject, identity.Email)
+
+	var pqErr *pq.Error
+	if errors.As(err, &pqErr) && pqErr.Code == uniqueViolation {
+		switch pqErr.Constraint {
+		case "user_identities_provider_subject_key":
+			return domain.ErrIdentityLinked
+		case "user_identities_user_id_provider_key":
+			return domain.ErrProviderLinked
+		}
+	}
+	return err
+}
+
+func (r *userRepository) GetIdentity(ctx context.Context, provider, subject string) (*domain.UserIdentity, error) {
+	query := `SELECT ` + identityColumns + ` FROM user_identities WHERE provider = $1 AND subject = $2`
+	i, err := scanIdentity(r.db.QueryRowContext(ctx, query, provider, subject))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return i, nil
+}
+
+func (r *userRepository) ListIdentities(ctx context.Context, userID string) ([]domain.UserIdentity, error) {
+	query := `SELECT ` + identityColumns + ` FROM user_identities WHERE user_id = $1 ORDER BY created_at`
+	rows, err := r.db.QueryContext(ctx, query, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var identities []domain.UserIdentity
+	for rows.Next() {
+		i, err := scanIdentity(rows)
+		if err != nil {
+			return nil, err
+		}
+		identities = append(identities, *i)
+	}
+	return identities, rows.Err()
+}
+
+func (r *userRepository) DeleteIdentity(ctx context.Context, userID, provider string) error {
+	res, err := r.db.ExecContext(ctx, `DELETE FROM user_identities WHERE user_id = $1 AND provider = $2`, userID, provider)
+	if err != nil {
+		return err
+	}
+	n, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if n == 0 {
+		return domain.ErrIdentityNotFound
+	}
+	return nil
+}
@@ -0,0 +1,76 @@
+package service
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"user-service/internal/domain"
+)
+
+// ================= DELETE ACCOUNT =================
+// Account darhol bloklanadi (login, refresh va RPC lar ACCOUNT_PENDING_DELETION bilan rad etiladi)
+// va DeletionGrace dan keyin RunAccountPurge tomonidan o'chiriladi. Shu oraliqda admin
+// UnsuspendUser bilan tiklay oladi.
+func (s *userService) DeleteAccount(ctx context.Context, userID, sessionID string) error {
+	if err := s.requireRecentAuth(ctx, userID, sessionID); err != nil {
+		return err
+	}
+	purgeAt := time.Now().Add(s.cfg.DeletionGrace)
+	if err := s.repo.ScheduleDeletion(ctx, userID, purgeAt); err != nil {
+		return err
+	}
+	restriction := &domain.AccountRestrictedError{Status: domain.UserStatusPendingDeletion, Until: &purgeAt}
+	if err := s.statuses.SetRestriction(ctx, userID, restriction); err != nil {
+		log.Println("failed to cache account restriction:", err)
+	}
+	if err := s.revokeAllSessions(ctx, userID); err != nil {
+		return err
+	}
+	s.invalidateProfile(ctx, userID)
+
+	s.publishEvent(ctx, map[string]string{
+		"event":    "UserDeletionScheduled",
+		"user_id":  userID,
+		"purge_at": purgeAt.UTC().Format(time.RFC3339),
+	})
+	return nil
+}
+
+// ================= ACCOUNT PURGE =================
+func (s *userService) RunAccountPurge(ctx context.Context) error {
+	ticker := time.NewTicker(s.cfg.PurgeInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+			s.purgeDeletedAccounts(ctx)
+		}
+	}
+}
+
+// purgeDeletedAccounts — muddati o'tgan accountlarni partiyalab o'chiradi; xato keyingi tsiklgacha loglanadi
+func (s *userService) purgeDeletedAccounts(ctx context.Context) {
+	for {
+		ids, err := s.repo.PurgeDeletedAccounts(ctx, accountPurgeBatch)
+		if err != nil {
+			log.Printf("⚠️ Account purge failed: %v", err)
+			return
+		}
+		for _, id := range ids {
+			if err := s.statuses.ClearRestriction(ctx, id); err != nil {
+				log.Println("failed to clear account restriction cache:", err)
+			}
+			s.invalidateProfile(ctx, id)
+			s.publishEvent(ctx, map[string]string{
+				"event":   "UserDeleted",
+				"user_id": id,
+			})
+		}
+		if len(ids) < accountPurgeBatch {
+			return
+		}
+	}
+}
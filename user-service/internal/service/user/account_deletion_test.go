@@ -0,0 +1,86 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"user-service/internal/domain"
+)
+
+const deletingID = "88888888-8888-8888-8888-888888888888"
+
+func TestDeleteAccountSchedulesDeletion(t *testing.T) {
+	user := &domain.User{ID: deletingID, Email: strPtr("bye@example.com"), Status: domain.UserStatusActive}
+	repo := newFakeRepo(user)
+	repo.sessions["s1"] = &domain.Session{ID: "s1", UserID: deletingID, DeviceID: "d1"}
+	s, events := newTestService(repo)
+	s.cfg.DeletionGrace = 72 * time.Hour
+	ctx := context.Background()
+
+	before := time.Now()
+	if err := s.DeleteAccount(ctx, deletingID, "s1"); err != nil {
+		t.Fatalf("DeleteAccount: %v", err)
+	}
+
+	if user.Status != domain.UserStatusPendingDeletion {
+		t.Fatalf("status = %q, want %q", user.Status, domain.UserStatusPendingDeletion)
+	}
+	if user.SuspendedUntil == nil || user.SuspendedUntil.Before(before.Add(s.cfg.DeletionGrace)) {
+		t.Errorf("purge time = %v, want %s after the request", user.SuspendedUntil, s.cfg.DeletionGrace)
+	}
+	if len(repo.sessions) != 0 {
+		t.Errorf("%d sessions left after DeleteAccount", len(repo.sessions))
+	}
+	if r, _ := s.statuses.Restriction(ctx, deletingID); r == nil || r.Status != domain.UserStatusPendingDeletion {
+		t.Errorf("cached restriction = %+v, want pending_deletion", r)
+	}
+	if len(events.named("UserDeletionScheduled")) != 1 {
+		t.Error("UserDeletionScheduled not published")
+	}
+
+	// Grace davomida account bloklangan, lekin hali o'chirilmagan
+	if r := user.Restriction(time.Now()); r == nil || r.Status != domain.UserStatusPendingDeletion {
+		t.Errorf("restriction = %+v, want pending_deletion", r)
+	}
+	s.purgeDeletedAccounts(ctx)
+	if _, ok := repo.users[deletingID]; !ok {
+		t.Error("account purged before the grace period ended")
+	}
+}
+
+func TestPurgeDeletedAccounts(t *testing.T) {
+	past := time.Now().Add(-time.Minute)
+	future := time.Now().Add(time.Hour)
+	repo := newFakeRepo(
+		&domain.User{ID: deletingID, Status: domain.UserStatusPendingDeletion, SuspendedUntil: &past},
+		&domain.User{ID: memberID, Status: domain.UserStatusPendingDeletion, SuspendedUntil: &future},
+		&domain.User{ID: adminID, Status: domain.UserStatusSuspended, SuspendedUntil: &past},
+	)
+	s, events := newTestService(repo)
+	ctx := context.Background()
+	_ = s.statuses.SetRestriction(ctx, deletingID, &domain.AccountRestrictedError{Status: domain.UserStatusPendingDeletion, Until: &past})
+	_ = s.profiles.SetProfiles(ctx, []domain.PublicProfile{{ID: deletingID}})
+
+	s.purgeDeletedAccounts(ctx)
+
+	if _, ok := repo.users[deletingID]; ok {
+		t.Error("expired pending_deletion account not purged")
+	}
+	if _, ok := repo.users[memberID]; !ok {
+		t.Error("account purged before its purge time")
+	}
+	if _, ok := repo.users[adminID]; !ok {
+		t.Error("suspended account purged")
+	}
+	if r, _ := s.statuses.Restriction(ctx, deletingID); r != nil {
+		t.Error("restriction cache not cleared for purged account")
+	}
+	if cached, _ := s.profiles.GetProfiles(ctx, []string{deletingID}); len(cached) != 0 {
+		t.Error("profile cache not cleared for purged account")
+	}
+	deleted := events.named("UserDeleted")
+	if len(deleted) != 1 || deleted[0]["user_id"] != deletingID {
+		t.Errorf("UserDeleted events = %v, want one for %s", deleted, deletingID)
+	}
+}
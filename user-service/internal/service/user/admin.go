@@ -0,0 +1,418 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"user-service/internal/domain"
+	"user-service/internal/event/kafka"
+)
+
+const (
+	defaultAdminPageSize = 50
+	maxAdminPageSize     = 200
+)
+
+// AdminConfig — admin amallari sozlamalari
+type AdminConfig struct {
+	ImpersonationTTL time.Duration
+}
+
+// adminService — faqat o'zi ishlatadigan bog'liqliklar; sessiya va event yordamchilari
+// userService bilan umumiy funksiyalar orqali
+type adminService struct {
+	repo          domain.UserRepository
+	tokenProvider domain.TokenProvider
+	k             eventProducer
+	statuses      domain.AccountStatusCache
+	profiles      domain.ProfileCache
+	cfg           AdminConfig
+}
+
+func NewAdminService(
+	repo domain.UserRepository,
+	tokenProvider domain.TokenProvider,
+	kafka *kafka.KafkaProducer,
+	statuses domain.AccountStatusCache,
+	profiles domain.ProfileCache,
+	cfg AdminConfig,
+) domain.AdminService {
+	return &adminService{
+		repo:          repo,
+		tokenProvider: tokenProvider,
+		k:             kafka,
+		statuses:      statuses,
+		profiles:      profiles,
+		cfg:           cfg,
+	}
+}
+
+func (s *adminService) publishEvent(ctx context.Context, event map[string]string) {
+	publishKafkaEvent(ctx, s.k, event)
+}
+
+func (s *adminService) revokeAllSessions(ctx context.Context, userID string) error {
+	return revokeUserSessions(ctx, s.repo, s.tokenProvider, userID)
+}
+
+// ================= LIST USERS =================
+func (s *adminService) ListUsers(ctx context.Context, actorID string, filter domain.UserFilter, pageSize int, pageToken string) (*domain.UserPage, error) {
+	actor, err := s.authorize(ctx, actorID, domain.PermUsersRead)
+	if err != nil {
+		return nil, err
+	}
+	if filter.Role != "" && !filter.Role.Valid() {
+		return nil, domain.ErrInvalidRole
+	}
+	if filter.Status != "" && !filter.Status.Valid() {
+		return nil, domain.ErrInvalidStatus
+	}
+
+	if pageSize <= 0 {
+		pageSize = defaultAdminPageSize
+	}
+	pageSize = min(pageSize, maxAdminPageSize)
+
+	after, err := decodePageToken(pageToken)
+	if err != nil {
+		return nil, err
+	}
+
+	// Shaxsiy ma'lumotlarni ko'rish ham audit qilinadi: yozuv saqlanmasa ro'yxat berilmaydi
+	entry := newAuditEntry(actor.ID, "ListUsers", "", map[string]string{
+		"query":      filter.Query,
+		"role":       string(filter.Role),
+		"status":     string(filter.Status),
+		"page_token": pageToken,
+	})
+	if err := s.audit(ctx, entry); err != nil {
+		return nil, err
+	}
+
+	// Bitta ortiqcha qator — keyingi sahifa bor-yo'qligini bilish uchun
+	users, err := s.repo.ListUsers(ctx, filter, pageSize+1, after)
+	if err != nil {
+		return nil, err
+	}
+
+	page := &domain.UserPage{Users: users}
+	if len(users) > pageSize {
+		page.Users = users[:pageSize]
+		last := page.Users[pageSize-1]
+		page.NextPageToken = encodePageToken(domain.PageCursor{At: last.RegisteredAt, ID: last.ID})
+	}
+	s.publishAudit(ctx, entry)
+	return page, nil
+}
+
+// ================= GET USER =================
+func (s *adminService) GetUser(ctx context.Context, actorID, userID string) (*domain.User, error) {
+	actor, err := s.authorize(ctx, actorID, domain.PermUsersRead)
+	if err != nil {
+		return nil, err
+	}
+	target, err := s.getTarget(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	entry := newAuditEntry(actor.ID, "GetUser", target.ID, nil)
+	if err := s.audit(ctx, entry); err != nil {
+		return nil, err
+	}
+	s.publishAudit(ctx, entry)
+	return target, nil
+}
+
+// ================= SUSPEND / BAN / UNSUSPEND =================
+// Suspension sessiyalarni o'chirmaydi: muddat davomida login, refresh va har bir RPC
+// AccountRestrictedError bilan rad etiladi, tugagach user qayta login qilmasdan davom etadi.
+func (s *adminService) SuspendUser(ctx context.Context, actorID, userID, reason string, until *time.Time) (*domain.User, error) {
+	actor, err := s.authorize(ctx, actorID, domain.PermUsersSuspend)
+	if err != nil {
+		return nil, err
+	}
+	if until != nil && !until.After(time.Now()) {
+		return nil, &domain.ValidationError{Violations: []domain.FieldViolation{
+			{Field: "until", Description: "must be in the future"},
+		}}
+	}
+	target, err := s.getManagedTarget(ctx, actor, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	details := map[string]string{"reason": reason}
+	if until != nil {
+		details["until"] = until.UTC().Format(time.RFC3339)
+	}
+	entry := newAuditEntry(actor.ID, "SuspendUser", target.ID, details)
+	if err := s.changeStatus(ctx, actor, target, domain.UserStatusSuspended, reason, until, entry); err != nil {
+		return nil, err
+	}
+
+	s.publishAudit(ctx, entry)
+	return target, nil
+}
+
+// BanUser — muddatsiz blok; barcha sessiyalar darhol yopiladi
+func (s *adminService) BanUser(ctx context.Context, actorID, userID, reason string) (*domain.User, error) {
+	actor, err := s.authorize(ctx, actorID, domain.PermUsersBan)
+	if err != nil {
+		return nil, err
+	}
+	target, err := s.getManagedTarget(ctx, actor, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	entry := newAuditEntry(actor.ID, "BanUser", target.ID, map[string]string{"reason": reason})
+	if err := s.changeStatus(ctx, actor, target, domain.UserStatusBanned, reason, nil, entry); err != nil {
+		return nil, err
+	}
+	if err := s.revokeAllSessions(ctx, target.ID); err != nil {
+		return nil, err
+	}
+
+	s.publishAudit(ctx, entry)
+	return target, nil
+}
+
+func (s *adminService) UnsuspendUser(ctx context.Context, actorID, userID string) (*domain.User, error) {
+	actor, err := s.authorize(ctx, actorID, domain.PermUsersSuspend)
+	if err != nil {
+		return nil, err
+	}
+	target, err := s.getManagedTarget(ctx, actor, userID)
+	if err != nil {
+		return nil, err
+	}
+	// Banni faqat ban qila oladigan rol olib tashlaydi
+	if target.Status == domain.UserStatusBanned && !actor.Role.Can(domain.PermUsersBan) {
+		return nil, domain.ErrPermissionDenied
+	}
+
+	entry := newAuditEntry(actor.ID, "UnsuspendUser", target.ID, map[string]string{"previous_status": string(target.Status)})
+	if err := s.changeStatus(ctx, actor, target, domain.UserStatusActive, "", nil, entry); err != nil {
+		return nil, err
+	}
+
+	s.publishAudit(ctx, entry)
+	return target, nil
+}
+
+// changeStatus — DB (audit yozuvi bilan bitta tranzaksiyada), auth interceptor keshi va
+// UserSuspended/UserReinstated eventi. Kesh yangilanmasa ham login va refresh DB dagi status bo'yicha tekshiriladi.
+func (s *adminService) changeStatus(ctx context.Context, actor, target *domain.User, status domain.UserStatus, reason string, until *time.Time, entry *domain.AuditEntry) error {
+	var reasonPtr *string
+	if reason != "" {
+		reasonPtr = &reason
+	}
+	if err := s.repo.SetStatus(ctx, target.ID, status, reasonPtr, until, entry); err != nil {
+		return err
+	}
+	previous := target.Status
+	target.Status, target.StatusReason, target.SuspendedUntil = status, reasonPtr, until
+	// Keshdagi ommaviy profil status o'zgarganini bilmaydi — keyingi o'qish DB dan
+	if err := s.profiles.Invalidate(ctx, target.ID); err != nil {
+		log.Println("failed to invalidate cached profile:", err)
+	}
+
+	restriction := target.Restriction(time.Now())
+	if restriction == nil {
+		if err := s.statuses.ClearRestriction(ctx, target.ID); err != nil {
+			log.Println("failed to clear account restriction cache:", err)
+		}
+		target.StatusReason, target.SuspendedUntil = nil, nil
+		s.publishEvent(ctx, map[string]string{
+			"event":           "UserReinstated",
+			"user_id":         target.ID,
+			"previous_status": string(previous),
+			"actor_id":        actor.ID,
+		})
+		return nil
+	}
+
+	if err := s.statuses.SetRestriction(ctx, target.ID, restriction); err != nil {
+		log.Println("failed to cache account restriction:", err)
+	}
+	event := map[string]string{
+		"event":    "UserSuspended",
+		"user_id":  target.ID,
+		"status":   string(status),
+		"reason":   reason,
+		"actor_id": actor.ID,
+	}
+	if until != nil {
+		event["until"] = until.UTC().Format(time.RFC3339)
+	}
+	s.publishEvent(ctx, event)
+	return nil
+}
+
+// ================= FORCE LOGOUT =================
+func (s *adminService) ForceLogout(ctx context.Context, actorID, userID string) error {
+	actor, err := s.authorize(ctx, actorID, domain.PermSessionsRevoke)
+	if err != nil {
+		return err
+	}
+	target, err := s.getManagedTarget(ctx, actor, userID)
+	if err != nil {
+		return err
+	}
+
+	entry := newAuditEntry(actor.ID, "ForceLogout", target.ID, nil)
+	if err := s.audit(ctx, entry); err != nil {
+		return err
+	}
+	if err := s.revokeAllSessions(ctx, target.ID); err != nil {
+		return err
+	}
+
+	s.publishAudit(ctx, entry)
+	return nil
+}
+
+// ================= SET ROLE =================
+// Yangi rol keyingi tokenlardan kuchga kiradi; joriy access tokenlar darhol bekor qilinadi
+func (s *adminService) SetRole(ctx context.Context, actorID, userID string, role domain.Role) (*domain.User, error) {
+	actor, err := s.authorize(ctx, actorID, domain.PermRolesManage)
+	if err != nil {
+		return nil, err
+	}
+	if !role.Valid() {
+		return nil, domain.ErrInvalidRole
+	}
+	target, err := s.getManagedTarget(ctx, actor, userID)
+	if err != nil {
+		return nil, err
+	}
+	entry := newAuditEntry(actor.ID, "SetRole", target.ID, map[string]string{
+		"previous_role": string(target.Role),
+		"role":          string(role),
+	})
+	if err := s.repo.SetRole(ctx, target.ID, role, entry); err != nil {
+		return nil, err
+	}
+	if err := s.tokenProvider.RevokeUserAccessTokens(target.ID); err != nil {
+		log.Println("failed to revoke access tokens after role change:", err)
+	}
+	target.Role = role
+
+	s.publishAudit(ctx, entry)
+	return target, nil
+}
+
+// ================= IMPERSONATE =================
+// Token faqat Authenticated metodlarda ishlaydi (parol, email, sessiyalar va h.k. yopiq),
+// uning bilan bajarilgan har bir chaqiruv auth interceptor tomonidan logga yoziladi
+func (s *adminService) Impersonate(ctx context.Context, actorID, userID, reason string) (*domain.Impersonation, error) {
+	actor, err := s.authorize(ctx, actorID, domain.PermImpersonate)
+	if err != nil {
+		return nil, err
+	}
+	if strings.TrimSpace(reason) == "" {
+		return nil, &domain.ValidationError{Violations: []domain.FieldViolation{
+			{Field: "reason", Description: "is required"},
+		}}
+	}
+	target, err := s.getManagedTarget(ctx, actor, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	// Token faqat audit yozuvi saqlangandan keyin chiqariladi
+	entry := newAuditEntry(actor.ID, "Impersonate", target.ID, map[string]string{
+		"reason": reason,
+		"ttl":    s.cfg.ImpersonationTTL.String(),
+	})
+	if err := s.audit(ctx, entry); err != nil {
+		return nil, err
+	}
+
+	token, expiresAt, err := s.tokenProvider.GenerateImpersonationToken(target.ID, actor.ID, s.cfg.ImpersonationTTL)
+	if err != nil {
+		return nil, errors.New("failed to generate token")
+	}
+
+	s.publishAudit(ctx, entry)
+	return &domain.Impersonation{AccessToken: token, ExpiresAt: expiresAt, User: target}, nil
+}
+
+// authorize — aktyor rolini tokendan emas, DB dan oladi (rol olib qo'yilgan bo'lsa darhol ta'sir qiladi)
+func (s *adminService) authorize(ctx context.Context, actorID string, perm domain.Permission) (*domain.User, error) {
+	actor, err := s.repo.GetByID(ctx, actorID)
+	if err != nil {
+		return nil, err
+	}
+	if actor == nil || actor.Status != domain.UserStatusActive || !actor.Role.Can(perm) {
+		return nil, domain.ErrPermissionDenied
+	}
+	return actor, nil
+}
+
+func (s *adminService) getTarget(ctx context.Context, userID string) (*domain.User, error) {
+	target, err := s.repo.GetByID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if target == nil {
+		return nil, domain.ErrUserNotFound
+	}
+	return target, nil
+}
+
+// getManagedTarget — o'zi ustida va o'zidan yuqori/teng roldagi userlar ustida amal bajarib bo'lmaydi
+func (s *adminService) getManagedTarget(ctx context.Context, actor *domain.User, userID string) (*domain.User, error) {
+	if userID == actor.ID {
+		return nil, domain.ErrSelfAction
+	}
+	target, err := s.getTarget(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if !actor.Role.Outranks(target.Role) {
+		return nil, domain.ErrPermissionDenied
+	}
+	return target, nil
+}
+
+func newAuditEntry(actorID, action, targetID string, details map[string]string) *domain.AuditEntry {
+	return &domain.AuditEntry{
+		ActorID:      actorID,
+		Action:       action,
+		TargetUserID: targetID,
+		Details:      details,
+		CreatedAt:    time.Now(),
+	}
+}
+
+// audit — holat o'zgarmaydigan amallar (o'qish, impersonatsiya, force logout) uchun amaldan OLDIN
+// admin_audit_log ga yozadi; yozuv saqlanmasa amal bajarilmaydi.
+// SetStatus/SetRole da yozuv o'zgarish bilan bitta tranzaksiyada saqlanadi.
+func (s *adminService) audit(ctx context.Context, entry *domain.AuditEntry) error {
+	if err := s.repo.CreateAuditEntry(ctx, entry); err != nil {
+		log.Printf("failed to write audit log (actor=%s action=%s target=%s): %v", entry.ActorID, entry.Action, entry.TargetUserID, err)
+		return fmt.Errorf("%w: audit log: %v", domain.ErrServiceUnavailable, err)
+	}
+	return nil
+}
+
+// publishAudit — amal bajarilgach AdminAction eventi. Tafsilotlar "detail_" prefiksi bilan,
+// shunda ular event/action/actor_id kabi asosiy maydonlarni ustidan yoza olmaydi.
+func (s *adminService) publishAudit(ctx context.Context, entry *domain.AuditEntry) {
+	event := map[string]string{
+		"event":          "AdminAction",
+		"action":         entry.Action,
+		"actor_id":       entry.ActorID,
+		"target_user_id": entry.TargetUserID,
+	}
+	for k, v := range entry.Details {
+		event["detail_"+k] = v
+	}
+	s.publishEvent(ctx, event)
+}
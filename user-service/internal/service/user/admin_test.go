@@ -0,0 +1,177 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"user-service/internal/domain"
+)
+
+const (
+	adminID  = "66666666-6666-6666-6666-666666666666"
+	memberID = "77777777-7777-7777-7777-777777777777"
+)
+
+// adminRepo — audit yozuvi saqlanmaydigan holatni ham ko'rsata oladi
+type adminRepo struct {
+	*fakeRepo
+	auditErr error
+}
+
+func (r *adminRepo) CreateAuditEntry(ctx context.Context, entry *domain.AuditEntry) error {
+	if r.auditErr != nil {
+		return r.auditErr
+	}
+	return r.fakeRepo.CreateAuditEntry(ctx, entry)
+}
+
+func (r *adminRepo) SetRole(ctx context.Context, userID string, role domain.Role, audit *domain.AuditEntry) error {
+	// Postgres dagi kabi: audit yozilmasa rol ham o'zgarmaydi
+	if r.auditErr != nil {
+		return r.auditErr
+	}
+	return r.fakeRepo.SetRole(ctx, userID, role, audit)
+}
+
+func (r *adminRepo) SetStatus(ctx context.Context, userID string, status domain.UserStatus, reason *string, until *time.Time, audit *domain.AuditEntry) error {
+	if r.auditErr != nil {
+		return r.auditErr
+	}
+	return r.fakeRepo.SetStatus(ctx, userID, status, reason, until, audit)
+}
+
+type impersonationTokens struct {
+	fakeTokens
+	minted *int
+}
+
+func (t impersonationTokens) GenerateImpersonationToken(userID, actorID string, ttl time.Duration) (string, time.Time, error) {
+	*t.minted++
+	return "impersonation", time.Now().Add(ttl), nil
+}
+
+func (impersonationTokens) RevokeUserAccessTokens(userID string) error { return nil }
+
+func newTestAdmin(auditErr error) (*adminService, *adminRepo, *fakeEvents, *int) {
+	repo := &adminRepo{
+		fakeRepo: newFakeRepo(
+			&domain.User{ID: adminID, Role: domain.RoleAdmin, Status: domain.UserStatusActive},
+			&domain.User{ID: memberID, Role: domain.RoleUser, Status: domain.UserStatusActive},
+		),
+		auditErr: auditErr,
+	}
+	events := &fakeEvents{}
+	minted := new(int)
+	return &adminService{
+		repo:          repo,
+		tokenProvider: impersonationTokens{minted: minted},
+		k:             events,
+		statuses:      newFakeStatusCache(),
+		profiles:      newFakeProfileCache(),
+		cfg:           AdminConfig{ImpersonationTTL: 10 * time.Minute},
+	}, repo, events, minted
+}
+
+func TestImpersonateRequiresAuditEntry(t *testing.T) {
+	s, _, events, minted := newTestAdmin(errors.New("db down"))
+
+	_, err := s.Impersonate(context.Background(), adminID, memberID, "ticket 42")
+	if !errors.Is(err, domain.ErrServiceUnavailable) {
+		t.Fatalf("err = %v, want ErrServiceUnavailable", err)
+	}
+	if *minted != 0 {
+		t.Error("impersonation token minted without an audit entry")
+	}
+	if len(events.named("AdminAction")) != 0 {
+		t.Error("AdminAction published without an audit entry")
+	}
+}
+
+func TestSetRoleFailsWithoutAuditEntry(t *testing.T) {
+	s, repo, events, _ := newTestAdmin(errors.New("db down"))
+
+	if _, err := s.SetRole(context.Background(), adminID, memberID, domain.RoleModerator); err == nil {
+		t.Fatal("SetRole succeeded without an audit entry")
+	}
+	if got := repo.users[memberID].Role; got != domain.RoleUser {
+		t.Errorf("role = %q, want unchanged %q", got, domain.RoleUser)
+	}
+	if len(events.named("AdminAction")) != 0 {
+		t.Error("AdminAction published for a failed role change")
+	}
+}
+
+func TestReadActionsAreAudited(t *testing.T) {
+	s, repo, _, _ := newTestAdmin(nil)
+
+	if _, err := s.GetUser(context.Background(), adminID, memberID); err != nil {
+		t.Fatalf("GetUser: %v", err)
+	}
+	if len(repo.audit) != 1 || repo.audit[0].Action != "GetUser" || repo.audit[0].TargetUserID != memberID {
+		t.Errorf("audit = %+v, want one GetUser entry for %s", repo.audit, memberID)
+	}
+
+	s, _, _, _ = newTestAdmin(errors.New("db down"))
+	if _, err := s.GetUser(context.Background(), adminID, memberID); !errors.Is(err, domain.ErrServiceUnavailable) {
+		t.Errorf("GetUser without audit: err = %v, want ErrServiceUnavailable", err)
+	}
+	if _, err := s.ListUsers(context.Background(), adminID, domain.UserFilter{}, 10, ""); !errors.Is(err, domain.ErrServiceUnavailable) {
+		t.Errorf("ListUsers without audit: err = %v, want ErrServiceUnavailable", err)
+	}
+}
+
+func TestAuditDetailsCannotOverrideEventFields(t *testing.T) {
+	s, _, events, _ := newTestAdmin(nil)
+
+	s.publishAudit(context.Background(), newAuditEntry(adminID, "SuspendUser", memberID, map[string]string{
+		"event":    "UserDeleted",
+		"action":   "Nothing",
+		"actor_id": "someone-else",
+		"reason":   "spam",
+	}))
+
+	published := events.named("AdminAction")
+	if len(published) != 1 {
+		t.Fatalf("AdminAction events = %d, want 1", len(published))
+	}
+	e := published[0]
+	if e["action"] != "SuspendUser" || e["actor_id"] != adminID || e["target_user_id"] != memberID {
+		t.Errorf("core fields overwritten: %v", e)
+	}
+	if e["detail_event"] != "UserDeleted" || e["detail_actor_id"] != "someone-else" || e["detail_reason"] != "spam" {
+		t.Errorf("details not namespaced: %v", e)
+	}
+}
+
+func TestBanHidesCachedProfile(t *testing.T) {
+	s, repo, _, _ := newTestAdmin(nil)
+	users, _ := newTestService(repo.fakeRepo)
+	users.profiles = s.profiles
+	ctx := context.Background()
+
+	// Profil avval keshga tushadi
+	batch, err := users.BatchGetUsers(ctx, []string{memberID})
+	if err != nil || len(batch.Profiles) != 1 {
+		t.Fatalf("BatchGetUsers before ban = %+v, %v; want the profile", batch, err)
+	}
+
+	if _, err := s.BanUser(ctx, adminID, memberID, "spam"); err != nil {
+		t.Fatalf("BanUser: %v", err)
+	}
+	if cached, _ := s.profiles.GetProfiles(ctx, []string{memberID}); len(cached) != 0 {
+		t.Error("banned user's profile still cached")
+	}
+
+	batch, err = users.BatchGetUsers(ctx, []string{memberID})
+	if err != nil {
+		t.Fatalf("BatchGetUsers after ban: %v", err)
+	}
+	if len(batch.Profiles) != 0 || len(batch.MissingIDs) != 1 {
+		t.Errorf("BatchGetUsers after ban = %+v, want the id reported missing", batch)
+	}
+	if _, err := users.GetUserProfile(ctx, domain.ProfileLookup{ID: memberID}); !errors.Is(err, domain.ErrUserNotFound) {
+		t.Errorf("GetUserProfile after ban: err = %v, want ErrUserNotFound", err)
+	}
+}
@@ -0,0 +1,56 @@
+package service
+
+import (
+	"time"
+
+	"user-service/internal/domain"
+)
+
+// Config — servis darajasidagi sozlamalar (main.go da config.AppConfig dan to'ldiriladi)
+type Config struct {
+	PasswordResetURL     string
+	EmailVerificationURL string
+	LoginLinkURL         string // magic link: ?email=...&code=...
+
+	// Login brute-force himoyasi: email va IP uchun alohida chegaralar
+	EmailLockout domain.LockoutPolicy
+	IPLockout    domain.LockoutPolicy
+
+	PasswordPolicy PasswordPolicy
+	UsernamePolicy UsernamePolicy
+
+	// ReauthMaxAge — ChangePassword, UpdateEmail, DeleteAccount uchun sessiyadagi
+	// auth_time shu oraliqdan eski bo'lmasligi kerak (0 => tekshiruv o'chiq)
+	ReauthMaxAge time.Duration
+
+	// DeleteAccount dan keyin account DeletionGrace davomida pending_deletion holatida turadi
+	// (admin tiklay oladi), so'ng har PurgeInterval da ishlaydigan tozalovchi uni o'chiradi
+	DeletionGrace time.Duration
+	PurgeInterval time.Duration
+}
+
+const (
+	passwordResetTokenBytes = 32
+	passwordResetTTL        = 30 * time.Minute
+	passwordResetLimit      = 3 // bitta email uchun passwordResetWindow ichida
+	passwordResetWindow     = 15 * time.Minute
+
+	emailVerificationTokenBytes = 32
+	emailVerificationTTL        = 24 * time.Hour
+	emailVerificationLimit      = 3 // bitta user uchun emailVerificationWindow ichida
+	emailVerificationWindow     = 15 * time.Minute
+
+	totpIssuer         = "ChatApp"
+	recoveryCodeCount  = 10
+	mfaAttemptLimit    = 5 // bitta challenge token uchun
+	mfaAttemptWindow   = 5 * time.Minute
+	totpCodeReuseGuard = 90 * time.Second // ± bitta oyna bilan kod amal qiladigan vaqt
+
+	loginCodeDigits      = 6
+	loginCodeTTL         = 10 * time.Minute
+	loginCodeMaxAttempts = 5
+	loginCodeLimit       = 3 // bitta email uchun loginCodeWindow ichida
+	loginCodeWindow      = 15 * time.Minute
+
+	accountPurgeBatch = 500
+)
@@ -0,0 +1,180 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"user-service/internal/domain"
+
+	"github.com/google/uuid"
+)
+
+const (
+	defaultSearchPageSize = 20
+	maxSearchPageSize     = 50
+
+	// Trigram qidiruvi 2 belgidan qisqa so'rovda deyarli barcha qatorlarga mos keladi
+	minSearchQueryLength = 2
+	maxSearchQueryLength = 64
+
+	maxBatchGetUsers = 200
+)
+
+// ================= USER PROFILE =================
+func (s *userService) GetUserProfile(ctx context.Context, lookup domain.ProfileLookup) (*domain.PublicProfile, error) {
+	lookup.Username = strings.TrimSpace(lookup.Username)
+	if (lookup.ID == "") == (lookup.Username == "") {
+		return nil, &domain.ValidationError{Violations: []domain.FieldViolation{{
+			Field:       "lookup",
+			Description: "exactly one of id or username is required",
+		}}}
+	}
+
+	// ID bo'yicha — BatchGetUsers bilan umumiy kesh
+	if lookup.ID != "" {
+		batch, err := s.BatchGetUsers(ctx, []string{lookup.ID})
+		if err != nil {
+			return nil, err
+		}
+		if len(batch.Profiles) == 0 {
+			return nil, domain.ErrUserNotFound
+		}
+		return &batch.Profiles[0], nil
+	}
+
+	user, err := s.repo.GetByUsername(ctx, lookup.Username)
+	if err != nil {
+		return nil, err
+	}
+	// Cheklangan account ID bo'yicha ham, username bo'yicha ham ko'rinmaydi
+	if user == nil || user.Restriction(time.Now()) != nil {
+		return nil, domain.ErrUserNotFound
+	}
+	profile := user.PublicProfile()
+	return &profile, nil
+}
+
+// ================= USER SEARCH =================
+func (s *userService) SearchUsers(ctx context.Context, callerID, query string, pageSize int, pageToken string) (*domain.UserSearchPage, error) {
+	query = strings.Join(strings.Fields(query), " ")
+	if n := utf8.RuneCountInString(query); n < minSearchQueryLength || n > maxSearchQueryLength {
+		return nil, &domain.ValidationError{Violations: []domain.FieldViolation{{
+			Field:       "query",
+			Description: fmt.Sprintf("must be between %d and %d characters long", minSearchQueryLength, maxSearchQueryLength),
+		}}}
+	}
+
+	if pageSize <= 0 {
+		pageSize = defaultSearchPageSize
+	}
+	pageSize = min(pageSize, maxSearchPageSize)
+
+	after, err := decodeSearchToken(pageToken)
+	if err != nil {
+		return nil, err
+	}
+
+	// Bitta ortiqcha qator — keyingi sahifa bor-yo'qligini bilish uchun
+	hits, err := s.repo.SearchUsers(ctx, query, callerID, pageSize+1, after)
+	if err != nil {
+		return nil, err
+	}
+
+	page := &domain.UserSearchPage{}
+	if len(hits) > pageSize {
+		hits = hits[:pageSize]
+		last := hits[pageSize-1]
+		page.NextPageToken = encodeSearchToken(domain.SearchCursor{Rank: last.Rank, ID: last.Profile.ID})
+	}
+	page.Profiles = make([]domain.PublicProfile, len(hits))
+	for i, h := range hits {
+		page.Profiles[i] = h.Profile
+	}
+	return page, nil
+}
+
+// ================= BATCH GET USERS =================
+// Read-through: avval Redis, keshda yo'qlari bitta DB so'rovi bilan o'qiladi va keshga yoziladi.
+// Kesh xatosi so'rovni to'xtatmaydi — hammasi DB dan olinadi.
+func (s *userService) BatchGetUsers(ctx context.Context, ids []string) (*domain.BatchProfiles, error) {
+	if len(ids) > maxBatchGetUsers {
+		return nil, &domain.ValidationError{Violations: []domain.FieldViolation{{
+			Field:       "ids",
+			Description: fmt.Sprintf("must contain at most %d ids", maxBatchGetUsers),
+		}}}
+	}
+
+	result := &domain.BatchProfiles{}
+	var lookup []string
+	seen := make(map[string]bool, len(ids))
+	for _, id := range ids {
+		if seen[id] {
+			continue
+		}
+		seen[id] = true
+		// Noto'g'ri UUID butun so'rovni (::uuid[] cast) buzmasligi uchun oldindan ajratiladi
+		if _, err := uuid.Parse(id); err != nil {
+			result.MissingIDs = append(result.MissingIDs, id)
+			continue
+		}
+		lookup = append(lookup, id)
+	}
+
+	found, err := s.profiles.GetProfiles(ctx, lookup)
+	if err != nil {
+		log.Printf("⚠️ Profile cache read failed: %v", err)
+		found = nil
+	}
+	if found == nil {
+		found = make(map[string]domain.PublicProfile, len(lookup))
+	}
+
+	var misses []string
+	for _, id := range lookup {
+		if _, ok := found[id]; !ok {
+			misses = append(misses, id)
+		}
+	}
+	if len(misses) > 0 {
+		profiles, err := s.repo.GetPublicProfiles(ctx, misses)
+		if err != nil {
+			return nil, err
+		}
+		for _, p := range profiles {
+			found[p.ID] = p
+		}
+		if err := s.profiles.SetProfiles(ctx, profiles); err != nil {
+			log.Printf("⚠️ Profile cache write failed: %v", err)
+		}
+	}
+
+	for _, id := range lookup {
+		if p, ok := found[id]; ok {
+			result.Profiles = append(result.Profiles, p)
+		} else {
+			result.MissingIDs = append(result.MissingIDs, id)
+		}
+	}
+	return result, nil
+}
+
+// updateProfileField — users jadvalidagi profil maydoni har doim shu orqali yoziladi,
+// shunda BatchGetUsers keshi eskirmaydi
+func (s *userService) updateProfileField(ctx context.Context, userID, field string, value *string) error {
+	if err := s.repo.UpdateField(ctx, userID, field, value); err != nil {
+		return err
+	}
+	s.invalidateProfile(ctx, userID)
+	return nil
+}
+
+// invalidateProfile — xato faqat loglanadi: yozuv baribir TTL bilan eskiradi
+func (s *userService) invalidateProfile(ctx context.Context, userID string) {
+	if err := s.profiles.Invalidate(ctx, userID); err != nil {
+		log.Printf("⚠️ Failed to invalidate cached profile for %s: %v", userID, err)
+	}
+}
@@ -0,0 +1,715 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"user-service/internal/domain"
+
+	"github.com/google/uuid"
+)
+
+// errNotFaked — xotiradagi fixture da ma'nosi yo'q so'rovlar (qidiruv, sahifalash);
+// bunday yo'lni sinaydigan test o'z fake ini beradi
+var errNotFaked = errors.New("not supported by the in-memory fake")
+
+// fakeVerification — email tasdiqlash tokeni qaysi user va qaysi manzil uchun berilgani
+type fakeVerification struct {
+	userID, email string
+}
+
+// fakeRepo — xotiradagi UserRepository. Har bir metod aniq yozilgan: qidiruv va sahifalash
+// kabi so'rovlar errNotFaked qaytaradi, panic qilmaydi.
+type fakeRepo struct {
+	mu                 sync.Mutex
+	users              map[string]*domain.User
+	identities         []domain.UserIdentity
+	sessions           map[string]*domain.Session
+	audit              []domain.AuditEntry
+	loginEvents        []domain.LoginEvent
+	resetTokens        map[string]string // token hash -> email
+	verificationTokens map[string]fakeVerification
+	recoveryCodes      map[string]map[string]bool // userID -> kod hashlari
+}
+
+func newFakeRepo(users ...*domain.User) *fakeRepo {
+	r := &fakeRepo{
+		users:              make(map[string]*domain.User),
+		sessions:           make(map[string]*domain.Session),
+		resetTokens:        make(map[string]string),
+		verificationTokens: make(map[string]fakeVerification),
+		recoveryCodes:      make(map[string]map[string]bool),
+	}
+	for _, u := range users {
+		r.users[u.ID] = u
+	}
+	return r
+}
+
+func (r *fakeRepo) GetByID(ctx context.Context, id string) (*domain.User, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if u, ok := r.users[id]; ok {
+		copied := *u
+		return &copied, nil
+	}
+	return nil, nil
+}
+
+func (r *fakeRepo) GetByEmail(ctx context.Context, email string) (*domain.User, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for _, u := range r.users {
+		if u.Email != nil && *u.Email == email {
+			copied := *u
+			return &copied, nil
+		}
+	}
+	return nil, nil
+}
+
+func (r *fakeRepo) GetByUsername(ctx context.Context, username string) (*domain.User, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for _, u := range r.users {
+		if u.Username != nil && *u.Username == username {
+			copied := *u
+			return &copied, nil
+		}
+	}
+	return nil, nil
+}
+
+// TakenUsernames — Postgres dagi citext kabi registrga befarq
+func (r *fakeRepo) TakenUsernames(ctx context.Context, candidates []string) (map[string]bool, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	taken := make(map[string]bool)
+	for _, c := range candidates {
+		for _, u := range r.users {
+			if u.Username != nil && strings.EqualFold(*u.Username, c) {
+				taken[strings.ToLower(c)] = true
+			}
+		}
+	}
+	return taken, nil
+}
+
+// UpdateUsername — boshqa userda registri boshqacha bir xil nom bo'lsa ham UNIQUE buziladi
+func (r *fakeRepo) UpdateUsername(ctx context.Context, userID, username string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for id, u := range r.users {
+		if id != userID && u.Username != nil && strings.EqualFold(*u.Username, username) {
+			return domain.ErrUsernameTaken
+		}
+	}
+	if u, ok := r.users[userID]; ok {
+		now := time.Now()
+		u.Username, u.UsernameChangedAt = &username, &now
+	}
+	return nil
+}
+
+func (r *fakeRepo) GetPublicProfiles(ctx context.Context, ids []string) ([]domain.PublicProfile, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	var profiles []domain.PublicProfile
+	for _, id := range ids {
+		if u, ok := r.users[id]; ok && u.Restriction(time.Now()) == nil {
+			profiles = append(profiles, u.PublicProfile())
+		}
+	}
+	return profiles, nil
+}
+
+func (r *fakeRepo) Create(ctx context.Context, u *domain.User) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	u.ID = uuid.New().String()
+	copied := *u
+	r.users[u.ID] = &copied
+	return nil
+}
+
+func (r *fakeRepo) CreateEmailVerificationToken(ctx context.Context, userID, email, tokenHash string, expiresAt time.Time) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.verificationTokens[tokenHash] = fakeVerification{userID: userID, email: email}
+	return nil
+}
+
+func (r *fakeRepo) ConsumeEmailVerificationToken(ctx context.Context, tokenHash string) (string, string, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	v, ok := r.verificationTokens[tokenHash]
+	if !ok {
+		return "", "", domain.ErrInvalidVerificationToken
+	}
+	delete(r.verificationTokens, tokenHash)
+	return v.userID, v.email, nil
+}
+
+func (r *fakeRepo) SetPendingEmail(ctx context.Context, userID string, email *string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if u, ok := r.users[userID]; ok {
+		u.PendingEmail = email
+	}
+	return nil
+}
+
+// MarkEmailVerified — Postgres dagi UNIQUE(email) kabi band manzilda ErrEmailTaken
+func (r *fakeRepo) MarkEmailVerified(ctx context.Context, userID, email string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for id, u := range r.users {
+		if id != userID && u.Email != nil && *u.Email == email {
+			return domain.ErrEmailTaken
+		}
+	}
+	if u, ok := r.users[userID]; ok {
+		now := time.Now()
+		u.Email, u.EmailVerifiedAt = &email, &now
+		if u.PendingEmail != nil && *u.PendingEmail == email {
+			u.PendingEmail = nil
+		}
+	}
+	return nil
+}
+
+func (r *fakeRepo) CreateUserWithIdentity(ctx context.Context, u *domain.User, identity *domain.UserIdentity) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	u.ID = uuid.New().String()
+	identity.UserID = u.ID
+	r.users[u.ID] = u
+	r.identities = append(r.identities, *identity)
+	return nil
+}
+
+func (r *fakeRepo) GetIdentity(ctx context.Context, provider, subject string) (*domain.UserIdentity, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for _, id := range r.identities {
+		if id.Provider == provider && id.Subject == subject {
+			copied := id
+			return &copied, nil
+		}
+	}
+	return nil, nil
+}
+
+func (r *fakeRepo) CreateIdentity(ctx context.Context, identity *domain.UserIdentity) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.identities = append(r.identities, *identity)
+	return nil
+}
+
+func (r *fakeRepo) GetSessionByID(ctx context.Context, sessionID string) (*domain.Session, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if s, ok := r.sessions[sessionID]; ok {
+		copied := *s
+		return &copied, nil
+	}
+	return nil, nil
+}
+
+func (r *fakeRepo) SetSessionAuthTime(ctx context.Context, sessionID string, authTime time.Time) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if s, ok := r.sessions[sessionID]; ok {
+		s.AuthTime = authTime
+	}
+	return nil
+}
+
+func (r *fakeRepo) GetSessionByDevice(ctx context.Context, userID, deviceID string) (*domain.Session, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for _, s := range r.sessions {
+		if s.UserID == userID && s.DeviceID == deviceID {
+			copied := *s
+			return &copied, nil
+		}
+	}
+	return nil, nil
+}
+
+func (r *fakeRepo) UpsertSession(ctx context.Context, s *domain.Session) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	copied := *s
+	copied.AuthTime = time.Now()
+	r.sessions[s.ID] = &copied
+	return nil
+}
+
+func (r *fakeRepo) GetSessions(ctx context.Context, userID string) ([]domain.Session, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	var out []domain.Session
+	for _, s := range r.sessions {
+		if s.UserID == userID {
+			out = append(out, *s)
+		}
+	}
+	return out, nil
+}
+
+func (r *fakeRepo) DeleteAllSessions(ctx context.Context, userID string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for id, s := range r.sessions {
+		if s.UserID == userID {
+			delete(r.sessions, id)
+		}
+	}
+	return nil
+}
+
+func (r *fakeRepo) ConsumePasswordResetToken(ctx context.Context, tokenHash string) (string, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	email, ok := r.resetTokens[tokenHash]
+	if !ok {
+		return "", domain.ErrInvalidResetToken
+	}
+	delete(r.resetTokens, tokenHash)
+	return email, nil
+}
+
+func (r *fakeRepo) ResetPassword(ctx context.Context, id, newHash string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if u, ok := r.users[id]; ok {
+		u.PasswordHash = newHash
+	}
+	return nil
+}
+
+func (r *fakeRepo) ScheduleDeletion(ctx context.Context, id string, purgeAt time.Time) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if u, ok := r.users[id]; ok {
+		u.Status, u.StatusReason, u.SuspendedUntil = domain.UserStatusPendingDeletion, nil, &purgeAt
+	}
+	return nil
+}
+
+func (r *fakeRepo) PurgeDeletedAccounts(ctx context.Context, limit int) ([]string, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	var ids []string
+	for id, u := range r.users {
+		if len(ids) == limit {
+			break
+		}
+		if u.Status == domain.UserStatusPendingDeletion && !time.Now().Before(*u.SuspendedUntil) {
+			delete(r.users, id)
+			ids = append(ids, id)
+		}
+	}
+	return ids, nil
+}
+
+func (r *fakeRepo) ChangePassword(ctx context.Context, id, newHash string) error {
+	return r.ResetPassword(ctx, id, newHash)
+}
+
+func (r *fakeRepo) RehashPassword(ctx context.Context, id, oldHash, newHash string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if u, ok := r.users[id]; ok && u.PasswordHash == oldHash {
+		u.PasswordHash = newHash
+	}
+	return nil
+}
+
+func (r *fakeRepo) CreatePasswordResetToken(ctx context.Context, email, tokenHash string, expiresAt time.Time) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.resetTokens[tokenHash] = email
+	return nil
+}
+
+func (r *fakeRepo) UpdateField(ctx context.Context, userID string, field string, value *string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	u, ok := r.users[userID]
+	if !ok {
+		return nil
+	}
+	switch field {
+	case "full_name":
+		u.FullName = value
+	case "avatar_url":
+		u.AvatarURL = value
+	case "language":
+		u.Language = value
+	default:
+		return fmt.Errorf("fakeRepo: unknown field %q", field)
+	}
+	return nil
+}
+
+func (r *fakeRepo) UpdatePrivacySettings(ctx context.Context, userID string, settings domain.PrivacySettings) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if u, ok := r.users[userID]; ok {
+		u.Privacy = settings
+	}
+	return nil
+}
+
+func (r *fakeRepo) SearchUsers(ctx context.Context, query, excludeUserID string, limit int, after *domain.SearchCursor) ([]domain.UserSearchHit, error) {
+	return nil, errNotFaked
+}
+
+func (r *fakeRepo) SetTOTPSecret(ctx context.Context, userID, secret string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if u, ok := r.users[userID]; ok {
+		u.TOTPSecret = &secret
+	}
+	return nil
+}
+
+func (r *fakeRepo) EnableTOTP(ctx context.Context, userID string, recoveryCodeHashes []string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	u, ok := r.users[userID]
+	if !ok {
+		return nil
+	}
+	now := time.Now()
+	u.TOTPEnabledAt = &now
+	codes := make(map[string]bool, len(recoveryCodeHashes))
+	for _, h := range recoveryCodeHashes {
+		codes[h] = true
+	}
+	r.recoveryCodes[userID] = codes
+	return nil
+}
+
+func (r *fakeRepo) DisableTOTP(ctx context.Context, userID string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if u, ok := r.users[userID]; ok {
+		u.TOTPSecret, u.TOTPEnabledAt = nil, nil
+	}
+	delete(r.recoveryCodes, userID)
+	return nil
+}
+
+func (r *fakeRepo) ConsumeRecoveryCode(ctx context.Context, userID, codeHash string) (bool, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if !r.recoveryCodes[userID][codeHash] {
+		return false, nil
+	}
+	delete(r.recoveryCodes[userID], codeHash)
+	return true, nil
+}
+
+func (r *fakeRepo) ListIdentities(ctx context.Context, userID string) ([]domain.UserIdentity, error) {
+	return r.identitiesOf(userID), nil
+}
+
+func (r *fakeRepo) DeleteIdentity(ctx context.Context, userID, provider string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.identities = slices.DeleteFunc(r.identities, func(id domain.UserIdentity) bool {
+		return id.UserID == userID && id.Provider == provider
+	})
+	return nil
+}
+
+func (r *fakeRepo) ListUsers(ctx context.Context, filter domain.UserFilter, limit int, after *domain.PageCursor) ([]domain.User, error) {
+	return nil, errNotFaked
+}
+
+func (r *fakeRepo) SetRole(ctx context.Context, userID string, role domain.Role, audit *domain.AuditEntry) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if u, ok := r.users[userID]; ok {
+		u.Role = role
+	}
+	r.audit = append(r.audit, *audit)
+	return nil
+}
+
+func (r *fakeRepo) SetStatus(ctx context.Context, userID string, status domain.UserStatus, reason *string, until *time.Time, audit *domain.AuditEntry) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if u, ok := r.users[userID]; ok {
+		u.Status, u.StatusReason, u.SuspendedUntil = status, reason, until
+	}
+	r.audit = append(r.audit, *audit)
+	return nil
+}
+
+func (r *fakeRepo) SetLastSeen(ctx context.Context, userID, sessionID string, at time.Time) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if u, ok := r.users[userID]; ok {
+		u.LastSeenAt = &at
+	}
+	return nil
+}
+
+func (r *fakeRepo) GetPresenceRecords(ctx context.Context, ids []string) ([]domain.PresenceRecord, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	var records []domain.PresenceRecord
+	for _, id := range ids {
+		if u, ok := r.users[id]; ok {
+			records = append(records, domain.PresenceRecord{UserID: id, LastSeenAt: u.LastSeenAt, HideLastSeen: u.Privacy.HideLastSeen})
+		}
+	}
+	return records, nil
+}
+
+func (r *fakeRepo) ListLoginEvents(ctx context.Context, userID string, limit int, after *domain.PageCursor) ([]domain.LoginEvent, error) {
+	return nil, errNotFaked
+}
+
+func (r *fakeRepo) UpdateSessionRefresh(ctx context.Context, sessionID, refreshJTI, ipAddress, userAgent string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if s, ok := r.sessions[sessionID]; ok {
+		s.RefreshJTI, s.IPAddress, s.UserAgent, s.LastSeen = refreshJTI, ipAddress, userAgent, time.Now()
+	}
+	return nil
+}
+
+func (r *fakeRepo) DeleteSession(ctx context.Context, userID, deviceID string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for id, s := range r.sessions {
+		if s.UserID == userID && s.DeviceID == deviceID {
+			delete(r.sessions, id)
+		}
+	}
+	return nil
+}
+
+func (r *fakeRepo) DeleteSessionByID(ctx context.Context, sessionID string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	delete(r.sessions, sessionID)
+	return nil
+}
+
+func (r *fakeRepo) DeleteOtherSessions(ctx context.Context, userID, keepSessionID string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for id, s := range r.sessions {
+		if s.UserID == userID && id != keepSessionID {
+			delete(r.sessions, id)
+		}
+	}
+	return nil
+}
+
+func (r *fakeRepo) CreateAuditEntry(ctx context.Context, entry *domain.AuditEntry) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.audit = append(r.audit, *entry)
+	return nil
+}
+
+func (r *fakeRepo) CreateLoginEvent(ctx context.Context, e *domain.LoginEvent) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.loginEvents = append(r.loginEvents, *e)
+	return nil
+}
+
+func (r *fakeRepo) KnownLoginContext(ctx context.Context, userID, deviceID, countryCode string) (*domain.KnownLoginContext, error) {
+	return &domain.KnownLoginContext{}, nil
+}
+
+func (r *fakeRepo) identitiesOf(userID string) []domain.UserIdentity {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	var out []domain.UserIdentity
+	for _, id := range r.identities {
+		if id.UserID == userID {
+			out = append(out, id)
+		}
+	}
+	return out
+}
+
+// fakeTokens — imzosiz, bashorat qilinadigan tokenlar. Tekshiruv metodlari hech qanday tokenni
+// qabul qilmaydi; ularni sinaydigan test o'z ustqurmasini beradi (mfaTokens, introspectTokens).
+type fakeTokens struct{}
+
+var errFakeToken = errors.New("fake tokens are never valid")
+
+func (fakeTokens) GenerateTokens(userID, sessionID string, roles []string) (*domain.TokenPair, error) {
+	return &domain.TokenPair{
+		AccessToken:  "access:" + userID + ":" + sessionID,
+		RefreshToken: "refresh:" + sessionID,
+		RefreshJTI:   "jti:" + sessionID,
+	}, nil
+}
+
+func (fakeTokens) RevokeRefreshJTI(jti string) error          { return nil }
+func (fakeTokens) RevokeSessionAccessTokens(id string) error  { return nil }
+func (fakeTokens) RevokeUserAccessTokens(userID string) error { return nil }
+func (fakeTokens) RevokeRefreshToken(tokenStr string) error   { return nil }
+func (fakeTokens) RevokeMFAToken(jti string) error            { return nil }
+func (fakeTokens) JWKS() []domain.JWK                         { return nil }
+func (fakeTokens) GenerateMFAToken(domain.MFAChallenge) (string, error) {
+	return "mfa-token", nil
+}
+
+func (fakeTokens) GenerateImpersonationToken(userID, actorID string, ttl time.Duration) (string, time.Time, error) {
+	return "impersonation:" + userID + ":" + actorID, time.Now().Add(ttl), nil
+}
+
+func (fakeTokens) ValidateAccessToken(tokenStr string) (*domain.AccessClaims, error) {
+	return nil, errFakeToken
+}
+
+func (fakeTokens) ValidateRefreshToken(tokenStr string) (*domain.RefreshClaims, error) {
+	return nil, errFakeToken
+}
+
+func (fakeTokens) ConsumeRefreshToken(tokenStr string) (*domain.RefreshClaims, error) {
+	return nil, errFakeToken
+}
+
+func (fakeTokens) ValidateMFAToken(tokenStr string) (*domain.MFAChallenge, error) {
+	return nil, errFakeToken
+}
+
+// fakeEvents — Kafka o'rniga nashr qilingan eventlarni yig'adi
+type fakeEvents struct {
+	mu     sync.Mutex
+	events []map[string]string
+}
+
+func (f *fakeEvents) Publish(ctx context.Context, value []byte) error {
+	var event map[string]string
+	if err := json.Unmarshal(value, &event); err != nil {
+		return err
+	}
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.events = append(f.events, event)
+	return nil
+}
+
+func (f *fakeEvents) named(name string) []map[string]string {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	var out []map[string]string
+	for _, e := range f.events {
+		if e["event"] == name {
+			out = append(out, e)
+		}
+	}
+	return out
+}
+
+type fakeIdentityProvider struct {
+	identity *domain.ExternalIdentity
+}
+
+func (f fakeIdentityProvider) Exchange(ctx context.Context, req domain.ProviderAuthRequest) (*domain.ExternalIdentity, error) {
+	copied := *f.identity
+	return &copied, nil
+}
+
+type fakeGeo struct{}
+
+func (fakeGeo) Locate(ctx context.Context, ip string) (*domain.GeoLocation, error) { return nil, nil }
+
+// fakeProfileCache — xotiradagi ProfileCache
+type fakeProfileCache struct {
+	mu       sync.Mutex
+	profiles map[string]domain.PublicProfile
+}
+
+func newFakeProfileCache() *fakeProfileCache {
+	return &fakeProfileCache{profiles: make(map[string]domain.PublicProfile)}
+}
+
+func (c *fakeProfileCache) GetProfiles(ctx context.Context, ids []string) (map[string]domain.PublicProfile, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	found := make(map[string]domain.PublicProfile)
+	for _, id := range ids {
+		if p, ok := c.profiles[id]; ok {
+			found[id] = p
+		}
+	}
+	return found, nil
+}
+
+func (c *fakeProfileCache) SetProfiles(ctx context.Context, profiles []domain.PublicProfile) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for _, p := range profiles {
+		c.profiles[p.ID] = p
+	}
+	return nil
+}
+
+func (c *fakeProfileCache) Invalidate(ctx context.Context, userIDs ...string) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for _, id := range userIDs {
+		delete(c.profiles, id)
+	}
+	return nil
+}
+
+// fakeStatusCache — xotiradagi AccountStatusCache
+type fakeStatusCache struct {
+	restrictions map[string]*domain.AccountRestrictedError
+}
+
+func newFakeStatusCache() *fakeStatusCache {
+	return &fakeStatusCache{restrictions: make(map[string]*domain.AccountRestrictedError)}
+}
+
+func (c *fakeStatusCache) Restriction(ctx context.Context, userID string) (*domain.AccountRestrictedError, error) {
+	return c.restrictions[userID], nil
+}
+
+func (c *fakeStatusCache) SetRestriction(ctx context.Context, userID string, r *domain.AccountRestrictedError) error {
+	c.restrictions[userID] = r
+	return nil
+}
+
+func (c *fakeStatusCache) ClearRestriction(ctx context.Context, userID string) error {
+	delete(c.restrictions, userID)
+	return nil
+}
+
+// newTestService — faqat sinovdagi yo'l uchun kerakli bog'liqliklar bilan
+func newTestService(repo *fakeRepo) (*userService, *fakeEvents) {
+	events := &fakeEvents{}
+	return &userService{
+		repo:          repo,
+		tokenProvider: fakeTokens{},
+		k:             events,
+		geo:           fakeGeo{},
+		profiles:      newFakeProfileCache(),
+		statuses:      newFakeStatusCache(),
+	}, events
+}
+
+func strPtr(s string) *string { return &s }
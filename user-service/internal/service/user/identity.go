@@ -0,0 +1,206 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"log"
+	"strings"
+	"time"
+
+	"user-service/internal/domain"
+
+	"github.com/google/uuid"
+)
+
+// ================= LOGIN WITH PROVIDER =================
+// Provayder identity si bog'langan bo'lsa — o'sha user; aks holda email bo'yicha bog'lanadi
+// (faqat ikkala tomonda email tasdiqlangan bo'lsa) yoki yangi user yaratiladi.
+func (s *userService) LoginWithProvider(ctx context.Context, req domain.ProviderLoginDTO) (result *domain.AuthResult, err error) {
+	trace := s.traceLogin(ctx, "provider:"+req.Auth.Provider, "", req.Platform, req.DeviceID, req.IPAddress, req.UserAgent)
+	defer func() { trace.finish(result, err) }()
+
+	ext, err := s.identities.Exchange(ctx, req.Auth)
+	if err != nil {
+		return nil, err
+	}
+
+	linked, err := s.repo.GetIdentity(ctx, ext.Provider, ext.Subject)
+	if err != nil {
+		return nil, err
+	}
+	if linked != nil {
+		user, err := s.repo.GetByID(ctx, linked.UserID)
+		if err != nil {
+			return nil, err
+		}
+		if user == nil {
+			return nil, errors.New("user not found")
+		}
+		trace.user = user
+		return s.completeLogin(ctx, user, req.Platform, req.DeviceID, req.IPAddress, req.UserAgent)
+	}
+
+	if ext.Email == "" {
+		return nil, errors.New("identity provider did not share an email address")
+	}
+
+	existing, err := s.repo.GetByEmail(ctx, ext.Email)
+	if err != nil {
+		return nil, err
+	}
+	if existing != nil {
+		// Tasdiqlanmagan email orqali birovning hisobini egallab olishning oldini olish
+		if !ext.EmailVerified || existing.EmailVerifiedAt == nil {
+			return nil, domain.ErrAccountExists
+		}
+		trace.user = existing
+		if _, err := s.linkIdentity(ctx, existing.ID, ext); err != nil {
+			return nil, err
+		}
+		return s.completeLogin(ctx, existing, req.Platform, req.DeviceID, req.IPAddress, req.UserAgent)
+	}
+
+	return s.registerWithProvider(ctx, req, ext)
+}
+
+// registerWithProvider — parolsiz yangi user (parolni keyinroq ForgotPassword orqali o'rnatish mumkin)
+func (s *userService) registerWithProvider(ctx context.Context, req domain.ProviderLoginDTO, ext *domain.ExternalIdentity) (*domain.AuthResult, error) {
+	username := generateUsername(ext.Email)
+	email := ext.Email
+
+	user := &domain.User{
+		Username:     &username,
+		Email:        &email,
+		Language:     req.Language,
+		Platform:     req.Platform,
+		DeviceID:     req.DeviceID,
+		UserAgent:    req.UserAgent,
+		RegisteredAt: time.Now(),
+		UpdatedAt:    time.Now(),
+	}
+	if ext.Name != "" {
+		user.FullName = &ext.Name
+	}
+	if ext.AvatarURL != "" {
+		user.AvatarURL = &ext.AvatarURL
+	}
+	if req.IPAddress != "" {
+		user.RegisteredIP = &req.IPAddress
+	}
+	if ext.EmailVerified {
+		now := time.Now()
+		user.EmailVerifiedAt = &now
+	}
+
+	identity := &domain.UserIdentity{
+		Provider: ext.Provider,
+		Subject:  ext.Subject,
+		Email:    ext.Email,
+	}
+	if err := s.repo.CreateUserWithIdentity(ctx, user, identity); err != nil {
+		return nil, err
+	}
+
+	s.publishEvent(ctx, map[string]string{
+		"event":    "UserRegistered",
+		"user_id":  user.ID,
+		"email":    email,
+		"provider": ext.Provider,
+	})
+
+	if user.EmailVerifiedAt == nil {
+		if err := s.sendVerificationEmail(ctx, user.ID, email); err != nil {
+			log.Println("failed to send verification email:", err)
+		}
+	}
+
+	return s.completeLogin(ctx, user, req.Platform, req.DeviceID, req.IPAddress, req.UserAgent)
+}
+
+// ================= LINK IDENTITY =================
+func (s *userService) LinkIdentity(ctx context.Context, userID string, req domain.ProviderAuthRequest) (*domain.UserIdentity, error) {
+	ext, err := s.identities.Exchange(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	linked, err := s.repo.GetIdentity(ctx, ext.Provider, ext.Subject)
+	if err != nil {
+		return nil, err
+	}
+	if linked != nil {
+		if linked.UserID != userID {
+			return nil, domain.ErrIdentityLinked
+		}
+		return linked, nil
+	}
+
+	return s.linkIdentity(ctx, userID, ext)
+}
+
+func (s *userService) linkIdentity(ctx context.Context, userID string, ext *domain.ExternalIdentity) (*domain.UserIdentity, error) {
+	identity := &domain.UserIdentity{
+		UserID:    userID,
+		Provider:  ext.Provider,
+		Subject:   ext.Subject,
+		Email:     ext.Email,
+		CreatedAt: time.Now(),
+	}
+	if err := s.repo.CreateIdentity(ctx, identity); err != nil {
+		return nil, err
+	}
+
+	s.publishEvent(ctx, map[string]string{
+		"event":    "IdentityLinked",
+		"user_id":  userID,
+		"provider": ext.Provider,
+	})
+	return identity, nil
+}
+
+// ================= UNLINK IDENTITY =================
+// Paroli yo'q userda oxirgi identity ni uzib bo'lmaydi — aks holda hisobga kirish yo'li qolmaydi
+func (s *userService) UnlinkIdentity(ctx context.Context, userID, provider string) error {
+	user, err := s.repo.GetByID(ctx, userID)
+	if err != nil {
+		return err
+	}
+	if user == nil {
+		return errors.New("user not found")
+	}
+
+	identities, err := s.repo.ListIdentities(ctx, userID)
+	if err != nil {
+		return err
+	}
+	if user.PasswordHash == "" && len(identities) <= 1 {
+		return domain.ErrLastLoginMethod
+	}
+
+	if err := s.repo.DeleteIdentity(ctx, userID, provider); err != nil {
+		return err
+	}
+
+	s.publishEvent(ctx, map[string]string{
+		"event":    "IdentityUnlinked",
+		"user_id":  userID,
+		"email":    getStr(user.Email),
+		"provider": provider,
+	})
+	return nil
+}
+
+// ================= GET IDENTITIES =================
+func (s *userService) GetIdentities(ctx context.Context, userID string) ([]domain.UserIdentity, error) {
+	return s.repo.ListIdentities(ctx, userID)
+}
+
+// generateUsername — email dan username: faqat [a-z0-9_], oxiriga tasodifiy qo'shimcha
+func generateUsername(email string) string {
+	local, _, _ := strings.Cut(email, "@")
+	base := sanitizeUsernameBase(local, 20)
+	if base == "" {
+		base = "user"
+	}
+	return base + "_" + strings.ReplaceAll(uuid.New().String(), "-", "")[:6]
+}
@@ -0,0 +1,104 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"user-service/internal/domain"
+)
+
+func TestLoginWithProviderAutoLink(t *testing.T) {
+	verifiedAt := time.Now().Add(-time.Hour)
+	tests := []struct {
+		name             string
+		providerVerified bool
+		localVerifiedAt  *time.Time
+		wantErr          error
+	}{
+		{"both sides verified links identity", true, &verifiedAt, nil},
+		{"provider email unverified", false, &verifiedAt, domain.ErrAccountExists},
+		{"local email unverified", true, nil, domain.ErrAccountExists},
+		{"neither side verified", false, nil, domain.ErrAccountExists},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			existing := &domain.User{
+				ID:              "11111111-1111-1111-1111-111111111111",
+				Username:        strPtr("ali"),
+				Email:           strPtr("ali@example.com"),
+				EmailVerifiedAt: tt.localVerifiedAt,
+				Status:          domain.UserStatusActive,
+			}
+			repo := newFakeRepo(existing)
+			s, events := newTestService(repo)
+			s.identities = fakeIdentityProvider{identity: &domain.ExternalIdentity{
+				Provider:      "google",
+				Subject:       "google-subject",
+				Email:         "ali@example.com",
+				EmailVerified: tt.providerVerified,
+			}}
+
+			result, err := s.LoginWithProvider(context.Background(), domain.ProviderLoginDTO{
+				Auth:     domain.ProviderAuthRequest{Provider: "google", Code: "code"},
+				DeviceID: "device-1",
+			})
+
+			linked := repo.identitiesOf(existing.ID)
+			if tt.wantErr != nil {
+				if !errors.Is(err, tt.wantErr) {
+					t.Fatalf("err = %v, want %v", err, tt.wantErr)
+				}
+				if len(linked) != 0 {
+					t.Errorf("identity linked despite unverified email: %+v", linked)
+				}
+				if len(events.named("IdentityLinked")) != 0 {
+					t.Error("IdentityLinked event published for rejected link")
+				}
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("LoginWithProvider: %v", err)
+			}
+			if result.User.ID != existing.ID || result.AccessToken == "" {
+				t.Errorf("logged into %q (token %q), want existing user %q", result.User.ID, result.AccessToken, existing.ID)
+			}
+			if len(linked) != 1 || linked[0].Provider != "google" || linked[0].Subject != "google-subject" {
+				t.Errorf("linked identities = %+v, want google/google-subject", linked)
+			}
+			if len(events.named("IdentityLinked")) != 1 {
+				t.Error("IdentityLinked event not published")
+			}
+		})
+	}
+}
+
+func TestLoginWithProviderUsesLinkedIdentity(t *testing.T) {
+	owner := &domain.User{
+		ID:       "22222222-2222-2222-2222-222222222222",
+		Username: strPtr("owner"),
+		Email:    strPtr("owner@example.com"),
+		Status:   domain.UserStatusActive,
+	}
+	repo := newFakeRepo(owner)
+	repo.identities = []domain.UserIdentity{{UserID: owner.ID, Provider: "google", Subject: "google-subject"}}
+	s, _ := newTestService(repo)
+	// Provayderdagi email boshqa — bog'langan identity ustun turadi
+	s.identities = fakeIdentityProvider{identity: &domain.ExternalIdentity{
+		Provider: "google",
+		Subject:  "google-subject",
+		Email:    "changed@example.com",
+	}}
+
+	result, err := s.LoginWithProvider(context.Background(), domain.ProviderLoginDTO{
+		Auth: domain.ProviderAuthRequest{Provider: "google", Code: "code"},
+	})
+	if err != nil {
+		t.Fatalf("LoginWithProvider: %v", err)
+	}
+	if result.User.ID != owner.ID {
+		t.Errorf("logged into %q, want %q", result.User.ID, owner.ID)
+	}
+}
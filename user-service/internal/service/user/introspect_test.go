@@ -0,0 +1,109 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"user-service/internal/domain"
+)
+
+// introspectTokens — ValidateAccessToken natijasi testda belgilanadi
+type introspectTokens struct {
+	fakeTokens
+	claims *domain.AccessClaims
+	err    error
+}
+
+func (t introspectTokens) ValidateAccessToken(token string) (*domain.AccessClaims, error) {
+	return t.claims, t.err
+}
+
+// fakeStatuses — AccountStatusCache; err berilsa kesh ishlamayotgandek
+type fakeStatuses struct {
+	restriction *domain.AccountRestrictedError
+	err         error
+}
+
+func (f fakeStatuses) Restriction(ctx context.Context, userID string) (*domain.AccountRestrictedError, error) {
+	return f.restriction, f.err
+}
+
+func (f fakeStatuses) SetRestriction(ctx context.Context, userID string, r *domain.AccountRestrictedError) error {
+	return f.err
+}
+
+func (f fakeStatuses) ClearRestriction(ctx context.Context, userID string) error { return f.err }
+
+// failingRepo — DB ham javob bermaydigan holat
+type failingRepo struct {
+	*fakeRepo
+}
+
+func (failingRepo) GetByID(ctx context.Context, id string) (*domain.User, error) {
+	return nil, errors.New("connection refused")
+}
+
+func TestIntrospectToken(t *testing.T) {
+	const userID = "44444444-4444-4444-4444-444444444444"
+	claims := &domain.AccessClaims{UserID: userID, SessionID: "session-1"}
+	cacheDown := errors.New("redis: connection refused")
+	suspended := &domain.AccountRestrictedError{Status: domain.UserStatusSuspended}
+
+	tests := []struct {
+		name       string
+		tokenErr   error
+		statuses   fakeStatuses
+		dbStatus   domain.UserStatus // "" => user DB da yo'q
+		dbDown     bool
+		wantActive bool
+		wantErr    error
+	}{
+		{name: "valid token, active account", dbStatus: domain.UserStatusActive, wantActive: true},
+		{name: "invalid token", tokenErr: errors.New("invalid token")},
+		{name: "revocation store down", tokenErr: fmt.Errorf("%w: redis", domain.ErrServiceUnavailable), wantErr: domain.ErrServiceUnavailable},
+		{name: "restricted in cache", statuses: fakeStatuses{restriction: suspended}, dbStatus: domain.UserStatusActive},
+		{name: "cache down, banned in DB", statuses: fakeStatuses{err: cacheDown}, dbStatus: domain.UserStatusBanned},
+		{name: "cache down, active in DB", statuses: fakeStatuses{err: cacheDown}, dbStatus: domain.UserStatusActive, wantActive: true},
+		{name: "cache down, user deleted", statuses: fakeStatuses{err: cacheDown}},
+		{name: "cache and DB down", statuses: fakeStatuses{err: cacheDown}, dbDown: true, wantErr: domain.ErrServiceUnavailable},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := newFakeRepo()
+			if tt.dbStatus != "" {
+				repo = newFakeRepo(&domain.User{ID: userID, Status: tt.dbStatus})
+			}
+			s, _ := newTestService(repo)
+			if tt.dbDown {
+				s.repo = failingRepo{repo}
+			}
+			s.tokenProvider = introspectTokens{claims: claims, err: tt.tokenErr}
+			s.statuses = tt.statuses
+
+			got, err := s.IntrospectToken(context.Background(), "token")
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("err = %v, want %v", err, tt.wantErr)
+			}
+			if active := got != nil; active != tt.wantActive {
+				t.Errorf("active = %v, want %v", active, tt.wantActive)
+			}
+		})
+	}
+}
+
+func TestIntrospectTokenExpiredSuspension(t *testing.T) {
+	const userID = "55555555-5555-5555-5555-555555555555"
+	ended := time.Now().Add(-time.Hour)
+	repo := newFakeRepo(&domain.User{ID: userID, Status: domain.UserStatusSuspended, SuspendedUntil: &ended})
+	s, _ := newTestService(repo)
+	s.tokenProvider = introspectTokens{claims: &domain.AccessClaims{UserID: userID}}
+	s.statuses = fakeStatuses{err: errors.New("redis down")}
+
+	got, err := s.IntrospectToken(context.Background(), "token")
+	if err != nil || got == nil {
+		t.Fatalf("IntrospectToken = %v, %v; want active claims", got, err)
+	}
+}
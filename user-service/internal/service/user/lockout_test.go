@@ -0,0 +1,131 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"sync"
+	"testing"
+	"time"
+
+	"user-service/internal/domain"
+	"user-service/internal/utils"
+
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+)
+
+// fakeAttempts — xotiradagi LoginAttemptTracker (Redis dagi bilan bir xil LockoutPolicy hisobi)
+type fakeAttempts struct {
+	mu       sync.Mutex
+	failures map[string]int
+	locked   map[string]time.Duration
+}
+
+func newFakeAttempts() *fakeAttempts {
+	return &fakeAttempts{failures: make(map[string]int), locked: make(map[string]time.Duration)}
+}
+
+func (f *fakeAttempts) LockedFor(ctx context.Context, key string) (time.Duration, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.locked[key], nil
+}
+
+func (f *fakeAttempts) RecordFailure(ctx context.Context, key string, policy domain.LockoutPolicy) (int, time.Duration, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.failures[key]++
+	lockout := policy.LockoutFor(f.failures[key])
+	if lockout > 0 {
+		f.locked[key] = lockout
+	}
+	return f.failures[key], lockout, nil
+}
+
+func (f *fakeAttempts) Reset(ctx context.Context, key string) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	delete(f.failures, key)
+	delete(f.locked, key)
+	return nil
+}
+
+// spoofedRequest — bitta TCP peerdan, har safar boshqa x-forwarded-for bilan kelgan so'rov IP si
+// (handler dagidek: ishonchli proxy ro'yxati bo'sh)
+func spoofedRequest(t *testing.T, peerAddr, forwardedFor string) string {
+	t.Helper()
+	addr, err := net.ResolveTCPAddr("tcp", peerAddr)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ctx := peer.NewContext(context.Background(), &peer.Peer{Addr: addr})
+	ctx = metadata.NewIncomingContext(ctx, metadata.Pairs("x-forwarded-for", forwardedFor))
+	proxies, err := utils.ParseTrustedProxies(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return proxies.ClientIP(ctx)
+}
+
+func TestLoginIPLockoutIgnoresForwardedFor(t *testing.T) {
+	const ipMaxFailures = 5
+	s, events := newTestService(newFakeRepo())
+	attempts := newFakeAttempts()
+	s.attempts = attempts
+	s.cfg.EmailLockout = domain.LockoutPolicy{MaxFailures: 100, BaseLockout: time.Minute, MaxLockout: time.Hour}
+	s.cfg.IPLockout = domain.LockoutPolicy{MaxFailures: ipMaxFailures, BaseLockout: time.Minute, MaxLockout: time.Hour}
+
+	// Hujumchi har urinishda boshqa email va soxta x-forwarded-for yuboradi
+	for i := 1; i <= ipMaxFailures+3; i++ {
+		ip := spoofedRequest(t, "203.0.113.5:4000", fmt.Sprintf("198.51.100.%d", i))
+		if ip != "203.0.113.5" {
+			t.Fatalf("resolved ip = %q, want peer address", ip)
+		}
+		_, err := s.Login(context.Background(), domain.LoginDTO{
+			Email:     fmt.Sprintf("victim%d@example.com", i),
+			Password:  "guess",
+			IPAddress: ip,
+		})
+
+		var locked *domain.AccountLockedError
+		if i < ipMaxFailures {
+			if err == nil || errors.As(err, &locked) {
+				t.Fatalf("attempt %d: err = %v, want plain login failure", i, err)
+			}
+			continue
+		}
+		if !errors.As(err, &locked) {
+			t.Fatalf("attempt %d: err = %v, want AccountLockedError", i, err)
+		}
+	}
+
+	if got := attempts.failures["ip:203.0.113.5"]; got != ipMaxFailures {
+		t.Errorf("ip failures = %d, want %d (locked attempts are not counted)", got, ipMaxFailures)
+	}
+	suspicious := events.named("SuspiciousLoginAttempts")
+	if len(suspicious) != 1 {
+		t.Fatalf("SuspiciousLoginAttempts events = %d, want exactly 1", len(suspicious))
+	}
+	if e := suspicious[0]; e["scope"] != "ip" || e["ip_address"] != "203.0.113.5" || e["failures"] != fmt.Sprint(ipMaxFailures) {
+		t.Errorf("event = %v", e)
+	}
+}
+
+func TestIPLockoutKey(t *testing.T) {
+	tests := []struct {
+		ip, want string
+	}{
+		{"203.0.113.5", "ip:203.0.113.5"},
+		{"::ffff:203.0.113.5", "ip:203.0.113.5"},
+		{"2001:db8:1:2:aaaa::1", "ip:2001:db8:1:2::/64"},
+		{"2001:db8:1:2:bbbb::9", "ip:2001:db8:1:2::/64"},
+		{"2001:db8:1:3::1", "ip:2001:db8:1:3::/64"},
+	}
+	for _, tt := range tests {
+		if got := ipLockoutKey(tt.ip); got != tt.want {
+			t.Errorf("ipLockoutKey(%q) = %q, want %q", tt.ip, got, tt.want)
+		}
+	}
+}
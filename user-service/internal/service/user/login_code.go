@@ -0,0 +1,107 @@
+package service
+
+import (
+	"context"
+	"log"
+	"net/url"
+	"strings"
+	"time"
+
+	"user-service/internal/domain"
+	"user-service/internal/utils"
+)
+
+// ================= REQUEST LOGIN CODE =================
+// ForgotPassword kabi: javob email mavjudligini oshkor qilmaydi.
+func (s *userService) RequestLoginCode(ctx context.Context, email string) error {
+	email = strings.ToLower(strings.TrimSpace(email))
+	if email == "" {
+		return nil
+	}
+
+	allowed, _, err := s.limiter.Allow(ctx, "login_code:"+email, loginCodeLimit, loginCodeWindow)
+	if err != nil {
+		log.Println("login code rate limiter error:", err)
+		return nil
+	}
+	if !allowed {
+		return nil
+	}
+
+	go s.sendLoginCode(context.WithoutCancel(ctx), email)
+	return nil
+}
+
+func (s *userService) sendLoginCode(ctx context.Context, email string) {
+	user, err := s.repo.GetByEmail(ctx, email)
+	if err != nil {
+		log.Println("login code lookup error:", err)
+		return
+	}
+	if user == nil {
+		return
+	}
+
+	code, err := utils.GenerateNumericCode(loginCodeDigits)
+	if err != nil {
+		log.Println("failed to generate login code:", err)
+		return
+	}
+	if err := s.loginCodes.Save(ctx, email, hashLoginCode(email, code), loginCodeTTL); err != nil {
+		log.Println("failed to store login code:", err)
+		return
+	}
+
+	link := s.cfg.LoginLinkURL + "?" + url.Values{"email": {email}, "code": {code}}.Encode()
+	s.publishEvent(ctx, map[string]string{
+		"event":      "LoginCodeRequested",
+		"user_id":    user.ID,
+		"email":      email,
+		"code":       code,
+		"login_link": link,
+		"expires_at": time.Now().Add(loginCodeTTL).UTC().Format(time.RFC3339),
+	})
+}
+
+// ================= VERIFY LOGIN CODE =================
+func (s *userService) VerifyLoginCode(ctx context.Context, req domain.LoginCodeDTO) (result *domain.AuthResult, err error) {
+	email := strings.ToLower(strings.TrimSpace(req.Email))
+	code := strings.TrimSpace(req.Code)
+	trace := s.traceLogin(ctx, "login_code", email, req.Platform, req.DeviceID, req.IPAddress, req.UserAgent)
+	defer func() { trace.finish(result, err) }()
+
+	if email == "" || code == "" {
+		return nil, domain.ErrInvalidLoginCode
+	}
+
+	ok, err := s.loginCodes.Verify(ctx, email, hashLoginCode(email, code), loginCodeMaxAttempts)
+	if err != nil {
+		return nil, err
+	}
+	if !ok {
+		return nil, domain.ErrInvalidLoginCode
+	}
+
+	user, err := s.repo.GetByEmail(ctx, email)
+	if err != nil {
+		return nil, err
+	}
+	if user == nil {
+		return nil, domain.ErrInvalidLoginCode
+	}
+	trace.user = user
+
+	// Kod shu manzilga yuborilgan — demak email egasi tasdiqlandi
+	if user.EmailVerifiedAt == nil {
+		if err := s.repo.MarkEmailVerified(ctx, user.ID, email); err != nil {
+			log.Println("failed to mark email verified:", err)
+		}
+	}
+
+	return s.completeLogin(ctx, user, req.Platform, req.DeviceID, req.IPAddress, req.UserAgent)
+}
+
+// hashLoginCode — kod email bilan birga hash qilinadi, bir xil kodlar turli hash beradi
+func hashLoginCode(email, code string) string {
+	return utils.HashToken(email + ":" + code)
+}
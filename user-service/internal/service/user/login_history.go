@@ -0,0 +1,155 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"log"
+	"strconv"
+
+	"user-service/internal/domain"
+)
+
+const (
+	defaultLoginHistoryPageSize = 20
+	maxLoginHistoryPageSize     = 100
+)
+
+// ================= LOGIN HISTORY =================
+func (s *userService) GetLoginHistory(ctx context.Context, userID string, pageSize int, pageToken string) (*domain.LoginHistoryPage, error) {
+	if pageSize <= 0 {
+		pageSize = defaultLoginHistoryPageSize
+	}
+	pageSize = min(pageSize, maxLoginHistoryPageSize)
+
+	after, err := decodePageToken(pageToken)
+	if err != nil {
+		return nil, err
+	}
+
+	// Bitta ortiqcha qator — keyingi sahifa bor-yo'qligini bilish uchun
+	events, err := s.repo.ListLoginEvents(ctx, userID, pageSize+1, after)
+	if err != nil {
+		return nil, err
+	}
+
+	page := &domain.LoginHistoryPage{Events: events}
+	if len(events) > pageSize {
+		page.Events = events[:pageSize]
+		last := page.Events[pageSize-1]
+		page.NextPageToken = encodePageToken(domain.PageCursor{At: last.CreatedAt, ID: last.ID})
+	}
+	return page, nil
+}
+
+// loginTrace — bitta login urinishi. Login metodlari boshida yaratiladi va
+// defer ichida natija bilan yakunlanadi (har bir chiqish nuqtasi avtomatik yoziladi).
+type loginTrace struct {
+	s     *userService
+	ctx   context.Context
+	event domain.LoginEvent
+	user  *domain.User // parol/kod tekshirilgan user (muvaffaqiyatsiz urinishda ham ma'lum bo'lishi mumkin)
+}
+
+func (s *userService) traceLogin(ctx context.Context, method, email, platform, deviceID, ip, userAgent string) *loginTrace {
+	return &loginTrace{
+		s:   s,
+		ctx: ctx,
+		event: domain.LoginEvent{
+			Email:     email,
+			Method:    method,
+			Platform:  platform,
+			DeviceID:  deviceID,
+			IPAddress: ip,
+			UserAgent: userAgent,
+		},
+	}
+}
+
+func (t *loginTrace) finish(result *domain.AuthResult, err error) {
+	e := t.event
+	user := t.user
+	if result != nil && result.User != nil {
+		user = result.User
+	}
+	if user != nil {
+		e.UserID = user.ID
+		if e.Email == "" {
+			e.Email = getStr(user.Email)
+		}
+	}
+
+	var locked *domain.AccountLockedError
+	var restricted *domain.AccountRestrictedError
+	switch {
+	case err == nil && result != nil && result.MFARequired:
+		e.Outcome = domain.LoginMFARequired
+	case err == nil:
+		e.Outcome = domain.LoginSuccess
+	case errors.As(err, &locked):
+		e.Outcome = domain.LoginLocked
+	case errors.As(err, &restricted):
+		e.Outcome = domain.LoginBlocked
+	default:
+		e.Outcome = domain.LoginFailure
+	}
+	if err != nil {
+		e.FailureReason = err.Error()
+	}
+
+	// GeoIP so'rovi login javobini kechiktirmasligi uchun fonda
+	go t.s.saveLoginEvent(context.WithoutCancel(t.ctx), e)
+}
+
+// saveLoginEvent — joylashuvni aniqlaydi, yangi qurilma/davlatni tekshiradi va login_events ga yozadi
+func (s *userService) saveLoginEvent(ctx context.Context, e domain.LoginEvent) {
+	if e.IPAddress != "" {
+		loc, err := s.geo.Locate(ctx, e.IPAddress)
+		if err != nil {
+			log.Println("failed to resolve login location:", err)
+		} else if loc != nil {
+			e.Location = loc.String()
+			e.CountryCode = loc.CountryCode
+		}
+	}
+
+	// Tekshiruv joriy yozuv saqlanishidan oldin — aks holda qurilma doim "ko'rilgan" bo'lardi
+	if e.Outcome == domain.LoginSuccess && e.UserID != "" {
+		s.detectNewDevice(ctx, e)
+	}
+
+	if err := s.repo.CreateLoginEvent(ctx, &e); err != nil {
+		log.Println("failed to store login event:", err)
+	}
+}
+
+// detectNewDevice — avval ko'rilmagan qurilma yoki davlatdan muvaffaqiyatli login bo'lsa
+// NewDeviceLogin eventi (notification servis userga xabar yuboradi). Birinchi login hisobga olinmaydi.
+func (s *userService) detectNewDevice(ctx context.Context, e domain.LoginEvent) {
+	known, err := s.repo.KnownLoginContext(ctx, e.UserID, e.DeviceID, e.CountryCode)
+	if err != nil {
+		log.Println("failed to check login history:", err)
+		return
+	}
+	if !known.HasHistory {
+		return
+	}
+	newDevice := e.DeviceID != "" && !known.DeviceSeen
+	newCountry := e.CountryCode != "" && !known.CountrySeen
+	if !newDevice && !newCountry {
+		return
+	}
+
+	s.publishEvent(ctx, map[string]string{
+		"event":       "NewDeviceLogin",
+		"user_id":     e.UserID,
+		"email":       e.Email,
+		"method":      e.Method,
+		"device_id":   e.DeviceID,
+		"platform":    e.Platform,
+		"ip_address":  e.IPAddress,
+		"user_agent":  e.UserAgent,
+		"location":    e.Location,
+		"new_device":  strconv.FormatBool(newDevice),
+		"new_country": strconv.FormatBool(newCountry),
+	})
+}
@@ -0,0 +1,186 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"user-service/internal/domain"
+	"user-service/internal/utils"
+)
+
+// ================= ENABLE TOTP =================
+// Secret saqlanadi, lekin 2FA faqat ConfirmTOTP dan keyin yoqiladi.
+func (s *userService) EnableTOTP(ctx context.Context, userID string) (*domain.TOTPSetup, error) {
+	user, err := s.repo.GetByID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if user == nil {
+		return nil, errors.New("user not found")
+	}
+	if user.TOTPEnabledAt != nil {
+		return nil, domain.ErrTOTPAlreadyEnabled
+	}
+
+	secret, err := utils.GenerateTOTPSecret()
+	if err != nil {
+		return nil, err
+	}
+	if err := s.repo.SetTOTPSecret(ctx, userID, secret); err != nil {
+		return nil, err
+	}
+
+	return &domain.TOTPSetup{
+		Secret:     secret,
+		OTPAuthURI: utils.TOTPURI(totpIssuer, getStr(user.Email), secret),
+	}, nil
+}
+
+// ================= CONFIRM TOTP =================
+// Ilovadagi birinchi kod to'g'ri bo'lsa 2FA yoqiladi va tiklash kodlari bir marta ko'rsatiladi.
+func (s *userService) ConfirmTOTP(ctx context.Context, userID, code string) ([]string, error) {
+	user, err := s.repo.GetByID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if user == nil {
+		return nil, errors.New("user not found")
+	}
+	if user.TOTPEnabledAt != nil {
+		return nil, domain.ErrTOTPAlreadyEnabled
+	}
+	if user.TOTPSecret == nil {
+		return nil, domain.ErrTOTPSetupNotStarted
+	}
+	if !utils.ValidateTOTP(*user.TOTPSecret, code, time.Now()) {
+		return nil, domain.ErrInvalidMFACode
+	}
+
+	codes, err := utils.GenerateRecoveryCodes(recoveryCodeCount)
+	if err != nil {
+		return nil, err
+	}
+	hashes := make([]string, len(codes))
+	for i, c := range codes {
+		hashes[i] = utils.HashToken(c)
+	}
+	if err := s.repo.EnableTOTP(ctx, userID, hashes); err != nil {
+		return nil, err
+	}
+
+	s.publishEvent(ctx, map[string]string{
+		"event":   "TwoFactorEnabled",
+		"user_id": userID,
+		"email":   getStr(user.Email),
+	})
+	return codes, nil
+}
+
+// ================= DISABLE TOTP =================
+func (s *userService) DisableTOTP(ctx context.Context, userID, code string) error {
+	user, err := s.repo.GetByID(ctx, userID)
+	if err != nil {
+		return err
+	}
+	if user == nil {
+		return errors.New("user not found")
+	}
+	if user.TOTPEnabledAt == nil {
+		return domain.ErrTOTPNotEnabled
+	}
+	if err := s.verifySecondFactor(ctx, user, code); err != nil {
+		return err
+	}
+	if err := s.repo.DisableTOTP(ctx, userID); err != nil {
+		return err
+	}
+
+	s.publishEvent(ctx, map[string]string{
+		"event":   "TwoFactorDisabled",
+		"user_id": userID,
+		"email":   getStr(user.Email),
+	})
+	return nil
+}
+
+// ================= VERIFY MFA =================
+// Login da berilgan challenge tokenni TOTP yoki tiklash kodi bilan almashtirib, sessiya ochadi.
+func (s *userService) VerifyMFA(ctx context.Context, mfaToken, code, ipAddress, userAgent string) (result *domain.AuthResult, err error) {
+	challenge, err := s.tokenProvider.ValidateMFAToken(mfaToken)
+	if err != nil {
+		return nil, domain.ErrInvalidMFAToken
+	}
+	trace := s.traceLogin(ctx, "mfa", "", challenge.Platform, challenge.DeviceID, ipAddress, userAgent)
+	defer func() { trace.finish(result, err) }()
+
+	allowed, _, err := s.limiter.Allow(ctx, "mfa:"+challenge.JTI, mfaAttemptLimit, mfaAttemptWindow)
+	if err != nil {
+		return nil, err
+	}
+	if !allowed {
+		// Juda ko'p noto'g'ri urinish — challenge bekor qilinadi, qaytadan Login kerak
+		_ = s.tokenProvider.RevokeMFAToken(challenge.JTI)
+		return nil, domain.ErrInvalidMFAToken
+	}
+
+	user, err := s.repo.GetByID(ctx, challenge.UserID)
+	if err != nil {
+		return nil, err
+	}
+	if user == nil || user.TOTPEnabledAt == nil {
+		return nil, domain.ErrInvalidMFAToken
+	}
+	trace.user = user
+	// Challenge berilgandan keyin account bloklangan bo'lishi mumkin — joriy status
+	// ikkinchi faktordan (tiklash kodi sarflanishidan) oldin tekshiriladi
+	if restriction := user.Restriction(time.Now()); restriction != nil {
+		_ = s.tokenProvider.RevokeMFAToken(challenge.JTI)
+		return nil, restriction
+	}
+	if err := s.verifySecondFactor(ctx, user, code); err != nil {
+		return nil, err
+	}
+	if err := s.tokenProvider.RevokeMFAToken(challenge.JTI); err != nil {
+		return nil, err
+	}
+
+	return s.startSession(ctx, user, challenge.Platform, challenge.DeviceID, ipAddress, userAgent)
+}
+
+// mfaChallenge — 2FA yoqilgan foydalanuvchi uchun tokenlar o'rniga challenge qaytaradi
+func (s *userService) mfaChallenge(user *domain.User, platform, deviceID string) (*domain.AuthResult, error) {
+	mfaToken, err := s.tokenProvider.GenerateMFAToken(domain.MFAChallenge{
+		UserID:   user.ID,
+		Platform: platform,
+		DeviceID: deviceID,
+	})
+	if err != nil {
+		return nil, errors.New("failed to generate tokens")
+	}
+	return &domain.AuthResult{MFARequired: true, MFAToken: mfaToken}, nil
+}
+
+// verifySecondFactor — TOTP kodi yoki bir martalik tiklash kodini tekshiradi.
+// Bitta TOTP kodi o'z oynasida ikki marta qabul qilinmaydi.
+func (s *userService) verifySecondFactor(ctx context.Context, user *domain.User, code string) error {
+	if user.TOTPSecret != nil && utils.ValidateTOTP(*user.TOTPSecret, code, time.Now()) {
+		fresh, _, err := s.limiter.Allow(ctx, "totp_used:"+user.ID+":"+code, 1, totpCodeReuseGuard)
+		if err != nil {
+			return err
+		}
+		if !fresh {
+			return domain.ErrInvalidMFACode
+		}
+		return nil
+	}
+
+	ok, err := s.repo.ConsumeRecoveryCode(ctx, user.ID, utils.HashToken(utils.NormalizeRecoveryCode(code)))
+	if err != nil {
+		return err
+	}
+	if !ok {
+		return domain.ErrInvalidMFACode
+	}
+	return nil
+}
@@ -0,0 +1,111 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"user-service/internal/domain"
+	"user-service/internal/utils"
+)
+
+const mfaRecoveryCode = "ABCD-EFGH"
+
+// mfaTokens — bitta challenge ni taniydi va bekor qilinganini eslab qoladi
+type mfaTokens struct {
+	fakeTokens
+	challenge domain.MFAChallenge
+
+	mu      sync.Mutex
+	revoked bool
+}
+
+func (t *mfaTokens) ValidateMFAToken(tokenStr string) (*domain.MFAChallenge, error) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if tokenStr != "mfa-token" || t.revoked {
+		return nil, errors.New("invalid mfa token")
+	}
+	copied := t.challenge
+	return &copied, nil
+}
+
+func (t *mfaTokens) RevokeMFAToken(jti string) error {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.revoked = true
+	return nil
+}
+
+type allowAll struct{}
+
+func (allowAll) Allow(ctx context.Context, key string, limit int, window time.Duration) (bool, time.Duration, error) {
+	return true, 0, nil
+}
+
+func TestVerifyMFARechecksAccountStatus(t *testing.T) {
+	past := time.Now().Add(-time.Minute)
+	future := time.Now().Add(time.Hour)
+	tests := []struct {
+		name       string
+		status     domain.UserStatus
+		until      *time.Time
+		restricted bool
+	}{
+		{"active", domain.UserStatusActive, nil, false},
+		{"suspended after challenge", domain.UserStatusSuspended, &future, true},
+		{"suspended indefinitely", domain.UserStatusSuspended, nil, true},
+		{"banned after challenge", domain.UserStatusBanned, nil, true},
+		{"suspension expired", domain.UserStatusSuspended, &past, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			enabledAt := time.Now().Add(-24 * time.Hour)
+			user := &domain.User{
+				ID:             "44444444-4444-4444-4444-444444444444",
+				Email:          strPtr("mfa@example.com"),
+				TOTPEnabledAt:  &enabledAt,
+				Status:         tt.status,
+				SuspendedUntil: tt.until,
+			}
+			recoveryHash := utils.HashToken(utils.NormalizeRecoveryCode(mfaRecoveryCode))
+			repo := newFakeRepo(user)
+			repo.recoveryCodes[user.ID] = map[string]bool{recoveryHash: true}
+			tokens := &mfaTokens{challenge: domain.MFAChallenge{UserID: user.ID, JTI: "challenge-1", DeviceID: "device-1"}}
+			s, _ := newTestService(repo)
+			s.tokenProvider = tokens
+			s.limiter = allowAll{}
+
+			result, err := s.VerifyMFA(context.Background(), "mfa-token", mfaRecoveryCode, "198.51.100.7", "test-agent")
+
+			if !tt.restricted {
+				if err != nil {
+					t.Fatalf("VerifyMFA: %v", err)
+				}
+				if result.AccessToken == "" {
+					t.Error("no access token issued")
+				}
+				return
+			}
+
+			var restricted *domain.AccountRestrictedError
+			if !errors.As(err, &restricted) || restricted.Status != tt.status {
+				t.Fatalf("err = %v, want AccountRestrictedError with status %q", err, tt.status)
+			}
+			if result != nil {
+				t.Errorf("result = %+v, want nil", result)
+			}
+			if len(repo.sessions) != 0 {
+				t.Errorf("%d sessions created for restricted account", len(repo.sessions))
+			}
+			if !repo.recoveryCodes[user.ID][recoveryHash] {
+				t.Error("recovery code consumed for restricted account")
+			}
+			if !tokens.revoked {
+				t.Error("MFA challenge not revoked")
+			}
+		})
+	}
+}
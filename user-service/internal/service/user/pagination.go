@@ -0,0 +1,71 @@
+package service
+
+import (
+	"encoding/base64"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+
+	"user-service/internal/domain"
+
+	"github.com/google/uuid"
+)
+
+// Page token — "<vaqt RFC3339Nano>|<id>" base64url ko'rinishida
+func encodePageToken(c domain.PageCursor) string {
+	return base64.RawURLEncoding.EncodeToString([]byte(c.At.Format(time.RFC3339Nano) + "|" + c.ID))
+}
+
+// decodePageToken — bo'sh token => birinchi sahifa (nil). id SQL da ::uuid bilan solishtiriladi,
+// shuning uchun soxta token Internal emas, InvalidArgument bo'lishi uchun shu yerda tekshiriladi
+func decodePageToken(token string) (*domain.PageCursor, error) {
+	if token == "" {
+		return nil, nil
+	}
+	raw, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil {
+		return nil, domain.ErrInvalidPageToken
+	}
+	ts, id, ok := strings.Cut(string(raw), "|")
+	if !ok {
+		return nil, domain.ErrInvalidPageToken
+	}
+	t, err := time.Parse(time.RFC3339Nano, ts)
+	if err != nil {
+		return nil, domain.ErrInvalidPageToken
+	}
+	if _, err := uuid.Parse(id); err != nil {
+		return nil, domain.ErrInvalidPageToken
+	}
+	return &domain.PageCursor{At: t, ID: id}, nil
+}
+
+// Qidiruv page tokeni — "<rank>|<id>"; rank float32 aniqligida yoziladi (SQL dagi real bilan teng bo'lishi uchun)
+func encodeSearchToken(c domain.SearchCursor) string {
+	rank := strconv.FormatFloat(float64(c.Rank), 'g', -1, 32)
+	return base64.RawURLEncoding.EncodeToString([]byte(rank + "|" + c.ID))
+}
+
+// decodeSearchToken — decodePageToken kabi: id uuid bo'lishi shart
+func decodeSearchToken(token string) (*domain.SearchCursor, error) {
+	if token == "" {
+		return nil, nil
+	}
+	raw, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil {
+		return nil, domain.ErrInvalidPageToken
+	}
+	rank, id, ok := strings.Cut(string(raw), "|")
+	if !ok || id == "" {
+		return nil, domain.ErrInvalidPageToken
+	}
+	r, err := strconv.ParseFloat(rank, 32)
+	if err != nil || math.IsNaN(r) || math.IsInf(r, 0) {
+		return nil, domain.ErrInvalidPageToken
+	}
+	if _, err := uuid.Parse(id); err != nil {
+		return nil, domain.ErrInvalidPageToken
+	}
+	return &domain.SearchCursor{Rank: float32(r), ID: id}, nil
+}
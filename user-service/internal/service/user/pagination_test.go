@@ -0,0 +1,91 @@
+package service
+
+import (
+	"encoding/base64"
+	"errors"
+	"testing"
+	"time"
+
+	"user-service/internal/domain"
+)
+
+const cursorID = "99999999-9999-9999-9999-999999999999"
+
+func rawToken(s string) string { return base64.RawURLEncoding.EncodeToString([]byte(s)) }
+
+func TestPageTokenRoundTrip(t *testing.T) {
+	at := time.Date(2024, 5, 1, 12, 30, 45, 123456789, time.UTC)
+	want := domain.PageCursor{At: at, ID: cursorID}
+
+	got, err := decodePageToken(encodePageToken(want))
+	if err != nil {
+		t.Fatalf("decodePageToken: %v", err)
+	}
+	if !got.At.Equal(want.At) || got.ID != want.ID {
+		t.Errorf("cursor = %+v, want %+v", *got, want)
+	}
+
+	if c, err := decodePageToken(""); c != nil || err != nil {
+		t.Errorf("empty token = %+v, %v; want nil, nil", c, err)
+	}
+}
+
+func TestDecodePageTokenRejectsForged(t *testing.T) {
+	tests := []struct {
+		name  string
+		token string
+	}{
+		{"not base64", "!!!"},
+		{"no separator", rawToken("2024-01-01T00:00:00Z")},
+		{"bad time", rawToken("yesterday|" + cursorID)},
+		{"id not uuid", rawToken("2024-01-01T00:00:00Z|x")},
+		{"empty id", rawToken("2024-01-01T00:00:00Z|")},
+		{"sql in id", rawToken("2024-01-01T00:00:00Z|' OR 1=1 --")},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := decodePageToken(tt.token); !errors.Is(err, domain.ErrInvalidPageToken) {
+				t.Errorf("err = %v, want ErrInvalidPageToken", err)
+			}
+		})
+	}
+}
+
+func TestSearchTokenRoundTrip(t *testing.T) {
+	for _, rank := range []float32{0, 0.0759, 1, 0.33333334} {
+		want := domain.SearchCursor{Rank: rank, ID: cursorID}
+		got, err := decodeSearchToken(encodeSearchToken(want))
+		if err != nil {
+			t.Fatalf("decodeSearchToken(%v): %v", rank, err)
+		}
+		if *got != want {
+			t.Errorf("cursor = %+v, want %+v", *got, want)
+		}
+	}
+
+	if c, err := decodeSearchToken(""); c != nil || err != nil {
+		t.Errorf("empty token = %+v, %v; want nil, nil", c, err)
+	}
+}
+
+func TestDecodeSearchTokenRejectsForged(t *testing.T) {
+	tests := []struct {
+		name  string
+		token string
+	}{
+		{"not base64", "!!!"},
+		{"no separator", rawToken("0.5")},
+		{"bad rank", rawToken("high|" + cursorID)},
+		{"nan rank", rawToken("NaN|" + cursorID)},
+		{"inf rank", rawToken("+Inf|" + cursorID)},
+		{"id not uuid", rawToken("0.5|x")},
+		{"empty id", rawToken("0.5|")},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := decodeSearchToken(tt.token); !errors.Is(err, domain.ErrInvalidPageToken) {
+				t.Errorf("err = %v, want ErrInvalidPageToken", err)
+			}
+		})
+	}
+}
@@ -0,0 +1,109 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strings"
+	"unicode"
+
+	"user-service/internal/domain"
+)
+
+// PasswordPolicy — parolga qo'yiladigan talablar (config dagi PASSWORD_* dan to'ldiriladi)
+type PasswordPolicy struct {
+	MinLength     int
+	MaxLength     int
+	RequireUpper  bool
+	RequireLower  bool
+	RequireDigit  bool
+	RequireSymbol bool
+}
+
+// validatePassword — barcha buzilgan qoidalarni birdan qaytaradi (klient hammasini ko'rsata oladi).
+// personal — parol teng bo'lmasligi kerak bo'lgan qiymatlar (username, email).
+func (s *userService) validatePassword(ctx context.Context, password string, personal ...string) error {
+	violations := s.passwordRuleViolations(password)
+	violate := func(desc string) {
+		violations = append(violations, domain.FieldViolation{Field: "password", Description: desc})
+	}
+
+	for _, value := range personal {
+		if value == "" {
+			continue
+		}
+		local, _, _ := strings.Cut(value, "@")
+		if strings.EqualFold(password, value) || strings.EqualFold(password, local) {
+			violate("must not match your username or email")
+			break
+		}
+	}
+
+	if password != "" && s.breached != nil {
+		breached, err := s.breached.IsBreached(ctx, password)
+		if err != nil {
+			// Ro'yxat o'qilmasa parol bloklanmaydi
+			log.Println("failed to check breached passwords:", err)
+		}
+		if breached {
+			violate("appears in a known data breach, choose a different password")
+		}
+	}
+
+	if len(violations) > 0 {
+		return &domain.ValidationError{Violations: violations}
+	}
+	return nil
+}
+
+// passwordRuleViolations — faqat uzunlik va belgi turlari: holatsiz, DB va breached ro'yxatga murojaat qilmaydi
+func (s *userService) passwordRuleViolations(password string) []domain.FieldViolation {
+	p := s.cfg.PasswordPolicy
+	var violations []domain.FieldViolation
+	violate := func(desc string) {
+		violations = append(violations, domain.FieldViolation{Field: "password", Description: desc})
+	}
+
+	length := len([]rune(password))
+	if length < p.MinLength {
+		violate(fmt.Sprintf("must be at least %d characters long", p.MinLength))
+	}
+	if p.MaxLength > 0 && length > p.MaxLength {
+		violate(fmt.Sprintf("must be at most %d characters long", p.MaxLength))
+	}
+
+	var upper, lower, digit, symbol bool
+	for _, r := range password {
+		switch {
+		case unicode.IsUpper(r):
+			upper = true
+		case unicode.IsLower(r):
+			lower = true
+		case unicode.IsDigit(r):
+			digit = true
+		case !unicode.IsLetter(r) && !unicode.IsSpace(r):
+			symbol = true
+		}
+	}
+	if p.RequireUpper && !upper {
+		violate("must contain an uppercase letter")
+	}
+	if p.RequireLower && !lower {
+		violate("must contain a lowercase letter")
+	}
+	if p.RequireDigit && !digit {
+		violate("must contain a digit")
+	}
+	if p.RequireSymbol && !symbol {
+		violate("must contain a symbol")
+	}
+	return violations
+}
+
+// checkPasswordRules — passwordRuleViolations ValidationError ko'rinishida
+func (s *userService) checkPasswordRules(password string) error {
+	if violations := s.passwordRuleViolations(password); len(violations) > 0 {
+		return &domain.ValidationError{Violations: violations}
+	}
+	return nil
+}
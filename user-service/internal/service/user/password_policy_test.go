@@ -0,0 +1,81 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"testing"
+
+	"user-service/internal/domain"
+)
+
+// fakeBreached — ro'yxatdagi parollar "buzilgan"; err berilsa tekshiruv xato qaytaradi
+type fakeBreached struct {
+	passwords []string
+	err       error
+	calls     int
+}
+
+func (f *fakeBreached) IsBreached(ctx context.Context, password string) (bool, error) {
+	f.calls++
+	return slices.Contains(f.passwords, password), f.err
+}
+
+func passwordViolations(err error) []string {
+	var invalid *domain.ValidationError
+	if !errors.As(err, &invalid) {
+		return nil
+	}
+	var out []string
+	for _, v := range invalid.Violations {
+		out = append(out, v.Description)
+	}
+	return out
+}
+
+func TestValidatePassword(t *testing.T) {
+	strict := PasswordPolicy{MinLength: 8, MaxLength: 16, RequireUpper: true, RequireLower: true, RequireDigit: true, RequireSymbol: true}
+	tests := []struct {
+		name     string
+		policy   PasswordPolicy
+		password string
+		personal []string
+		breached *fakeBreached
+		want     []string
+	}{
+		{"valid", strict, "Str0ng!pass", nil, nil, nil},
+		{"too short", PasswordPolicy{MinLength: 8}, "short", nil, nil, []string{"must be at least 8 characters long"}},
+		{"length counts runes", PasswordPolicy{MinLength: 4}, "ёёёё", nil, nil, nil},
+		{"too long", PasswordPolicy{MaxLength: 4}, "toolong", nil, nil, []string{"must be at most 4 characters long"}},
+		{"no max length", PasswordPolicy{}, string(make([]byte, 1000)), nil, nil, nil},
+		{
+			"all classes missing reported together", strict, "        ", nil, nil,
+			[]string{
+				"must contain an uppercase letter", "must contain a lowercase letter",
+				"must contain a digit", "must contain a symbol",
+			},
+		},
+		{"matches username", PasswordPolicy{}, "ALI_VALI", []string{"ali_vali", "ali@example.com"}, nil, []string{"must not match your username or email"}},
+		{"matches email", PasswordPolicy{}, "ali@example.com", []string{"ali_vali", "ali@example.com"}, nil, []string{"must not match your username or email"}},
+		{"matches email local part", PasswordPolicy{}, "Ali", []string{"", "ali@example.com"}, nil, []string{"must not match your username or email"}},
+		{"contains username is fine", PasswordPolicy{}, "ali_vali-2024!", []string{"ali_vali"}, nil, nil},
+		{"breached", PasswordPolicy{}, "hunter2", nil, &fakeBreached{passwords: []string{"hunter2"}}, []string{"appears in a known data breach, choose a different password"}},
+		{"breach check error does not block", PasswordPolicy{}, "hunter2", nil, &fakeBreached{err: errors.New("io error")}, nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := &userService{cfg: Config{PasswordPolicy: tt.policy}}
+			if tt.breached != nil {
+				s.breached = tt.breached
+			}
+			err := s.validatePassword(context.Background(), tt.password, tt.personal...)
+			got := passwordViolations(err)
+			if err != nil && got == nil {
+				t.Fatalf("err = %v, want ValidationError", err)
+			}
+			if !slices.Equal(got, tt.want) {
+				t.Errorf("violations = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,311 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"sync"
+	"time"
+
+	"user-service/internal/domain"
+
+	"github.com/google/uuid"
+)
+
+// PresenceConfig — main.go da config dan to'ldiriladi
+type PresenceConfig struct {
+	TTL           time.Duration // shu vaqt ichida heartbeat kelmasa qurilma offline
+	SweepInterval time.Duration // muddati o'tgan qurilmalarni tekshirish oralig'i
+}
+
+const (
+	maxWatchedUsers      = 100
+	presenceSweepBatch   = 500
+	presenceWatchBuffer  = 64
+	presenceHeartbeatDiv = 3 // klient TTL ichida kamida 3 marta heartbeat yuboradi
+
+	// Pub/sub obunasi uzilsa qayta ulanish oralig'i (har urinishda ikki baravar)
+	presenceRetryMin = 500 * time.Millisecond
+	presenceRetryMax = 30 * time.Second
+)
+
+// presenceService — Redis pub/sub dan kelgan o'zgarishlarni shu replikadagi WatchPresence
+// oqimlariga tarqatadi. Har bir replika bitta obuna ochadi.
+type presenceService struct {
+	repo  domain.UserRepository
+	store domain.PresenceStore
+	cfg   PresenceConfig
+
+	mu       sync.RWMutex
+	watchers map[string]map[*presenceWatcher]struct{} // userID -> obunachilar
+}
+
+type presenceWatcher struct {
+	events  chan domain.Presence
+	lagging chan struct{} // yopilgan => bufer to'lgan, obunachi qayta ulanishi kerak
+	once    sync.Once
+}
+
+func NewPresenceService(repo domain.UserRepository, store domain.PresenceStore, cfg PresenceConfig) domain.PresenceService {
+	return &presenceService{
+		repo:     repo,
+		store:    store,
+		cfg:      cfg,
+		watchers: make(map[string]map[*presenceWatcher]struct{}),
+	}
+}
+
+// ================= HEARTBEAT =================
+func (s *presenceService) Heartbeat(ctx context.Context, userID, deviceID string, offline bool) (time.Duration, error) {
+	if deviceID == "" {
+		return 0, domain.ErrSessionNotFound
+	}
+	interval := s.cfg.TTL / presenceHeartbeatDiv
+
+	if offline {
+		wentOffline, err := s.store.Disconnect(ctx, userID, deviceID)
+		if err != nil {
+			return 0, err
+		}
+		if wentOffline {
+			s.markOffline(ctx, domain.DeviceExpiry{UserID: userID, DeviceID: deviceID, LastSeen: time.Now()})
+		}
+		return interval, nil
+	}
+
+	cameOnline, err := s.store.Heartbeat(ctx, userID, deviceID)
+	if err != nil {
+		return 0, err
+	}
+	if cameOnline {
+		if err := s.publish(ctx, userID); err != nil {
+			log.Printf("⚠️ Failed to publish presence for %s: %v", userID, err)
+		}
+	}
+	return interval, nil
+}
+
+func (s *presenceService) Refresh(ctx context.Context, userID string) error {
+	return s.publish(ctx, userID)
+}
+
+// markOffline — last seen Postgres ga yoziladi, keyin obunachilarga xabar beriladi
+func (s *presenceService) markOffline(ctx context.Context, e domain.DeviceExpiry) {
+	if err := s.repo.SetLastSeen(ctx, e.UserID, e.DeviceID, e.LastSeen); err != nil {
+		log.Printf("⚠️ Failed to save last seen for %s: %v", e.UserID, err)
+	}
+	if err := s.publish(ctx, e.UserID); err != nil {
+		log.Printf("⚠️ Failed to publish presence for %s: %v", e.UserID, err)
+	}
+}
+
+func (s *presenceService) publish(ctx context.Context, userID string) error {
+	snapshot, err := s.snapshot(ctx, []string{userID})
+	if err != nil {
+		return err
+	}
+	if len(snapshot) == 0 {
+		return nil // user o'chirilgan
+	}
+	return s.store.Publish(ctx, snapshot[0])
+}
+
+// snapshot — Redis dagi online holat + Postgres dagi last seen va maxfiylik sozlamasi
+func (s *presenceService) snapshot(ctx context.Context, userIDs []string) ([]domain.Presence, error) {
+	online, err := s.store.Online(ctx, userIDs)
+	if err != nil {
+		return nil, err
+	}
+	records, err := s.repo.GetPresenceRecords(ctx, userIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	presence := make([]domain.Presence, len(records))
+	for i, rec := range records {
+		presence[i] = domain.NewPresence(rec.UserID, online[rec.UserID], rec.LastSeenAt, rec.HideLastSeen)
+	}
+	return presence, nil
+}
+
+// ================= WATCH =================
+func (s *presenceService) Watch(ctx context.Context, userIDs []string, send func(domain.Presence) error) error {
+	ids, err := watchedUserIDs(userIDs)
+	if err != nil {
+		return err
+	}
+
+	// Obuna snapshotdan oldin: oradagi o'zgarishlar buferda kutadi
+	w := &presenceWatcher{
+		events:  make(chan domain.Presence, presenceWatchBuffer),
+		lagging: make(chan struct{}),
+	}
+	s.subscribe(w, ids)
+	defer s.unsubscribe(w, ids)
+
+	snapshot, err := s.snapshot(ctx, ids)
+	if err != nil {
+		return err
+	}
+	for _, p := range snapshot {
+		if err := send(p); err != nil {
+			return err
+		}
+	}
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-w.lagging:
+			return domain.ErrPresenceWatchLagging
+		case p := <-w.events:
+			if err := send(p); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+// watchedUserIDs — takrorlar va noto'g'ri UUIDlar olib tashlanadi; hech biri qolmasa validatsiya xatosi
+func watchedUserIDs(userIDs []string) ([]string, error) {
+	if len(userIDs) == 0 || len(userIDs) > maxWatchedUsers {
+		return nil, &domain.ValidationError{Violations: []domain.FieldViolation{{
+			Field:       "user_ids",
+			Description: fmt.Sprintf("must contain between 1 and %d ids", maxWatchedUsers),
+		}}}
+	}
+	seen := make(map[string]bool, len(userIDs))
+	ids := make([]string, 0, len(userIDs))
+	for _, id := range userIDs {
+		if _, err := uuid.Parse(id); err != nil || seen[id] {
+			continue
+		}
+		seen[id] = true
+		ids = append(ids, id)
+	}
+	if len(ids) == 0 {
+		return nil, &domain.ValidationError{Violations: []domain.FieldViolation{{
+			Field:       "user_ids",
+			Description: "must contain at least one valid user id",
+		}}}
+	}
+	return ids, nil
+}
+
+func (s *presenceService) subscribe(w *presenceWatcher, userIDs []string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for _, id := range userIDs {
+		if s.watchers[id] == nil {
+			s.watchers[id] = make(map[*presenceWatcher]struct{})
+		}
+		s.watchers[id][w] = struct{}{}
+	}
+}
+
+func (s *presenceService) unsubscribe(w *presenceWatcher, userIDs []string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for _, id := range userIDs {
+		delete(s.watchers[id], w)
+		if len(s.watchers[id]) == 0 {
+			delete(s.watchers, id)
+		}
+	}
+}
+
+// dispatch — sekin obunachi boshqalarni kutdirmaydi: bufer to'lsa u uziladi
+func (s *presenceService) dispatch(p domain.Presence) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	for w := range s.watchers[p.UserID] {
+		select {
+		case w.events <- p:
+		default:
+			w.once.Do(func() { close(w.lagging) })
+		}
+	}
+}
+
+// dropWatchers — obuna uzilgan paytdagi o'zgarishlar yo'qolgan: barcha obunachilar uziladi
+// va qayta ulanib yangi snapshot oladi
+func (s *presenceService) dropWatchers() {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	for _, watchers := range s.watchers {
+		for w := range watchers {
+			w.once.Do(func() { close(w.lagging) })
+		}
+	}
+}
+
+// ================= BACKGROUND =================
+// Run — Redis ishlamay qolsa servis to'xtamaydi: obuna backoff bilan qayta ochiladi
+func (s *presenceService) Run(ctx context.Context) error {
+	go s.sweepLoop(ctx)
+
+	backoff := presenceRetryMin
+	for {
+		events, err := s.store.Subscribe(ctx)
+		if err != nil {
+			log.Printf("⚠️ Presence subscription failed, retrying in %s: %v", backoff, err)
+		} else {
+			backoff = presenceRetryMin
+			s.dropWatchers()
+			s.consume(ctx, events)
+			if ctx.Err() == nil {
+				log.Printf("⚠️ Presence subscription closed, reconnecting in %s", backoff)
+			}
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-time.After(backoff):
+		}
+		backoff = min(backoff*2, presenceRetryMax)
+	}
+}
+
+// consume — kanal yopilguncha yoki ctx tugaguncha eventlarni tarqatadi
+func (s *presenceService) consume(ctx context.Context, events <-chan domain.Presence) {
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case p, ok := <-events:
+			if !ok {
+				return
+			}
+			s.dispatch(p)
+		}
+	}
+}
+
+// sweepLoop — heartbeat TTL i o'tgan qurilmalar; ExpireDevices atomar bo'lgani uchun
+// bir nechta replikada parallel ishlashi xavfsiz
+func (s *presenceService) sweepLoop(ctx context.Context) {
+	ticker := time.NewTicker(s.cfg.SweepInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			for {
+				expired, err := s.store.ExpireDevices(ctx, presenceSweepBatch)
+				if err != nil {
+					log.Printf("⚠️ Presence sweep failed: %v", err)
+					break
+				}
+				for _, e := range expired {
+					s.markOffline(ctx, e)
+				}
+				if len(expired) < presenceSweepBatch {
+					break
+				}
+			}
+		}
+	}
+}
@@ -0,0 +1,126 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"sync"
+	"testing"
+	"time"
+
+	"user-service/internal/domain"
+)
+
+func TestWatchedUserIDs(t *testing.T) {
+	const a = "55555555-5555-5555-5555-555555555555"
+	const b = "66666666-6666-6666-6666-666666666666"
+	tests := []struct {
+		name    string
+		in      []string
+		want    []string
+		wantErr bool
+	}{
+		{"valid ids", []string{a, b}, []string{a, b}, false},
+		{"duplicates and garbage dropped", []string{a, "nope", a, b}, []string{a, b}, false},
+		{"empty", nil, nil, true},
+		{"only invalid ids", []string{"nope", "", "123"}, nil, true},
+		{"too many", make([]string, maxWatchedUsers+1), nil, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := watchedUserIDs(tt.in)
+			if tt.wantErr {
+				var verr *domain.ValidationError
+				if !errors.As(err, &verr) {
+					t.Fatalf("err = %v, want ValidationError", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("watchedUserIDs: %v", err)
+			}
+			if !slices.Equal(got, tt.want) {
+				t.Errorf("ids = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+// flakyPresenceStore — birinchi Subscribe xato beradi, keyingilari test boshqaradigan kanal qaytaradi
+type flakyPresenceStore struct {
+	domain.PresenceStore
+
+	mu       sync.Mutex
+	attempts int
+	subs     chan chan domain.Presence
+}
+
+func (f *flakyPresenceStore) Subscribe(ctx context.Context) (<-chan domain.Presence, error) {
+	f.mu.Lock()
+	f.attempts++
+	first := f.attempts == 1
+	f.mu.Unlock()
+	if first {
+		return nil, errors.New("redis: connection refused")
+	}
+	ch := make(chan domain.Presence)
+	f.subs <- ch
+	return ch, nil
+}
+
+func TestPresenceRunResubscribes(t *testing.T) {
+	const userID = "77777777-7777-7777-7777-777777777777"
+	store := &flakyPresenceStore{subs: make(chan chan domain.Presence)}
+	s := NewPresenceService(nil, store, PresenceConfig{TTL: time.Minute, SweepInterval: time.Hour}).(*presenceService)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error, 1)
+	go func() { done <- s.Run(ctx) }()
+
+	nextSub := func() chan domain.Presence {
+		t.Helper()
+		select {
+		case ch := <-store.subs:
+			return ch
+		case err := <-done:
+			t.Fatalf("Run returned %v, want retry", err)
+		case <-time.After(5 * time.Second):
+			t.Fatal("no resubscribe attempt")
+		}
+		return nil
+	}
+
+	// Birinchi urinish xato — Run to'xtamay qayta obuna bo'ladi
+	sub := nextSub()
+	w := &presenceWatcher{events: make(chan domain.Presence, 1), lagging: make(chan struct{})}
+	s.subscribe(w, []string{userID})
+
+	sub <- domain.Presence{UserID: userID, Online: true}
+	select {
+	case p := <-w.events:
+		if !p.Online {
+			t.Errorf("presence = %+v, want online", p)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("event not dispatched after resubscribe")
+	}
+
+	// Obuna uzildi — qayta ulangach obunachi yangi snapshot uchun uziladi
+	close(sub)
+	nextSub()
+	select {
+	case <-w.lagging:
+	case <-time.After(5 * time.Second):
+		t.Fatal("watcher not dropped after subscription loss")
+	}
+
+	cancel()
+	select {
+	case err := <-done:
+		if err != nil {
+			t.Errorf("Run = %v, want nil on shutdown", err)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("Run did not stop on ctx cancel")
+	}
+}
@@ -0,0 +1,112 @@
+package service
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"user-service/internal/domain"
+)
+
+// ================= REAUTHENTICATE =================
+// Parol yoki TOTP (tiklash kodi) tasdiqlansa sessiyaning auth_time i yangilanadi.
+// Noto'g'ri urinishlar login bilan bir xil lockout siyosatiga bo'ysunadi (o'g'irlangan token bilan
+// parolni tanlab ko'rishning oldini olish uchun).
+func (s *userService) Reauthenticate(ctx context.Context, userID, sessionID, password, totpCode string) (time.Time, time.Time, error) {
+	if (password == "") == (totpCode == "") {
+		return time.Time{}, time.Time{}, &domain.ValidationError{Violations: []domain.FieldViolation{
+			{Field: "method", Description: "exactly one of password or totp_code is required"},
+		}}
+	}
+
+	session, err := s.repo.GetSessionByID(ctx, sessionID)
+	if err != nil {
+		return time.Time{}, time.Time{}, err
+	}
+	if session == nil || session.UserID != userID {
+		return time.Time{}, time.Time{}, domain.ErrSessionNotFound
+	}
+
+	lockKey := "reauth:" + userID
+	locked, err := s.attempts.LockedFor(ctx, lockKey)
+	if err != nil {
+		log.Println("failed to check reauth lock:", err)
+	}
+	if locked > 0 {
+		return time.Time{}, time.Time{}, &domain.AccountLockedError{RetryAfter: locked}
+	}
+
+	user, err := s.repo.GetByID(ctx, userID)
+	if err != nil {
+		return time.Time{}, time.Time{}, err
+	}
+	if user == nil {
+		return time.Time{}, time.Time{}, domain.ErrUserNotFound
+	}
+
+	if password != "" {
+		if user.PasswordHash == "" {
+			return time.Time{}, time.Time{}, domain.ErrPasswordNotSet
+		}
+		ok, needsRehash, err := s.hasher.Verify(password, user.PasswordHash)
+		if err != nil {
+			log.Println("failed to verify password hash:", err)
+		}
+		if !ok {
+			return time.Time{}, time.Time{}, s.reauthFailed(ctx, lockKey, domain.ErrInvalidPassword)
+		}
+		if needsRehash {
+			s.rehashPassword(ctx, user, password)
+		}
+	} else {
+		if user.TOTPEnabledAt == nil {
+			return time.Time{}, time.Time{}, domain.ErrTOTPNotEnabled
+		}
+		if err := s.verifySecondFactor(ctx, user, totpCode); err != nil {
+			return time.Time{}, time.Time{}, s.reauthFailed(ctx, lockKey, err)
+		}
+	}
+
+	if err := s.attempts.Reset(ctx, lockKey); err != nil {
+		log.Println("failed to reset reauth attempts:", err)
+	}
+
+	now := time.Now()
+	if err := s.repo.SetSessionAuthTime(ctx, session.ID, now); err != nil {
+		return time.Time{}, time.Time{}, err
+	}
+	var validUntil time.Time
+	if s.cfg.ReauthMaxAge > 0 {
+		validUntil = now.Add(s.cfg.ReauthMaxAge)
+	}
+	return now, validUntil, nil
+}
+
+// reauthFailed — muvaffaqiyatsiz urinishni hisoblaydi; chegaradan oshsa AccountLockedError
+func (s *userService) reauthFailed(ctx context.Context, lockKey string, cause error) error {
+	_, lockout, err := s.attempts.RecordFailure(ctx, lockKey, s.cfg.EmailLockout)
+	if err != nil {
+		log.Println("failed to record reauth failure:", err)
+		return cause
+	}
+	if lockout > 0 {
+		return &domain.AccountLockedError{RetryAfter: lockout}
+	}
+	return cause
+}
+
+// requireRecentAuth — nozik amallardan oldin: sessiyada so'nggi ReauthMaxAge ichida
+// login yoki Reauthenticate bo'lgan bo'lishi kerak
+func (s *userService) requireRecentAuth(ctx context.Context, userID, sessionID string) error {
+	if s.cfg.ReauthMaxAge <= 0 {
+		return nil
+	}
+	session, err := s.repo.GetSessionByID(ctx, sessionID)
+	if err != nil {
+		return err
+	}
+	if session == nil || session.UserID != userID || time.Since(session.AuthTime) > s.cfg.ReauthMaxAge {
+		return &domain.ReauthenticationRequiredError{MaxAge: s.cfg.ReauthMaxAge}
+	}
+	return nil
+}
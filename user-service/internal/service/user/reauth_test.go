@@ -0,0 +1,113 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"user-service/internal/domain"
+	"user-service/internal/utils"
+)
+
+const reauthUserID = "99999999-9999-9999-9999-999999999999"
+
+func TestRequireRecentAuth(t *testing.T) {
+	const maxAge = 10 * time.Minute
+	tests := []struct {
+		name      string
+		maxAge    time.Duration
+		sessionID string
+		authTime  time.Time
+		owner     string
+		wantErr   bool
+	}{
+		{"fresh auth_time", maxAge, "s1", time.Now().Add(-time.Minute), reauthUserID, false},
+		{"stale auth_time", maxAge, "s1", time.Now().Add(-maxAge - time.Second), reauthUserID, true},
+		{"other user's session", maxAge, "s1", time.Now(), memberID, true},
+		{"unknown session", maxAge, "missing", time.Now(), reauthUserID, true},
+		{"check disabled", 0, "s1", time.Now().Add(-24 * time.Hour), reauthUserID, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := newFakeRepo(&domain.User{ID: reauthUserID})
+			repo.sessions["s1"] = &domain.Session{ID: "s1", UserID: tt.owner, AuthTime: tt.authTime}
+			s, _ := newTestService(repo)
+			s.cfg.ReauthMaxAge = tt.maxAge
+
+			err := s.requireRecentAuth(context.Background(), reauthUserID, tt.sessionID)
+
+			if !tt.wantErr {
+				if err != nil {
+					t.Fatalf("requireRecentAuth: %v", err)
+				}
+				return
+			}
+			var reauth *domain.ReauthenticationRequiredError
+			if !errors.As(err, &reauth) || reauth.MaxAge != tt.maxAge {
+				t.Errorf("err = %v, want ReauthenticationRequiredError{MaxAge: %s}", err, tt.maxAge)
+			}
+		})
+	}
+}
+
+func TestReauthenticateWrongPasswordLocksOut(t *testing.T) {
+	const maxFailures = 3
+	hasher := utils.NewPasswordHasher(utils.Argon2Params{Memory: 1024, Iterations: 1, Parallelism: 1, SaltLength: 16, KeyLength: 32})
+	hash, err := hasher.Hash("correct horse battery staple")
+	if err != nil {
+		t.Fatal(err)
+	}
+	staleAuth := time.Now().Add(-time.Hour)
+	repo := newFakeRepo(&domain.User{ID: reauthUserID, PasswordHash: hash})
+	repo.sessions["s1"] = &domain.Session{ID: "s1", UserID: reauthUserID, AuthTime: staleAuth}
+	s, _ := newTestService(repo)
+	attempts := newFakeAttempts()
+	s.attempts = attempts
+	s.hasher = hasher
+	s.cfg.ReauthMaxAge = 10 * time.Minute
+	s.cfg.EmailLockout = domain.LockoutPolicy{MaxFailures: maxFailures, BaseLockout: time.Minute, MaxLockout: time.Hour}
+	ctx := context.Background()
+	lockKey := "reauth:" + reauthUserID
+
+	for i := 1; i < maxFailures; i++ {
+		_, _, err := s.Reauthenticate(ctx, reauthUserID, "s1", "wrong password", "")
+		if !errors.Is(err, domain.ErrInvalidPassword) {
+			t.Fatalf("attempt %d: err = %v, want ErrInvalidPassword", i, err)
+		}
+		if got := attempts.failures[lockKey]; got != i {
+			t.Errorf("attempt %d: %s failures = %d, want %d", i, lockKey, got, i)
+		}
+	}
+
+	var locked *domain.AccountLockedError
+	if _, _, err := s.Reauthenticate(ctx, reauthUserID, "s1", "wrong password", ""); !errors.As(err, &locked) {
+		t.Fatalf("attempt %d: err = %v, want AccountLockedError", maxFailures, err)
+	}
+	// Blok davomida to'g'ri parol ham qabul qilinmaydi
+	if _, _, err := s.Reauthenticate(ctx, reauthUserID, "s1", "correct horse battery staple", ""); !errors.As(err, &locked) {
+		t.Errorf("correct password while locked: err = %v, want AccountLockedError", err)
+	}
+	if !repo.sessions["s1"].AuthTime.Equal(staleAuth) {
+		t.Error("auth_time refreshed by a failed reauthentication")
+	}
+	if err := s.requireRecentAuth(ctx, reauthUserID, "s1"); err == nil {
+		t.Error("stale session passed requireRecentAuth after failed reauthentication")
+	}
+
+	// Blok tugagach to'g'ri parol hisoblagichni tozalaydi va auth_time ni yangilaydi
+	delete(attempts.locked, lockKey)
+	authTime, validUntil, err := s.Reauthenticate(ctx, reauthUserID, "s1", "correct horse battery staple", "")
+	if err != nil {
+		t.Fatalf("Reauthenticate: %v", err)
+	}
+	if got := validUntil.Sub(authTime); got != s.cfg.ReauthMaxAge {
+		t.Errorf("validUntil - authTime = %s, want %s", got, s.cfg.ReauthMaxAge)
+	}
+	if _, ok := attempts.failures[lockKey]; ok {
+		t.Error("failure counter not reset after successful reauthentication")
+	}
+	if err := s.requireRecentAuth(ctx, reauthUserID, "s1"); err != nil {
+		t.Errorf("requireRecentAuth after reauthentication: %v", err)
+	}
+}
@@ -0,0 +1,81 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+
+	"user-service/internal/domain"
+	"user-service/internal/utils"
+)
+
+// recordingGeo — qaysi IPlar so'ralganini eslab qoladi
+type recordingGeo struct {
+	mu  sync.Mutex
+	ips []string
+	loc *domain.GeoLocation
+	err error
+}
+
+func (g *recordingGeo) Locate(ctx context.Context, ip string) (*domain.GeoLocation, error) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	g.ips = append(g.ips, ip)
+	return g.loc, g.err
+}
+
+func (g *recordingGeo) lookups() []string {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	return append([]string(nil), g.ips...)
+}
+
+func TestRegisterResolvesLocationWithInjectedGeo(t *testing.T) {
+	tashkent := &domain.GeoLocation{Country: "Uzbekistan", CountryCode: "UZ", City: "Tashkent"}
+	tests := []struct {
+		name         string
+		ip           *string
+		geo          *recordingGeo
+		wantLocation string
+		wantLookup   bool
+	}{
+		{"resolved", strPtr("203.0.113.9"), &recordingGeo{loc: tashkent}, "Uzbekistan, Tashkent", true},
+		{"unknown ip", strPtr("10.0.0.1"), &recordingGeo{}, "", true},
+		{"geo error", strPtr("203.0.113.9"), &recordingGeo{err: errors.New("geoip: timeout")}, "", true},
+		{"no client ip", nil, &recordingGeo{loc: tashkent}, "", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := newFakeRepo()
+			s, _ := newTestService(repo)
+			s.geo = tt.geo
+			s.hasher = utils.NewPasswordHasher(utils.Argon2Params{Memory: 1024, Iterations: 1, Parallelism: 1, SaltLength: 16, KeyLength: 32})
+
+			result, err := s.Register(context.Background(), domain.RegisterDTO{
+				Username:     "vali",
+				Email:        "vali@example.com",
+				Password:     "Sup3r-Secret-Passphrase",
+				DeviceID:     "device-1",
+				RegisteredIP: tt.ip,
+			})
+			if err != nil {
+				t.Fatalf("Register: %v", err)
+			}
+
+			stored, _ := repo.GetByID(context.Background(), result.User.ID)
+			if got := getStr(stored.Location); got != tt.wantLocation {
+				t.Errorf("location = %q, want %q", got, tt.wantLocation)
+			}
+			if !tt.wantLookup {
+				if lookups := tt.geo.lookups(); len(lookups) != 0 {
+					t.Errorf("geo lookups = %v without a client ip", lookups)
+				}
+				return
+			}
+			if lookups := tt.geo.lookups(); len(lookups) == 0 || lookups[0] != *tt.ip {
+				t.Errorf("geo lookups = %v, want first %q", lookups, *tt.ip)
+			}
+		})
+	}
+}
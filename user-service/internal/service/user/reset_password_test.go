@@ -0,0 +1,80 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"user-service/internal/domain"
+	"user-service/internal/utils"
+)
+
+func TestResetPasswordValidation(t *testing.T) {
+	const token = "reset-token"
+	tests := []struct {
+		name          string
+		password      string
+		wantViolation string
+		wantConsumed  bool
+	}{
+		{"valid password", "Sup3r-Secret-Passphrase", "", true},
+		{"too short keeps token", "short", "must be at least 8 characters long", false},
+		{"matches username", "valiusername", "must not match your username or email", true},
+		{"matches email local part", "VALI.RESET", "must not match your username or email", true},
+		{"breached", "Breached-Passw0rd", "appears in a known data breach, choose a different password", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			user := &domain.User{
+				ID:           "88888888-8888-8888-8888-888888888888",
+				Username:     strPtr("valiusername"),
+				Email:        strPtr("vali.reset@example.com"),
+				PasswordHash: "old-hash",
+				Status:       domain.UserStatusActive,
+			}
+			repo := newFakeRepo(user)
+			repo.resetTokens[utils.HashToken(token)] = *user.Email
+			breached := &fakeBreached{passwords: []string{"Breached-Passw0rd"}}
+			s, _ := newTestService(repo)
+			s.cfg.PasswordPolicy = PasswordPolicy{MinLength: 8}
+			s.breached = breached
+			s.hasher = utils.NewPasswordHasher(utils.Argon2Params{Memory: 1024, Iterations: 1, Parallelism: 1, SaltLength: 16, KeyLength: 32})
+
+			err := s.ResetPassword(context.Background(), token, tt.password)
+
+			_, stillThere := repo.resetTokens[utils.HashToken(token)]
+			if consumed := !stillThere; consumed != tt.wantConsumed {
+				t.Errorf("token consumed = %v, want %v", consumed, tt.wantConsumed)
+			}
+			// Breached ro'yxat faqat token iste'mol qilingandan keyin va bir marta o'qiladi
+			wantCalls := 0
+			if tt.wantConsumed {
+				wantCalls = 1
+			}
+			if breached.calls != wantCalls {
+				t.Errorf("breached corpus read %d times, want %d", breached.calls, wantCalls)
+			}
+
+			stored, _ := repo.GetByID(context.Background(), user.ID)
+			if tt.wantViolation == "" {
+				if err != nil {
+					t.Fatalf("ResetPassword: %v", err)
+				}
+				if stored.PasswordHash == "old-hash" {
+					t.Error("password hash not updated")
+				}
+				return
+			}
+			var invalid *domain.ValidationError
+			if !errors.As(err, &invalid) {
+				t.Fatalf("err = %v, want ValidationError", err)
+			}
+			if got := passwordViolations(err); len(got) != 1 || got[0] != tt.wantViolation {
+				t.Errorf("violations = %q, want [%q]", got, tt.wantViolation)
+			}
+			if stored.PasswordHash != "old-hash" {
+				t.Error("password changed despite validation error")
+			}
+		})
+	}
+}
@@ -4,37 +4,101 @@ import (
 	"context"
 	"encoding/json"
 	"errors"
+	"fmt"
 	"log"
+	"net"
+	"net/url"
+	"strconv"
 	"strings"
 	"time"
 	"user-service/internal/domain"
 	"user-service/internal/event/kafka"
+	"user-service/internal/utils"
 
-	"golang.org/x/crypto/bcrypt"
+	"github.com/google/uuid"
 )
 
+// eventProducer — *kafka.KafkaProducer (testlarda xotiradagi yozuvchi bilan almashtiriladi)
+type eventProducer interface {
+	Publish(ctx context.Context, value []byte) error
+}
+
 type userService struct {
 	repo          domain.UserRepository
 	tokenProvider domain.TokenProvider
-	k             kafka.KafkaProducer
+	k             eventProducer
+	limiter       domain.RateLimiter
+	loginCodes    domain.LoginCodeStore
+	identities    domain.IdentityProvider
+	attempts      domain.LoginAttemptTracker
+	hasher        domain.PasswordHasher
+	breached      domain.BreachedPasswordChecker
+	geo           domain.GeoLocator
+	profiles      domain.ProfileCache
+	presence      domain.PresenceService
+	statuses      domain.AccountStatusCache
+	cfg           Config
 }
 
-func NewUserService(repo domain.UserRepository, tokenProvider domain.TokenProvider, kafka *kafka.KafkaProducer) domain.UserService {
+// Deps — userService bog'liqliklari (main.go da to'ldiriladi). Breached nil bo'lsa
+// sizib chiqqan parollar tekshiruvi o'chiq; qolganlari ishlatiladigan yo'llarda majburiy.
+type Deps struct {
+	Repo       domain.UserRepository
+	Tokens     domain.TokenProvider
+	Kafka      *kafka.KafkaProducer
+	Limiter    domain.RateLimiter
+	LoginCodes domain.LoginCodeStore
+	Identities domain.IdentityProvider
+	Attempts   domain.LoginAttemptTracker
+	Hasher     domain.PasswordHasher
+	Breached   domain.BreachedPasswordChecker
+	Geo        domain.GeoLocator
+	Profiles   domain.ProfileCache
+	Presence   domain.PresenceService
+	Statuses   domain.AccountStatusCache
+}
+
+func NewUserService(deps Deps, cfg Config) domain.UserService {
 	return &userService{
-		repo:          repo,
-		tokenProvider: tokenProvider,
-		k:             *kafka,
+		repo:          deps.Repo,
+		tokenProvider: deps.Tokens,
+		k:             deps.Kafka,
+		limiter:       deps.Limiter,
+		loginCodes:    deps.LoginCodes,
+		identities:    deps.Identities,
+		attempts:      deps.Attempts,
+		hasher:        deps.Hasher,
+		breached:      deps.Breached,
+		geo:           deps.Geo,
+		profiles:      deps.Profiles,
+		presence:      deps.Presence,
+		statuses:      deps.Statuses,
+		cfg:           cfg,
 	}
 }
 
 // ================= REGISTER =================
 func (s *userService) Register(ctx context.Context, req domain.RegisterDTO) (*domain.AuthResult, error) {
 	email := strings.ToLower(strings.TrimSpace(req.Email))
+	req.Username = strings.TrimSpace(req.Username)
 	if existing, _ := s.repo.GetByEmail(ctx, email); existing != nil {
-		return nil, errors.New("email already registered")
+		return nil, domain.ErrEmailTaken
 	}
 
-	hashedPassword, err := bcrypt.GenerateFromPassword([]byte(req.Password), bcrypt.DefaultCost)
+	// Username va parol xatolari bitta javobda
+	violations := s.usernameViolations(req.Username)
+	if err := s.validatePassword(ctx, req.Password, req.Username, email); err != nil {
+		var invalid *domain.ValidationError
+		if !errors.As(err, &invalid) {
+			return nil, err
+		}
+		violations = append(violations, invalid.Violations...)
+	}
+	if len(violations) > 0 {
+		return nil, &domain.ValidationError{Violations: violations}
+	}
+
+	hashedPassword, err := s.hasher.Hash(req.Password)
 	if err != nil {
 		return nil, errors.New("failed to hash password")
 	}
@@ -42,7 +106,7 @@ func (s *userService) Register(ctx context.Context, req domain.RegisterDTO) (*do
 	user := &domain.User{
 		Username:     &req.Username,
 		Email:        &email,
-		PasswordHash: string(hashedPassword),
+		PasswordHash: hashedPassword,
 		FullName:     req.FullName,
 		AvatarURL:    req.AvatarURL,
 		Language:     req.Language,
@@ -50,7 +114,7 @@ func (s *userService) Register(ctx context.Context, req domain.RegisterDTO) (*do
 		DeviceID:     req.DeviceID,
 		RegisteredIP: req.RegisteredIP,
 		UserAgent:    req.UserAgent,
-		Location:     req.Location,
+		Location:     s.registrationLocation(ctx, req.RegisteredIP),
 		RegisteredAt: time.Now(),
 		UpdatedAt:    time.Now(),
 	}
@@ -59,49 +123,223 @@ func (s *userService) Register(ctx context.Context, req domain.RegisterDTO) (*do
 		return nil, err
 	}
 
-	event := map[string]string{
+	s.publishEvent(ctx, map[string]string{
 		"event":    "UserRegistered",
 		"user_id":  user.ID,
 		"email":    *user.Email,
 		"username": *user.Username,
+	})
+	if err := s.sendVerificationEmail(ctx, user.ID, email); err != nil {
+		log.Println("failed to send verification email:", err)
+	}
+
+	var ip string
+	if req.RegisteredIP != nil {
+		ip = *req.RegisteredIP
+	}
+	result, err := s.startSession(ctx, user, req.Platform, req.DeviceID, ip, req.UserAgent)
+	if err != nil {
+		return nil, err
+	}
+	// Registratsiya qurilmasi login tarixining boshlang'ich nuqtasi (keyingi loginda "yangi qurilma" emas)
+	s.traceLogin(ctx, "register", email, req.Platform, req.DeviceID, ip, req.UserAgent).finish(result, nil)
+	return result, nil
+}
+
+// ================= LOGIN =================
+func (s *userService) Login(ctx context.Context, req domain.LoginDTO) (result *domain.AuthResult, err error) {
+	email := strings.ToLower(strings.TrimSpace(req.Email))
+	trace := s.traceLogin(ctx, "password", email, req.Platform, req.DeviceID, req.IPAddress, req.UserAgent)
+	defer func() { trace.finish(result, err) }()
+
+	if err := s.checkLoginLock(ctx, email, req.IPAddress); err != nil {
+		return nil, err
+	}
+
+	user, err := s.repo.GetByEmail(ctx, email)
+	if err != nil || user == nil {
+		return nil, s.loginFailed(ctx, email, req.IPAddress)
+	}
+	trace.user = user
+
+	ok, needsRehash, err := s.hasher.Verify(req.Password, user.PasswordHash)
+	if err != nil {
+		log.Println("failed to verify password hash:", err)
+	}
+	if !ok {
+		return nil, s.loginFailed(ctx, email, req.IPAddress)
+	}
+	if needsRehash {
+		s.rehashPassword(ctx, user, req.Password)
+	}
+
+	if err := s.attempts.Reset(ctx, "email:"+email); err != nil {
+		log.Println("failed to reset login attempts:", err)
+	}
+	return s.completeLogin(ctx, user, req.Platform, req.DeviceID, req.IPAddress, req.UserAgent)
+}
+
+// rehashPassword — eski (bcrypt yoki eski parametrli) hashni joriy sozlamalar bilan yangilaydi.
+// Xatolik loginni to'xtatmaydi: keyingi muvaffaqiyatli loginda yana urinib ko'riladi.
+func (s *userService) rehashPassword(ctx context.Context, user *domain.User, password string) {
+	newHash, err := s.hasher.Hash(password)
+	if err != nil {
+		log.Println("failed to rehash password:", err)
+		return
 	}
-	if eventBytes, _ := json.Marshal(event); true {
-		if err := s.k.Publish(ctx, eventBytes); err != nil {
-			log.Println("Kafka publish error:", err)
+	if err := s.repo.RehashPassword(ctx, user.ID, user.PasswordHash, newHash); err != nil {
+		log.Println("failed to store rehashed password:", err)
+		return
+	}
+	user.PasswordHash = newHash
+}
+
+// checkLoginLock — email yoki IP bloklangan bo'lsa AccountLockedError (qolgan vaqtlarning kattasi)
+func (s *userService) checkLoginLock(ctx context.Context, email, ip string) error {
+	keys := []string{"email:" + email}
+	if ip != "" {
+		keys = append(keys, ipLockoutKey(ip))
+	}
+
+	var retryAfter time.Duration
+	for _, key := range keys {
+		locked, err := s.attempts.LockedFor(ctx, key)
+		if err != nil {
+			// Redis ishlamasa login bloklanmaydi
+			log.Println("failed to check login lock:", err)
+			continue
+		}
+		retryAfter = max(retryAfter, locked)
+	}
+	if retryAfter > 0 {
+		return &domain.AccountLockedError{RetryAfter: retryAfter}
+	}
+	return nil
+}
+
+// ipLockoutKey — ip handlerda ishonchli proxy ro'yxati bo'yicha aniqlangan (utils.GetIPFromCtx),
+// klient yuborgan x-forwarded-for emas. IPv6 klientga odatda butun /64 tarmoq beriladi —
+// manzilni almashtirib blokdan qochmaslik uchun hisoblagich /64 bo'yicha yuritiladi.
+func ipLockoutKey(ip string) string {
+	addr := net.ParseIP(ip)
+	if addr == nil {
+		return "ip:" + ip
+	}
+	if addr.To4() != nil {
+		return "ip:" + addr.To4().String()
+	}
+	network := net.IPNet{IP: addr.Mask(net.CIDRMask(64, 128)), Mask: net.CIDRMask(64, 128)}
+	return "ip:" + network.String()
+}
+
+// loginFailed — email va IP hisoblagichlarini oshiradi. User mavjud-mavjud emasligidan qat'i nazar
+// bir xil ishlaydi, shuning uchun bloklash orqali emaillarni aniqlab bo'lmaydi.
+func (s *userService) loginFailed(ctx context.Context, email, ip string) error {
+	type target struct {
+		key, kind string
+		policy    domain.LockoutPolicy
+	}
+	targets := []target{{"email:" + email, "email", s.cfg.EmailLockout}}
+	if ip != "" {
+		targets = append(targets, target{ipLockoutKey(ip), "ip", s.cfg.IPLockout})
+	}
+
+	var lockedFor time.Duration
+	for _, t := range targets {
+		failures, lockout, err := s.attempts.RecordFailure(ctx, t.key, t.policy)
+		if err != nil {
+			log.Println("failed to record login failure:", err)
+			continue
+		}
+		// Hodisa faqat chegara kesib o'tilganda — har bir keyingi urinishda emas
+		if failures == t.policy.MaxFailures {
+			s.publishEvent(ctx, map[string]string{
+				"event":      "SuspiciousLoginAttempts",
+				"scope":      t.kind,
+				"email":      email,
+				"ip_address": ip,
+				"failures":   strconv.Itoa(failures),
+				"locked_for": lockout.String(),
+			})
 		}
+		lockedFor = max(lockedFor, lockout)
 	}
 
-	access, refresh, err := s.tokenProvider.GenerateTokens(user.ID)
+	if lockedFor > 0 {
+		return &domain.AccountLockedError{RetryAfter: lockedFor}
+	}
+	return errors.New("invalid email or password")
+}
+
+// registrationLocation — users.location uchun IP bo'yicha joylashuv; aniqlanmasa nil
+// (registratsiya GeoIP xatosi tufayli to'xtamaydi)
+func (s *userService) registrationLocation(ctx context.Context, ip *string) *string {
+	if ip == nil || *ip == "" || s.geo == nil {
+		return nil
+	}
+	loc, err := s.geo.Locate(ctx, *ip)
 	if err != nil {
-		return nil, errors.New("failed to generate tokens")
+		log.Println("failed to resolve registration location:", err)
+		return nil
 	}
+	if loc == nil || loc.String() == "" {
+		return nil
+	}
+	location := loc.String()
+	return &location
+}
 
-	return &domain.AuthResult{
-		AccessToken:  access,
-		RefreshToken: refresh,
-		User:         user,
-	}, nil
+// completeLogin — birinchi faktor (parol, email kodi) tasdiqlangandan keyingi umumiy qadam:
+// 2FA yoqilgan bo'lsa challenge, aks holda sessiya va tokenlar qaytaradi
+func (s *userService) completeLogin(ctx context.Context, user *domain.User, platform, deviceID, ip, userAgent string) (*domain.AuthResult, error) {
+	if restriction := user.Restriction(time.Now()); restriction != nil {
+		return nil, restriction
+	}
+	if user.TOTPEnabledAt != nil {
+		return s.mfaChallenge(user, platform, deviceID)
+	}
+	return s.startSession(ctx, user, platform, deviceID, ip, userAgent)
 }
 
-// ================= LOGIN =================
-func (s *userService) Login(ctx context.Context, email, password, platform, deviceID string) (*domain.AuthResult, error) {
-	user, err := s.repo.GetByEmail(ctx, strings.ToLower(strings.TrimSpace(email)))
-	if err != nil || user == nil {
-		return nil, errors.New("invalid email or password")
+// startSession — user+device uchun yangi sessiya ochadi va unga bog'langan tokenlarni qaytaradi.
+// Shu qurilmada oldingi sessiya bo'lsa, uning refresh tokeni bekor qilinadi.
+func (s *userService) startSession(ctx context.Context, user *domain.User, platform, deviceID, ip, userAgent string) (*domain.AuthResult, error) {
+	if deviceID == "" {
+		deviceID = uuid.New().String()
+	}
+
+	if old, err := s.repo.GetSessionByDevice(ctx, user.ID, deviceID); err == nil && old != nil {
+		if err := s.tokenProvider.RevokeRefreshJTI(old.RefreshJTI); err != nil {
+			log.Println("failed to revoke previous session token:", err)
+		}
+		if err := s.tokenProvider.RevokeSessionAccessTokens(old.ID); err != nil {
+			log.Println("failed to revoke previous session access tokens:", err)
+		}
 	}
 
-	if bcrypt.CompareHashAndPassword([]byte(user.PasswordHash), []byte(password)) != nil {
-		return nil, errors.New("invalid email or password")
+	session := &domain.Session{
+		ID:        uuid.New().String(),
+		UserID:    user.ID,
+		DeviceID:  deviceID,
+		Platform:  platform,
+		IPAddress: ip,
+		UserAgent: userAgent,
 	}
 
-	access, refresh, err := s.tokenProvider.GenerateTokens(user.ID)
+	tokens, err := s.tokenProvider.GenerateTokens(user.ID, session.ID, user.Roles())
 	if err != nil {
 		return nil, errors.New("failed to generate tokens")
 	}
+	session.RefreshJTI = tokens.RefreshJTI
+
+	if err := s.repo.UpsertSession(ctx, session); err != nil {
+		_ = s.tokenProvider.RevokeRefreshJTI(tokens.RefreshJTI)
+		return nil, errors.New("failed to create session")
+	}
 
 	return &domain.AuthResult{
-		AccessToken:  access,
-		RefreshToken: refresh,
+		AccessToken:  tokens.AccessToken,
+		RefreshToken: tokens.RefreshToken,
 		User:         user,
 	}, nil
 }
@@ -113,23 +351,80 @@ func (s *userService) GetProfile(ctx context.Context, userID string) (*domain.Us
 
 // ================= UPDATE FIELDS =================
 func (s *userService) UpdateUsername(ctx context.Context, userID, username string) (*domain.User, error) {
-	if username == "" {
-		return nil, errors.New("username cannot be empty")
+	username = strings.TrimSpace(username)
+	if err := s.validateUsername(username); err != nil {
+		return nil, err
 	}
-	if err := s.repo.UpdateField(ctx, userID, "username", &username); err != nil {
+
+	user, err := s.repo.GetByID(ctx, userID)
+	if err != nil {
 		return nil, err
 	}
+	if user == nil {
+		return nil, domain.ErrUserNotFound
+	}
+	previous := getStr(user.Username)
+	if previous == username {
+		return user, nil
+	}
+
+	// Faqat harf registrini o'zgartirish cooldownga kirmaydi
+	cooldown := s.cfg.UsernamePolicy.ChangeCooldown
+	if !strings.EqualFold(previous, username) && cooldown > 0 &&
+		user.UsernameChangedAt != nil && time.Since(*user.UsernameChangedAt) < cooldown {
+		return nil, domain.ErrUsernameChangeTooSoon
+	}
+
+	if err := s.repo.UpdateUsername(ctx, userID, username); err != nil {
+		return nil, err
+	}
+	s.invalidateProfile(ctx, userID)
+	s.publishEvent(ctx, map[string]string{
+		"event":             "UsernameChanged",
+		"user_id":           userID,
+		"previous_username": previous,
+		"username":          username,
+	})
 	return s.repo.GetByID(ctx, userID)
 }
 
-func (s *userService) UpdateEmail(ctx context.Context, userID, email string) (*domain.User, error) {
+func (s *userService) UpdateEmail(ctx context.Context, userID, sessionID, email string) (*domain.User, error) {
 	email = strings.ToLower(strings.TrimSpace(email))
 	if email == "" {
 		return nil, errors.New("email cannot be empty")
 	}
-	if err := s.repo.UpdateField(ctx, userID, "email", &email); err != nil {
+	if err := s.requireRecentAuth(ctx, userID, sessionID); err != nil {
+		return nil, err
+	}
+	user, err := s.repo.GetByID(ctx, userID)
+	if err != nil {
 		return nil, err
 	}
+	if user == nil {
+		return nil, errors.New("user not found")
+	}
+	if user.Email != nil && *user.Email == email {
+		return user, nil
+	}
+	if existing, err := s.repo.GetByEmail(ctx, email); err != nil {
+		return nil, err
+	} else if existing != nil {
+		return nil, domain.ErrEmailTaken
+	}
+
+	// Yangi manzil tasdiqlanmaguncha asosiy email o'zgarmaydi
+	if err := s.repo.SetPendingEmail(ctx, userID, &email); err != nil {
+		return nil, err
+	}
+	if err := s.sendVerificationEmail(ctx, userID, email); err != nil {
+		return nil, err
+	}
+	s.publishEvent(ctx, map[string]string{
+		"event":     "EmailChangeRequested",
+		"user_id":   userID,
+		"email":     getStr(user.Email), // eski manzilga ogohlantirish
+		"new_email": email,
+	})
 	return s.repo.GetByID(ctx, userID)
 }
 
@@ -137,7 +432,7 @@ func (s *userService) UpdateFullName(ctx context.Context, userID, fullName strin
 	if fullName == "" {
 		return nil, errors.New("full_name cannot be empty")
 	}
-	if err := s.repo.UpdateField(ctx, userID, "full_name", &fullName); err != nil {
+	if err := s.updateProfileField(ctx, userID, "full_name", &fullName); err != nil {
 		return nil, err
 	}
 	return s.repo.GetByID(ctx, userID)
@@ -148,7 +443,7 @@ func (s *userService) UpdateAvatar(ctx context.Context, userID, avatarURL string
 	if strings.TrimSpace(avatarURL) != "" {
 		value = &avatarURL
 	} // else -> nil = remove avatar
-	if err := s.repo.UpdateField(ctx, userID, "avatar_url", value); err != nil {
+	if err := s.updateProfileField(ctx, userID, "avatar_url", value); err != nil {
 		return nil, err
 	}
 	return s.repo.GetByID(ctx, userID)
@@ -158,74 +453,359 @@ func (s *userService) UpdateLanguage(ctx context.Context, userID, language strin
 	if language == "" {
 		return nil, errors.New("language cannot be empty")
 	}
-	if err := s.repo.UpdateField(ctx, userID, "language", &language); err != nil {
+	if err := s.updateProfileField(ctx, userID, "language", &language); err != nil {
+		return nil, err
+	}
+	return s.repo.GetByID(ctx, userID)
+}
+
+func (s *userService) UpdatePrivacySettings(ctx context.Context, userID string, update domain.PrivacySettingsUpdate) (*domain.User, error) {
+	user, err := s.repo.GetByID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if user == nil {
+		return nil, domain.ErrUserNotFound
+	}
+
+	settings := user.Privacy
+	if update.Discoverable != nil {
+		settings.Discoverable = *update.Discoverable
+	}
+	if update.ShowFullName != nil {
+		settings.ShowFullName = *update.ShowFullName
+	}
+	if update.ShowAvatar != nil {
+		settings.ShowAvatar = *update.ShowAvatar
+	}
+	if update.HideLastSeen != nil {
+		settings.HideLastSeen = *update.HideLastSeen
+	}
+	if settings == user.Privacy {
+		return user, nil
+	}
+	if err := s.repo.UpdatePrivacySettings(ctx, userID, settings); err != nil {
 		return nil, err
 	}
+	// Keshdagi profil eski ko'rinish sozlamalari bilan qurilgan
+	s.invalidateProfile(ctx, userID)
+	if settings.HideLastSeen != user.Privacy.HideLastSeen {
+		if err := s.presence.Refresh(ctx, userID); err != nil {
+			log.Printf("⚠️ Failed to publish presence for %s: %v", userID, err)
+		}
+	}
 	return s.repo.GetByID(ctx, userID)
 }
 
 // ================= REFRESH TOKEN =================
-func (s *userService) RefreshToken(ctx context.Context, refreshToken string) (*domain.AuthResult, error) {
-	userID, err := s.tokenProvider.ValidateRefreshToken(refreshToken)
+func (s *userService) RefreshToken(ctx context.Context, refreshToken, ipAddress, userAgent string) (*domain.AuthResult, error) {
+	// Status token iste'mol qilinishidan oldin tekshiriladi: suspension vaqtida sessiya va
+	// refresh token saqlanadi, muddat tugagach klient shu token bilan davom etadi
+	if peek, err := s.tokenProvider.ValidateRefreshToken(refreshToken); err == nil {
+		user, err := s.repo.GetByID(ctx, peek.UserID)
+		if err != nil {
+			return nil, err
+		}
+		if user != nil {
+			if restriction := user.Restriction(time.Now()); restriction != nil {
+				return nil, restriction
+			}
+		}
+	}
+
+	claims, err := s.tokenProvider.ConsumeRefreshToken(refreshToken)
+	var reuse *domain.RefreshTokenReuseError
+	if errors.As(err, &reuse) {
+		// Token oilasi allaqachon bekor qilingan — sessiyani ham yopamiz va xavfsizlik hodisasini yuboramiz
+		if err := s.repo.DeleteSessionByID(ctx, reuse.SessionID); err != nil {
+			log.Println("failed to delete compromised session:", err)
+		}
+		if err := s.tokenProvider.RevokeSessionAccessTokens(reuse.SessionID); err != nil {
+			log.Println("failed to revoke compromised session access tokens:", err)
+		}
+		s.publishEvent(ctx, map[string]string{
+			"event":      "RefreshTokenReuseDetected",
+			"user_id":    reuse.UserID,
+			"session_id": reuse.SessionID,
+			"ip_address": ipAddress,
+			"user_agent": userAgent,
+		})
+		return nil, err
+	}
 	if err != nil {
-		return nil, errors.New("invalid refresh token")
+		return nil, domain.ErrInvalidRefreshToken
 	}
 
-	user, err := s.repo.GetByID(ctx, userID)
+	session, err := s.repo.GetSessionByID(ctx, claims.SessionID)
+	if err != nil || session == nil || session.UserID != claims.UserID || session.RefreshJTI != claims.JTI {
+		return nil, domain.ErrInvalidRefreshToken
+	}
+
+	user, err := s.repo.GetByID(ctx, claims.UserID)
 	if err != nil || user == nil {
 		return nil, errors.New("user not found")
 	}
 
-	access, refresh, err := s.tokenProvider.GenerateTokens(user.ID)
+	// Rollar har safar DB dan olinadi — SetRole keyingi refreshdan kuchga kiradi
+	tokens, err := s.tokenProvider.GenerateTokens(user.ID, session.ID, user.Roles())
 	if err != nil {
 		return nil, errors.New("failed to generate tokens")
 	}
 
+	if err := s.repo.UpdateSessionRefresh(ctx, session.ID, tokens.RefreshJTI, ipAddress, userAgent); err != nil {
+		_ = s.tokenProvider.RevokeRefreshJTI(tokens.RefreshJTI)
+		return nil, err
+	}
+
 	return &domain.AuthResult{
-		AccessToken:  access,
-		RefreshToken: refresh,
+		AccessToken:  tokens.AccessToken,
+		RefreshToken: tokens.RefreshToken,
 		User:         user,
 	}, nil
 }
 
 // ================= LOGOUT =================
-func (s *userService) Logout(ctx context.Context, userID, deviceID string) error {
-	return s.repo.DeleteSession(ctx, userID, deviceID)
+func (s *userService) Logout(ctx context.Context, userID, sessionID string) error {
+	session, err := s.repo.GetSessionByID(ctx, sessionID)
+	if err != nil {
+		return err
+	}
+	if session == nil || session.UserID != userID {
+		return domain.ErrSessionNotFound
+	}
+	return s.revokeSession(ctx, session)
 }
 
 // ================= CHANGE PASSWORD =================
-func (s *userService) ChangePassword(ctx context.Context, userID, oldPassword, newPassword string) error {
+func (s *userService) ChangePassword(ctx context.Context, userID, sessionID, oldPassword, newPassword string) error {
+	if err := s.requireRecentAuth(ctx, userID, sessionID); err != nil {
+		return err
+	}
+
 	user, err := s.repo.GetByID(ctx, userID)
-	if err != nil {
+	if err != nil || user == nil {
 		return errors.New("user not found")
 	}
 
-	if bcrypt.CompareHashAndPassword([]byte(user.PasswordHash), []byte(oldPassword)) != nil {
+	if ok, _, _ := s.hasher.Verify(oldPassword, user.PasswordHash); !ok {
 		return errors.New("old password is incorrect")
 	}
 
-	newHash, err := bcrypt.GenerateFromPassword([]byte(newPassword), bcrypt.DefaultCost)
+	if err := s.validatePassword(ctx, newPassword, getStr(user.Username), getStr(user.Email)); err != nil {
+		return err
+	}
+
+	newHash, err := s.hasher.Hash(newPassword)
 	if err != nil {
 		return errors.New("failed to hash new password")
 	}
 
-	return s.repo.ChangePassword(ctx, userID, string(newHash))
+	if err := s.repo.ChangePassword(ctx, userID, newHash); err != nil {
+		return err
+	}
+
+	// Boshqa qurilmalardagi sessiyalarni yopamiz, barcha eski access tokenlarni yaroqsiz qilamiz.
+	// Joriy sessiyaning refresh tokeni saqlanadi — klient yangi access token olishi mumkin.
+	if sessionID != "" {
+		if err := s.RevokeAllOtherSessions(ctx, userID, sessionID); err != nil {
+			return err
+		}
+	}
+	return s.tokenProvider.RevokeUserAccessTokens(userID)
 }
 
 // ================= FORGOT PASSWORD =================
+// Javob email mavjud yoki mavjud emasligidan qat'i nazar bir xil (nil) bo'ladi.
 func (s *userService) ForgotPassword(ctx context.Context, email string) error {
+	email = strings.ToLower(strings.TrimSpace(email))
+	if email == "" {
+		return nil
+	}
+
+	allowed, _, err := s.limiter.Allow(ctx, "forgot_password:"+email, passwordResetLimit, passwordResetWindow)
+	if err != nil {
+		log.Println("forgot password rate limiter error:", err)
+		return nil
+	}
+	if !allowed {
+		return nil
+	}
+
+	// Token yaratish va yuborish fonda bajariladi — javob vaqti ham email mavjudligini oshkor qilmaydi
+	go s.sendPasswordReset(context.WithoutCancel(ctx), email)
 	return nil
 }
 
+func (s *userService) sendPasswordReset(ctx context.Context, email string) {
+	user, err := s.repo.GetByEmail(ctx, email)
+	if err != nil {
+		log.Println("forgot password lookup error:", err)
+		return
+	}
+	if user == nil {
+		return
+	}
+
+	token, err := utils.GenerateSecureToken(passwordResetTokenBytes)
+	if err != nil {
+		log.Println("failed to generate reset token:", err)
+		return
+	}
+	expiresAt := time.Now().Add(passwordResetTTL)
+	if err := s.repo.CreatePasswordResetToken(ctx, email, utils.HashToken(token), expiresAt); err != nil {
+		log.Println("failed to store reset token:", err)
+		return
+	}
+
+	s.publishEvent(ctx, map[string]string{
+		"event":      "PasswordResetRequested",
+		"user_id":    user.ID,
+		"email":      email,
+		"reset_link": s.cfg.PasswordResetURL + "?token=" + url.QueryEscape(token),
+		"expires_at": expiresAt.UTC().Format(time.RFC3339),
+	})
+}
+
 // ================= RESET PASSWORD =================
 func (s *userService) ResetPassword(ctx context.Context, token, newPassword string) error {
-	
-	return s.repo.ResetPassword(ctx, "","" )
+	if token == "" {
+		return domain.ErrInvalidResetToken
+	}
+	// Token iste'mol qilinishidan oldin: yaroqsiz parol tokenni yoqib yubormasligi uchun.
+	// Bu yerda faqat holatsiz qoidalar — breached va shaxsiy ma'lumot tekshiruvi user yuklangach bir marta
+	if err := s.checkPasswordRules(newPassword); err != nil {
+		return err
+	}
+
+	email, err := s.repo.ConsumePasswordResetToken(ctx, utils.HashToken(token))
+	if err != nil {
+		return err
+	}
+
+	user, err := s.repo.GetByEmail(ctx, email)
+	if err != nil {
+		return err
+	}
+	if user == nil {
+		return domain.ErrInvalidResetToken
+	}
+
+	if err := s.validatePassword(ctx, newPassword, getStr(user.Username), getStr(user.Email)); err != nil {
+		return err
+	}
+
+	newHash, err := s.hasher.Hash(newPassword)
+	if err != nil {
+		return errors.New("failed to hash new password")
+	}
+	if err := s.repo.ResetPassword(ctx, user.ID, newHash); err != nil {
+		return err
+	}
+
+	if err := s.revokeAllSessions(ctx, user.ID); err != nil {
+		return err
+	}
+
+	s.publishEvent(ctx, map[string]string{
+		"event":   "PasswordReset",
+		"user_id": user.ID,
+		"email":   email,
+	})
+	return nil
+}
+
+// ================= SEND VERIFICATION EMAIL =================
+// Kutilayotgan (pending) email bo'lsa o'shanga, aks holda joriy emailga yuboriladi.
+func (s *userService) SendVerificationEmail(ctx context.Context, userID string) error {
+	user, err := s.repo.GetByID(ctx, userID)
+	if err != nil {
+		return err
+	}
+	if user == nil {
+		return errors.New("user not found")
+	}
+
+	email := getStr(user.PendingEmail)
+	if email == "" {
+		if user.EmailVerifiedAt != nil {
+			return domain.ErrEmailAlreadyVerified
+		}
+		email = getStr(user.Email)
+	}
+
+	allowed, _, err := s.limiter.Allow(ctx, "verify_email:"+userID, emailVerificationLimit, emailVerificationWindow)
+	if err != nil {
+		return err
+	}
+	if !allowed {
+		return domain.ErrTooManyRequests
+	}
+	return s.sendVerificationEmail(ctx, userID, email)
 }
 
-// ================= DELETE ACCOUNT =================
-func (s *userService) DeleteAccount(ctx context.Context, userID string) error {
-	return s.repo.Delete(ctx, userID)
+func (s *userService) sendVerificationEmail(ctx context.Context, userID, email string) error {
+	token, err := utils.GenerateSecureToken(emailVerificationTokenBytes)
+	if err != nil {
+		return err
+	}
+	expiresAt := time.Now().Add(emailVerificationTTL)
+	if err := s.repo.CreateEmailVerificationToken(ctx, userID, email, utils.HashToken(token), expiresAt); err != nil {
+		return err
+	}
+
+	s.publishEvent(ctx, map[string]string{
+		"event":       "EmailVerificationRequested",
+		"user_id":     userID,
+		"email":       email,
+		"verify_link": s.cfg.EmailVerificationURL + "?token=" + url.QueryEscape(token),
+		"expires_at":  expiresAt.UTC().Format(time.RFC3339),
+	})
+	return nil
+}
+
+// ================= VERIFY EMAIL =================
+func (s *userService) VerifyEmail(ctx context.Context, token string) error {
+	if token == "" {
+		return domain.ErrInvalidVerificationToken
+	}
+	userID, email, err := s.repo.ConsumeEmailVerificationToken(ctx, utils.HashToken(token))
+	if err != nil {
+		return err
+	}
+
+	user, err := s.repo.GetByID(ctx, userID)
+	if err != nil {
+		return err
+	}
+	if user == nil {
+		return domain.ErrInvalidVerificationToken
+	}
+
+	switch email {
+	case getStr(user.Email):
+		return s.repo.MarkEmailVerified(ctx, userID, email)
+
+	case getStr(user.PendingEmail):
+		// Tasdiqlash kutilayotgan vaqtda manzilni boshqa kimdir egallab olgan bo'lishi mumkin
+		if existing, err := s.repo.GetByEmail(ctx, email); err != nil {
+			return err
+		} else if existing != nil {
+			return domain.ErrEmailTaken
+		}
+		if err := s.repo.MarkEmailVerified(ctx, userID, email); err != nil {
+			return err
+		}
+		s.publishEvent(ctx, map[string]string{
+			"event":     "EmailChanged",
+			"user_id":   userID,
+			"old_email": getStr(user.Email),
+			"email":     email,
+		})
+		return nil
+	}
+
+	// Token eski (bekor qilingan) pending email uchun berilgan
+	return domain.ErrInvalidVerificationToken
 }
 
 // ================= GET SESSIONS =================
@@ -233,12 +813,144 @@ func (s *userService) GetSessions(ctx context.Context, userID string) ([]domain.
 	return s.repo.GetSessions(ctx, userID)
 }
 
-// ================= DELETE SESSION =================
-func (s *userService) DeleteSession(ctx context.Context, userID, deviceID string) error {
-	return s.repo.DeleteSession(ctx, userID, deviceID)
+// ================= REVOKE SESSION =================
+func (s *userService) RevokeSession(ctx context.Context, userID, deviceID string) error {
+	session, err := s.repo.GetSessionByDevice(ctx, userID, deviceID)
+	if err != nil {
+		return err
+	}
+	if session == nil {
+		return domain.ErrSessionNotFound
+	}
+	return s.revokeSession(ctx, session)
+}
+
+// ================= REVOKE ALL OTHER SESSIONS =================
+func (s *userService) RevokeAllOtherSessions(ctx context.Context, userID, currentSessionID string) error {
+	if currentSessionID == "" {
+		return domain.ErrSessionNotFound
+	}
+	sessions, err := s.repo.GetSessions(ctx, userID)
+	if err != nil {
+		return err
+	}
+	for _, session := range sessions {
+		if session.ID == currentSessionID {
+			continue
+		}
+		if err := s.tokenProvider.RevokeRefreshJTI(session.RefreshJTI); err != nil {
+			return err
+		}
+		if err := s.tokenProvider.RevokeSessionAccessTokens(session.ID); err != nil {
+			return err
+		}
+	}
+	return s.repo.DeleteOtherSessions(ctx, userID, currentSessionID)
+}
+
+// ================= JWKS =================
+func (s *userService) GetJWKS(ctx context.Context) []domain.JWK {
+	return s.tokenProvider.JWKS()
+}
+
+// ================= INTROSPECT TOKEN =================
+// Boshqa servislar uchun: imzo, muddat va Redis dagi revokatsiyalar (sessiya, watermark) tekshiriladi
+func (s *userService) IntrospectToken(ctx context.Context, accessToken string) (*domain.AccessClaims, error) {
+	if accessToken == "" {
+		return nil, nil
+	}
+	claims, err := s.tokenProvider.ValidateAccessToken(accessToken)
+	if errors.Is(err, domain.ErrServiceUnavailable) {
+		return nil, err
+	}
+	if err != nil {
+		return nil, nil
+	}
+
+	// Auth interceptor kabi: suspended/banned userning hali muddati tugamagan tokenlari ham inactive
+	active, err := s.accountActive(ctx, claims.UserID)
+	if err != nil {
+		return nil, err
+	}
+	if !active {
+		return nil, nil
+	}
+	return claims, nil
+}
+
+// accountActive — avval AccountStatusCache, u ishlamasa DB dagi status.
+// Ikkalasi ham javob bermasa ErrServiceUnavailable.
+func (s *userService) accountActive(ctx context.Context, userID string) (bool, error) {
+	restriction, err := s.statuses.Restriction(ctx, userID)
+	if err == nil {
+		return restriction == nil, nil
+	}
+	log.Printf("⚠️ Account status cache unavailable, falling back to DB: %v", err)
+
+	user, err := s.repo.GetByID(ctx, userID)
+	if err != nil {
+		return false, fmt.Errorf("%w: account status lookup: %v", domain.ErrServiceUnavailable, err)
+	}
+	if user == nil {
+		return false, nil
+	}
+	return user.Restriction(time.Now()) == nil, nil
+}
+
+// revokeAllSessions — foydalanuvchining barcha sessiyalarini yopadi va barcha tokenlarini bekor qiladi
+func (s *userService) revokeAllSessions(ctx context.Context, userID string) error {
+	return revokeUserSessions(ctx, s.repo, s.tokenProvider, userID)
+}
+
+// revokeUserSessions — userService va adminService uchun umumiy
+func revokeUserSessions(ctx context.Context, repo domain.UserRepository, tokens domain.TokenProvider, userID string) error {
+	sessions, err := repo.GetSessions(ctx, userID)
+	if err != nil {
+		return err
+	}
+	for _, session := range sessions {
+		if err := tokens.RevokeRefreshJTI(session.RefreshJTI); err != nil {
+			return err
+		}
+	}
+	if err := repo.DeleteAllSessions(ctx, userID); err != nil {
+		return err
+	}
+	return tokens.RevokeUserAccessTokens(userID)
+}
+
+// revokeSession — sessiya qatorini o'chiradi, uning refresh tokenini Redis dan bekor qiladi
+// va shu sessiyaga berilgan access tokenlarni denylistga qo'shadi
+func (s *userService) revokeSession(ctx context.Context, session *domain.Session) error {
+	if err := s.tokenProvider.RevokeRefreshJTI(session.RefreshJTI); err != nil {
+		return err
+	}
+	if err := s.tokenProvider.RevokeSessionAccessTokens(session.ID); err != nil {
+		return err
+	}
+	return s.repo.DeleteSessionByID(ctx, session.ID)
 }
 
-// ================= DELETE ALL SESSIONS =================
-func (s *userService) DeleteAllSessions(ctx context.Context, userID string) error {
-	return s.repo.DeleteAllSessions(ctx, userID)
+// publishEvent — hodisani Kafka ga yuboradi; xatolik faqat logga yoziladi
+func (s *userService) publishEvent(ctx context.Context, event map[string]string) {
+	publishKafkaEvent(ctx, s.k, event)
+}
+
+// publishKafkaEvent — userService va adminService uchun umumiy; xato faqat loglanadi
+func publishKafkaEvent(ctx context.Context, k eventProducer, event map[string]string) {
+	eventBytes, err := json.Marshal(event)
+	if err != nil {
+		log.Println("Kafka event marshal error:", err)
+		return
+	}
+	if err := k.Publish(ctx, eventBytes); err != nil {
+		log.Println("Kafka publish error:", err)
+	}
+}
+
+func getStr(s *string) string {
+	if s == nil {
+		return ""
+	}
+	return *s
 }
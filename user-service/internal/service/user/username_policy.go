@@ -0,0 +1,180 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"math/rand/v2"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"user-service/internal/domain"
+)
+
+// UsernamePolicy — username talablari (config dagi USERNAME_* dan to'ldiriladi)
+type UsernamePolicy struct {
+	MinLength      int
+	MaxLength      int
+	Reserved       []string      // defaultReservedUsernames ga qo'shimcha
+	ChangeCooldown time.Duration // ikki o'zgartirish orasidagi minimal vaqt (0 => cheklovsiz)
+}
+
+// defaultReservedUsernames — tizim, support va URL yo'llari bilan adashtirilishi mumkin bo'lgan nomlar
+var defaultReservedUsernames = []string{
+	"admin", "administrator", "root", "system", "support", "help", "security",
+	"moderator", "mod", "staff", "official", "team", "api", "www", "mail",
+	"login", "logout", "register", "signup", "settings", "me", "user", "users",
+	"null", "undefined", "anonymous", "chat", "chat-app",
+}
+
+const usernameSuggestionCount = 5
+
+// usernameViolations — barcha buzilgan qoidalar (Register da parol xatolari bilan birga qaytariladi)
+func (s *userService) usernameViolations(username string) []domain.FieldViolation {
+	p := s.cfg.UsernamePolicy
+	var violations []domain.FieldViolation
+	violate := func(desc string) {
+		violations = append(violations, domain.FieldViolation{Field: "username", Description: desc})
+	}
+
+	if len(username) < p.MinLength {
+		violate(fmt.Sprintf("must be at least %d characters long", p.MinLength))
+	}
+	if p.MaxLength > 0 && len(username) > p.MaxLength {
+		violate(fmt.Sprintf("must be at most %d characters long", p.MaxLength))
+	}
+
+	var badChar, badSeparator bool
+	for i, r := range username {
+		switch {
+		case isUsernameAlnum(r):
+		case r == '_' || r == '.':
+			// Ajratuvchi boshida, oxirida yoki ketma-ket kelmaydi
+			if i == 0 || i == len(username)-1 || !isUsernameAlnum(rune(username[i-1])) {
+				badSeparator = true
+			}
+		default:
+			badChar = true
+		}
+	}
+	if badChar {
+		violate("may only contain latin letters, digits, '_' and '.'")
+	}
+	if badSeparator {
+		violate("'_' and '.' must be between letters or digits")
+	}
+
+	if s.isReservedUsername(username) {
+		violate("is reserved")
+	}
+	return violations
+}
+
+func (s *userService) validateUsername(username string) error {
+	if violations := s.usernameViolations(username); len(violations) > 0 {
+		return &domain.ValidationError{Violations: violations}
+	}
+	return nil
+}
+
+func (s *userService) isReservedUsername(username string) bool {
+	name := strings.ToLower(username)
+	return slices.Contains(defaultReservedUsernames, name) || slices.Contains(s.cfg.UsernamePolicy.Reserved, name)
+}
+
+func isUsernameAlnum(r rune) bool {
+	return (r >= 'a' && r <= 'z') || (r >= 'A' && r <= 'Z') || (r >= '0' && r <= '9')
+}
+
+// ================= USERNAME AVAILABILITY =================
+func (s *userService) CheckUsernameAvailability(ctx context.Context, username string) (*domain.UsernameAvailability, error) {
+	username = strings.TrimSpace(username)
+
+	result := &domain.UsernameAvailability{}
+	if violations := s.usernameViolations(username); len(violations) > 0 {
+		result.Reason = violations[0].Description
+	} else {
+		taken, err := s.repo.TakenUsernames(ctx, []string{username})
+		if err != nil {
+			return nil, err
+		}
+		if !taken[strings.ToLower(username)] {
+			result.Available = true
+			return result, nil
+		}
+		result.Reason = "is already taken"
+	}
+
+	suggestions, err := s.suggestUsernames(ctx, username)
+	if err != nil {
+		return nil, err
+	}
+	result.Suggestions = suggestions
+	return result, nil
+}
+
+// suggestUsernames — so'ralgan nomga yaqin, siyosatga mos va bo'sh variantlar
+func (s *userService) suggestUsernames(ctx context.Context, username string) ([]string, error) {
+	p := s.cfg.UsernamePolicy
+	base := sanitizeUsernameBase(username, p.MaxLength-5) // "_" + 4 raqamga joy
+	if len(base) < p.MinLength || s.isReservedUsername(base) {
+		base = "user"
+	}
+
+	var candidates []string
+	seen := make(map[string]bool)
+	// Urinishlar chegaralangan: juda qisqa MaxLength da hech bir variant siyosatga mos kelmasligi mumkin
+	for attempt := 0; attempt < 100 && len(candidates) < usernameSuggestionCount*3; attempt++ {
+		candidate := base + strconv.Itoa(rand.IntN(9000)+10)
+		if len(candidates)%2 == 1 {
+			candidate = base + "_" + strconv.Itoa(rand.IntN(900)+10)
+		}
+		if seen[candidate] || len(s.usernameViolations(candidate)) > 0 {
+			continue
+		}
+		seen[candidate] = true
+		candidates = append(candidates, candidate)
+	}
+
+	if len(candidates) == 0 {
+		return nil, nil
+	}
+	taken, err := s.repo.TakenUsernames(ctx, candidates)
+	if err != nil {
+		return nil, err
+	}
+	var free []string
+	for _, c := range candidates {
+		if !taken[c] {
+			free = append(free, c)
+		}
+		if len(free) == usernameSuggestionCount {
+			break
+		}
+	}
+	return free, nil
+}
+
+// sanitizeUsernameBase — ixtiyoriy matndan siyosatga mos asos: kichik harf, ruxsat etilgan belgilar,
+// ajratuvchilar faqat harf/raqamlar orasida
+func sanitizeUsernameBase(value string, maxLen int) string {
+	var b strings.Builder
+	pendingSep := rune(0)
+	for _, r := range strings.ToLower(value) {
+		if b.Len() >= maxLen {
+			break
+		}
+		switch {
+		case isUsernameAlnum(r):
+			if pendingSep != 0 && b.Len() > 0 && b.Len() < maxLen-1 {
+				b.WriteRune(pendingSep)
+			}
+			pendingSep = 0
+			b.WriteRune(r)
+		case r == '_' || r == '.':
+			pendingSep = r
+		}
+	}
+	return b.String()
+}
@@ -0,0 +1,130 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"user-service/internal/domain"
+)
+
+const otherUserID = "aaaaaaaa-aaaa-aaaa-aaaa-aaaaaaaaaaaa"
+
+func newUsernameService(users ...*domain.User) (*userService, *fakeRepo) {
+	repo := newFakeRepo(users...)
+	s, _ := newTestService(repo)
+	s.cfg.UsernamePolicy = UsernamePolicy{
+		MinLength:      3,
+		MaxLength:      30,
+		Reserved:       []string{"acme"}, // config kichik harfga o'giradi
+		ChangeCooldown: 30 * 24 * time.Hour,
+	}
+	return s, repo
+}
+
+func TestUsernameReserved(t *testing.T) {
+	s, _ := newUsernameService()
+	tests := []struct {
+		username string
+		reserved bool
+	}{
+		{"admin", true},
+		{"Admin", true},
+		{"ADMINISTRATOR", true},
+		{"Support", true},
+		{"acme", true},
+		{"ACME", true},
+		{"adminx", false},
+		{"my_admin", false},
+		{"acme.corp", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.username, func(t *testing.T) {
+			if got := s.isReservedUsername(tt.username); got != tt.reserved {
+				t.Errorf("isReservedUsername(%q) = %v, want %v", tt.username, got, tt.reserved)
+			}
+			err := s.validateUsername(tt.username)
+			var validation *domain.ValidationError
+			if tt.reserved && (!errors.As(err, &validation) || validation.Violations[0].Description != "is reserved") {
+				t.Errorf("validateUsername(%q) = %v, want \"is reserved\"", tt.username, err)
+			}
+			if !tt.reserved && err != nil {
+				t.Errorf("validateUsername(%q) = %v, want nil", tt.username, err)
+			}
+		})
+	}
+}
+
+func TestUsernameCaseFoldingCollisions(t *testing.T) {
+	s, repo := newUsernameService(
+		&domain.User{ID: memberID, Username: strPtr("Alice")},
+		&domain.User{ID: otherUserID, Username: strPtr("bob")},
+	)
+	ctx := context.Background()
+
+	for _, name := range []string{"alice", "ALICE", "aLiCe"} {
+		availability, err := s.CheckUsernameAvailability(ctx, name)
+		if err != nil {
+			t.Fatalf("CheckUsernameAvailability(%q): %v", name, err)
+		}
+		if availability.Available || availability.Reason != "is already taken" {
+			t.Errorf("CheckUsernameAvailability(%q) = %+v, want taken", name, availability)
+		}
+		for _, suggestion := range availability.Suggestions {
+			if taken, _ := repo.TakenUsernames(ctx, []string{suggestion}); len(taken) != 0 {
+				t.Errorf("suggestion %q is taken", suggestion)
+			}
+		}
+
+		if _, err := s.UpdateUsername(ctx, otherUserID, name); !errors.Is(err, domain.ErrUsernameTaken) {
+			t.Errorf("UpdateUsername(%q) by another user: err = %v, want ErrUsernameTaken", name, err)
+		}
+	}
+
+	// Egasi o'z nomining registrini o'zgartira oladi
+	user, err := s.UpdateUsername(ctx, memberID, "ALICE")
+	if err != nil {
+		t.Fatalf("UpdateUsername case change by owner: %v", err)
+	}
+	if got := getStr(user.Username); got != "ALICE" {
+		t.Errorf("username = %q, want ALICE", got)
+	}
+}
+
+func TestUpdateUsernameCooldown(t *testing.T) {
+	recent := time.Now().Add(-time.Hour)
+	longAgo := time.Now().Add(-31 * 24 * time.Hour)
+	tests := []struct {
+		name      string
+		changedAt *time.Time
+		cooldown  time.Duration
+		newName   string
+		wantErr   error
+	}{
+		{"never changed", nil, 30 * 24 * time.Hour, "carol_new", nil},
+		{"changed within cooldown", &recent, 30 * 24 * time.Hour, "carol_new", domain.ErrUsernameChangeTooSoon},
+		{"case-only change within cooldown", &recent, 30 * 24 * time.Hour, "Carol", nil},
+		{"cooldown elapsed", &longAgo, 30 * 24 * time.Hour, "carol_new", nil},
+		{"cooldown disabled", &recent, 0, "carol_new", nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s, repo := newUsernameService(&domain.User{ID: memberID, Username: strPtr("carol"), UsernameChangedAt: tt.changedAt})
+			s.cfg.UsernamePolicy.ChangeCooldown = tt.cooldown
+
+			_, err := s.UpdateUsername(context.Background(), memberID, tt.newName)
+
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("err = %v, want %v", err, tt.wantErr)
+			}
+			want := tt.newName
+			if tt.wantErr != nil {
+				want = "carol"
+			}
+			if got := getStr(repo.users[memberID].Username); got != want {
+				t.Errorf("username = %q, want %q", got, want)
+			}
+		})
+	}
+}
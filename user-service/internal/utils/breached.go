@@ -0,0 +1,139 @@
+package utils
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"crypto/sha1"
+	"encoding/hex"
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"user-service/internal/domain"
+)
+
+// breachedLineMax — "SHA1:COUNT\r\n" qatorining maksimal uzunligi
+const breachedLineMax = 128
+
+type breachedPasswords struct {
+	path  string
+	isDir bool
+}
+
+// NewBreachedPasswordChecker — Have I Been Pwned ma'lumotlari bilan oflayn tekshiruv. path:
+//   - katalog: range API formatidagi fayllar (ABCDE yoki ABCDE.txt, qatorlar "SUFFIX:COUNT")
+//   - fayl: hash bo'yicha saralangan to'liq ro'yxat ("SHA1:COUNT"), binary search bilan qidiriladi
+func NewBreachedPasswordChecker(path string) (domain.BreachedPasswordChecker, error) {
+	info, err := os.Stat(path)
+	if err != nil {
+		return nil, err
+	}
+	return &breachedPasswords{path: path, isDir: info.IsDir()}, nil
+}
+
+func (b *breachedPasswords) IsBreached(_ context.Context, password string) (bool, error) {
+	sum := sha1.Sum([]byte(password))
+	hash := strings.ToUpper(hex.EncodeToString(sum[:]))
+	if b.isDir {
+		return b.searchRange(hash[:5], hash[5:])
+	}
+	return b.searchSorted(hash)
+}
+
+func (b *breachedPasswords) searchRange(prefix, suffix string) (bool, error) {
+	f, err := os.Open(filepath.Join(b.path, prefix+".txt"))
+	if errors.Is(err, os.ErrNotExist) {
+		f, err = os.Open(filepath.Join(b.path, prefix))
+	}
+	if errors.Is(err, os.ErrNotExist) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	defer f.Close()
+
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		line, _, _ := strings.Cut(scanner.Text(), ":")
+		if strings.EqualFold(line, suffix) {
+			return true, nil
+		}
+	}
+	return false, scanner.Err()
+}
+
+// searchSorted — fayl xotiraga yuklanmaydi (to'liq ro'yxat o'nlab GB bo'lishi mumkin)
+func (b *breachedPasswords) searchSorted(hash string) (bool, error) {
+	f, err := os.Open(b.path)
+	if err != nil {
+		return false, err
+	}
+	defer f.Close()
+
+	info, err := f.Stat()
+	if err != nil {
+		return false, err
+	}
+
+	target := []byte(hash)
+	buf := make([]byte, breachedLineMax)
+
+	// Invariant: qidirilayotgan qator (bo'lsa) [lo, hi) oralig'ida boshlanadi; lo doim qator boshi
+	lo, hi := int64(0), info.Size()
+	for lo < hi {
+		mid := lo + (hi-lo)/2
+		start, err := lineStartAt(f, mid, buf)
+		if err != nil {
+			return false, err
+		}
+		if start >= hi {
+			hi = mid
+			continue
+		}
+
+		n, err := f.ReadAt(buf, start)
+		if err != nil && err != io.EOF {
+			return false, err
+		}
+		line := buf[:n]
+		end := bytes.IndexByte(line, '\n')
+		if end >= 0 {
+			line = line[:end]
+		} else {
+			end = n
+		}
+		key, _, _ := bytes.Cut(line, []byte(":"))
+
+		switch cmp := bytes.Compare(bytes.ToUpper(bytes.TrimSpace(key)), target); {
+		case cmp == 0:
+			return true, nil
+		case cmp < 0:
+			lo = start + int64(end) + 1
+		default:
+			hi = mid
+		}
+	}
+	return false, nil
+}
+
+// lineStartAt — pos dan boshlab (pos ham kiradi) birinchi qator boshi
+func lineStartAt(f *os.File, pos int64, buf []byte) (int64, error) {
+	if pos == 0 {
+		return 0, nil
+	}
+	n, err := f.ReadAt(buf, pos-1)
+	if err != nil && err != io.EOF {
+		return 0, err
+	}
+	if i := bytes.IndexByte(buf[:n], '\n'); i >= 0 {
+		return pos + int64(i), nil
+	}
+	if err == io.EOF {
+		return pos + int64(n), nil
+	}
+	return 0, errors.New("breached password file: line too long")
+}
@@ -0,0 +1,193 @@
+package utils
+
+import (
+	"context"
+	"crypto/sha1"
+	"encoding/hex"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"testing"
+)
+
+func sha1Hex(password string) string {
+	sum := sha1.Sum([]byte(password))
+	return strings.ToUpper(hex.EncodeToString(sum[:]))
+}
+
+// breachedCorpus — saralangan hashlar; birinchi va oxirgi qatorlar alohida tekshiriladi
+func breachedCorpus(n int) (passwords []string, hashes []string) {
+	byHash := make(map[string]string, n)
+	for i := 0; i < n; i++ {
+		p := fmt.Sprintf("breached-%d", i)
+		h := sha1Hex(p)
+		byHash[h] = p
+		hashes = append(hashes, h)
+	}
+	sort.Strings(hashes)
+	for _, h := range hashes {
+		passwords = append(passwords, byHash[h])
+	}
+	return passwords, hashes
+}
+
+// missBetween — saralangan ro'yxatda ikki qo'shni hash orasiga tushadigan, ro'yxatda yo'q parol
+func missBetween(t *testing.T, hashes []string) string {
+	t.Helper()
+	for i := 0; i < 100000; i++ {
+		p := fmt.Sprintf("clean-%d", i)
+		h := sha1Hex(p)
+		j := sort.SearchStrings(hashes, h)
+		if j > 0 && j < len(hashes) && hashes[j] != h {
+			return p
+		}
+	}
+	t.Fatal("no password falls between corpus entries")
+	return ""
+}
+
+func writeSorted(t *testing.T, hashes []string, lineEnd string, trailing bool) string {
+	t.Helper()
+	var b strings.Builder
+	for i, h := range hashes {
+		fmt.Fprintf(&b, "%s:%d", h, i+1)
+		if i < len(hashes)-1 || trailing {
+			b.WriteString(lineEnd)
+		}
+	}
+	path := filepath.Join(t.TempDir(), "pwned-passwords-sha1-ordered-by-hash.txt")
+	if err := os.WriteFile(path, []byte(b.String()), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func TestBreachedSortedFile(t *testing.T) {
+	passwords, hashes := breachedCorpus(50)
+	between := missBetween(t, hashes)
+
+	files := []struct {
+		name     string
+		lineEnd  string
+		trailing bool
+	}{
+		{"lf with trailing newline", "\n", true},
+		{"lf without trailing newline", "\n", false},
+		{"crlf with trailing newline", "\r\n", true},
+		{"crlf without trailing newline", "\r\n", false},
+	}
+	lookups := []struct {
+		name     string
+		password string
+		want     bool
+	}{
+		{"first line", passwords[0], true},
+		{"second line", passwords[1], true},
+		{"middle line", passwords[len(passwords)/2], true},
+		{"last line", passwords[len(passwords)-1], true},
+		{"miss between entries", between, false},
+		{"empty password", "", false},
+	}
+	for _, file := range files {
+		t.Run(file.name, func(t *testing.T) {
+			checker, err := NewBreachedPasswordChecker(writeSorted(t, hashes, file.lineEnd, file.trailing))
+			if err != nil {
+				t.Fatal(err)
+			}
+			for _, l := range lookups {
+				got, err := checker.IsBreached(context.Background(), l.password)
+				if err != nil {
+					t.Fatalf("%s: %v", l.name, err)
+				}
+				if got != l.want {
+					t.Errorf("%s: IsBreached = %v, want %v", l.name, got, l.want)
+				}
+			}
+		})
+	}
+}
+
+func TestBreachedSortedFileEdges(t *testing.T) {
+	passwords, hashes := breachedCorpus(3)
+	tests := []struct {
+		name     string
+		hashes   []string
+		password string
+		want     bool
+	}{
+		{"single line hit", hashes[:1], passwords[0], true},
+		{"single line miss before", hashes[1:2], passwords[0], false},
+		{"single line miss after", hashes[:1], passwords[1], false},
+		{"miss before first line", hashes[1:], passwords[0], false},
+		{"miss after last line", hashes[:2], passwords[2], false},
+		{"empty file", nil, passwords[0], false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			checker, err := NewBreachedPasswordChecker(writeSorted(t, tt.hashes, "\n", false))
+			if err != nil {
+				t.Fatal(err)
+			}
+			got, err := checker.IsBreached(context.Background(), tt.password)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got != tt.want {
+				t.Errorf("IsBreached = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestBreachedRangeDirectory(t *testing.T) {
+	dir := t.TempDir()
+	withTxt, bare, noFile, otherSuffix := "hunter2", "password123", "correct horse battery staple", "letmein"
+
+	write := func(name, content string) {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0o600); err != nil {
+			t.Fatal(err)
+		}
+	}
+	// Range API javobi: prefikssiz suffix, CRLF, suffix kichik harflarda ham bo'lishi mumkin
+	h := sha1Hex(withTxt)
+	write(h[:5]+".txt", "0000000000000000000000000000000000A:1\r\n"+strings.ToLower(h[5:])+":17\r\n")
+	h = sha1Hex(bare)
+	write(h[:5], h[5:]+":42")
+	// Prefiks fayli bor, lekin unda boshqa suffixlar
+	h = sha1Hex(otherSuffix)
+	write(h[:5]+".txt", "0000000000000000000000000000000000A:1\r\nFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFF:3\r\n")
+
+	tests := []struct {
+		name     string
+		password string
+		want     bool
+	}{
+		{"prefix.txt file", withTxt, true},
+		{"bare prefix file", bare, true},
+		{"no file for prefix", noFile, false},
+		{"same prefix, different suffix", otherSuffix, false},
+	}
+	checker, err := NewBreachedPasswordChecker(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := checker.IsBreached(context.Background(), tt.password)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got != tt.want {
+				t.Errorf("IsBreached = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestNewBreachedPasswordCheckerMissingPath(t *testing.T) {
+	if _, err := NewBreachedPasswordChecker(filepath.Join(t.TempDir(), "missing")); err == nil {
+		t.Error("expected error for missing path")
+	}
+}
@@ -1,52 +1,62 @@
 package utils
 
-// import (
-// 	"context"
-// 	"log"
-// 	"strings"
-
-// 	"google.golang.org/grpc"
-// 	"google.golang.org/grpc/codes"
-// 	"google.golang.org/grpc/metadata"
-// 	"google.golang.org/grpc/status"
-// )
-
-// // Key to extract user ID from context later
-// type ctxKey string
-
-// const UserIDKey ctxKey = "userID"
-
-// // gRPC Unary Interceptor for JWT authentication
-// func GRPCAuthInterceptor(
-// 	ctx context.Context,
-// 	req interface{},
-// 	info *grpc.UnaryServerInfo,
-// 	handler grpc.UnaryHandler,
-// ) (interface{}, error) {
-// 	md, ok := metadata.FromIncomingContext(ctx)
-// 	if !ok {
-// 		return handler(ctx, req)
-// 	}
-
-// 	authHeaders := md.Get("authorization")
-// 	if len(authHeaders) == 0 {
-// 		return handler(ctx, req) // No token → public endpoint
-// 	}
-
-// 	tokenStr := strings.TrimPrefix(authHeaders[0], "Bearer ")
-
-// 	userID, err := ValidateAccessToken(tokenStr)
-// 	if err != nil {
-// 		log.Printf("❌ Invalid token: %v", err)
-// 		return nil, status.Error(codes.Unauthenticated, "invalid token")
-// 	}
-
-// 	ctx = context.WithValue(ctx, UserIDKey, userID)
-// 	return handler(ctx, req)
-// }
-
-// // Helper to extract userID from context
-// func GetUserIDFromContext(ctx context.Context) (string, bool) {
-// 	userID, ok := ctx.Value(UserIDKey).(string)
-// 	return userID, ok
-// }
+import "context"
+
+// ctxKey — context kalitlari uchun alohida tip (boshqa paketlardagi string kalitlar bilan to'qnashmaydi)
+type ctxKey string
+
+const (
+	userIDKey    ctxKey = "userID"
+	sessionIDKey ctxKey = "sessionID"
+	rolesKey     ctxKey = "roles"
+	serviceKey   ctxKey = "service"
+	actorKey     ctxKey = "actor"
+	clientIPKey  ctxKey = "clientIP"
+)
+
+// WithUser — auth interceptor tasdiqlangan access token ma'lumotlarini ctx ga qo'yadi
+func WithUser(ctx context.Context, userID, sessionID string, roles []string) context.Context {
+	ctx = context.WithValue(ctx, userIDKey, userID)
+	ctx = context.WithValue(ctx, sessionIDKey, sessionID)
+	return context.WithValue(ctx, rolesKey, roles)
+}
+
+// UserIDFromContext — autentifikatsiya qilingan user ID
+func UserIDFromContext(ctx context.Context) (string, bool) {
+	userID, ok := ctx.Value(userIDKey).(string)
+	return userID, ok && userID != ""
+}
+
+// SessionIDFromContext — joriy access token bog'langan sessiya
+func SessionIDFromContext(ctx context.Context) string {
+	sessionID, _ := ctx.Value(sessionIDKey).(string)
+	return sessionID
+}
+
+// RolesFromContext — access tokendagi rollar
+func RolesFromContext(ctx context.Context) []string {
+	roles, _ := ctx.Value(rolesKey).([]string)
+	return roles
+}
+
+// WithService — service-to-service token bilan kelgan chaqiruvchi servis nomi
+func WithService(ctx context.Context, name string) context.Context {
+	return context.WithValue(ctx, serviceKey, name)
+}
+
+// ServiceFromContext — chaqiruvchi servis nomi (user tokeni bilan kelgan so'rovlarda bo'sh)
+func ServiceFromContext(ctx context.Context) (string, bool) {
+	name, ok := ctx.Value(serviceKey).(string)
+	return name, ok && name != ""
+}
+
+// WithActor — impersonatsiya tokeni bilan kelgan so'rovda haqiqiy chaqiruvchi (support xodimi)
+func WithActor(ctx context.Context, actorID string) context.Context {
+	return context.WithValue(ctx, actorKey, actorID)
+}
+
+// ActorFromContext — impersonatsiya qilayotgan xodim ID si (oddiy so'rovlarda bo'sh)
+func ActorFromContext(ctx context.Context) (string, bool) {
+	actorID, ok := ctx.Value(actorKey).(string)
+	return actorID, ok && actorID != ""
+}
@@ -0,0 +1,89 @@
+package utils
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net"
+	"net/http"
+	"sync"
+	"time"
+
+	"user-service/internal/domain"
+)
+
+const (
+	geoIPCacheTTL     = time.Hour
+	geoIPCacheEntries = 10000
+)
+
+type geoIPResponse struct {
+	Status      string `json:"status"` // success | fail
+	Country     string `json:"country"`
+	CountryCode string `json:"countryCode"`
+	Region      string `json:"regionName"`
+	City        string `json:"city"`
+	Query       string `json:"query"` // IP manzil
+}
+
+type geoCacheEntry struct {
+	loc       *domain.GeoLocation
+	expiresAt time.Time
+}
+
+// GeoIPLocator — ip-api.com orqali; natijalar xotirada keshlanadi (ip-api daqiqasiga ~45 so'rov beradi)
+type GeoIPLocator struct {
+	client *http.Client
+
+	mu    sync.Mutex
+	cache map[string]geoCacheEntry
+}
+
+func NewGeoIPLocator(timeout time.Duration) *GeoIPLocator {
+	return &GeoIPLocator{
+		client: &http.Client{Timeout: timeout},
+		cache:  make(map[string]geoCacheEntry),
+	}
+}
+
+func (g *GeoIPLocator) Locate(ctx context.Context, ip string) (*domain.GeoLocation, error) {
+	parsed := net.ParseIP(ip)
+	if parsed == nil || parsed.IsLoopback() || parsed.IsPrivate() || parsed.IsUnspecified() {
+		return nil, nil
+	}
+
+	g.mu.Lock()
+	if e, ok := g.cache[ip]; ok && time.Now().Before(e.expiresAt) {
+		g.mu.Unlock()
+		return e.loc, nil
+	}
+	g.mu.Unlock()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, fmt.Sprintf("http://ip-api.com/json/%s", ip), nil)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := g.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	var data geoIPResponse
+	if err := json.NewDecoder(resp.Body).Decode(&data); err != nil {
+		return nil, err
+	}
+
+	var loc *domain.GeoLocation
+	if data.Status == "success" {
+		loc = &domain.GeoLocation{Country: data.Country, CountryCode: data.CountryCode, City: data.City}
+	}
+
+	g.mu.Lock()
+	if len(g.cache) >= geoIPCacheEntries {
+		clear(g.cache)
+	}
+	g.cache[ip] = geoCacheEntry{loc: loc, expiresAt: time.Now().Add(geoIPCacheTTL)}
+	g.mu.Unlock()
+	return loc, nil
+}
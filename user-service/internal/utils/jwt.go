@@ -4,24 +4,30 @@ package utils
 import (
 	"context"
 	"errors"
+	"fmt"
 	"time"
 
+	"user-service/internal/domain"
+
 	"github.com/go-redis/redis/v8"
 	"github.com/golang-jwt/jwt/v5"
 	"github.com/google/uuid"
 )
 
+// JWTProvider — access tokenlar asimmetrik kalit (RS256/EdDSA, kid bilan) bilan imzolanadi,
+// shuning uchun boshqa servislar ularni JWKS orqali offline tekshira oladi.
+// Refresh tokenlarni faqat shu servis o'qiydi, ular HS256 da qoladi.
 type JWTProvider struct {
-	accessSecret  []byte
+	keys          *KeySet
 	refreshSecret []byte
 	accessTTL     time.Duration
 	refreshTTL    time.Duration
 	redis         *redis.Client
 }
 
-func NewJWTProvider(accessSecret, refreshSecret string, accessTTL, refreshTTL time.Duration, r *redis.Client) *JWTProvider {
+func NewJWTProvider(keys *KeySet, refreshSecret string, accessTTL, refreshTTL time.Duration, r *redis.Client) *JWTProvider {
 	return &JWTProvider{
-		accessSecret:  []byte(accessSecret),
+		keys:          keys,
 		refreshSecret: []byte(refreshSecret),
 		accessTTL:     accessTTL,
 		refreshTTL:    refreshTTL,
@@ -29,66 +35,288 @@ func NewJWTProvider(accessSecret, refreshSecret string, accessTTL, refreshTTL ti
 	}
 }
 
-func (p *JWTProvider) GenerateTokens(userID string) (string, string, error) {
+// issuedAtMsClaim — iat millisekundlarda: watermark bilan bir soniyada berilgan tokenlarni ajratish uchun
+// (standart iat faqat soniya aniqligida)
+const issuedAtMsClaim = "iat_ms"
+
+func (p *JWTProvider) GenerateTokens(userID, sessionID string, roles []string) (*domain.TokenPair, error) {
 	now := time.Now()
 	accessClaims := jwt.MapClaims{
-		"userID": userID,
-		"exp":     now.Add(p.accessTTL).Unix(),
-		"iat":     now.Unix(),
+		"userID":        userID,
+		"sid":           sessionID,
+		"jti":           uuid.New().String(),
+		"exp":           now.Add(p.accessTTL).Unix(),
+		"iat":           now.Unix(),
+		issuedAtMsClaim: now.UnixMilli(),
+	}
+	if len(roles) > 0 {
+		accessClaims["roles"] = roles
 	}
-	access := jwt.NewWithClaims(jwt.SigningMethodHS256, accessClaims)
-	accessStr, err := access.SignedString(p.accessSecret)
+	signingKey := p.keys.Active()
+	access := jwt.NewWithClaims(signingKey.Method, accessClaims)
+	access.Header["kid"] = signingKey.ID
+	accessStr, err := access.SignedString(signingKey.Private)
 	if err != nil {
-		return "", "", err
+		return nil, err
 	}
 
+	jti := uuid.New().String() // unique id for revocation
 	refreshClaims := jwt.MapClaims{
 		"userID": userID,
-		"exp":     now.Add(p.refreshTTL).Unix(),
-		"iat":     now.Unix(),
-		"jti":     uuid.New().String(), // unique id for revocation
+		"sid":    sessionID,
+		"exp":    now.Add(p.refreshTTL).Unix(),
+		"iat":    now.Unix(),
+		"jti":    jti,
 	}
 	refresh := jwt.NewWithClaims(jwt.SigningMethodHS256, refreshClaims)
 	refreshStr, err := refresh.SignedString(p.refreshSecret)
 	if err != nil {
-		return "", "", err
+		return nil, err
 	}
 
-	// save refresh token jti -> userID mapping in Redis (or store whole token)
-	// TTL equal to refreshTTL for automatic expiration
-	jti, _ := refreshClaims["jti"].(string)
+	// save refresh token jti -> userID mapping in Redis
+	// TTL equal to refreshTTL for automatic expiration.
+	// jti ni sessiya (token oilasi) to'plamiga ham qo'shamiz — reuse bo'lsa hammasini bekor qilish uchun
 	ctx := context.Background()
-	if err := p.redis.Set(ctx, "refresh:"+jti, userID, p.refreshTTL).Err(); err != nil {
-		return "", "", err
+	pipe := p.redis.TxPipeline()
+	pipe.Set(ctx, "refresh:"+jti, userID, p.refreshTTL)
+	if sessionID != "" {
+		pipe.SAdd(ctx, "refresh_family:"+sessionID, jti)
+		pipe.Expire(ctx, "refresh_family:"+sessionID, p.refreshTTL)
+	}
+	if _, err := pipe.Exec(ctx); err != nil {
+		return nil, err
 	}
 
-	// Optionally map token string -> jti for quick revoke by token string
-	return accessStr, refreshStr, nil
+	return &domain.TokenPair{
+		AccessToken:  accessStr,
+		RefreshToken: refreshStr,
+		RefreshJTI:   jti,
+	}, nil
 }
 
-func (p *JWTProvider) ValidateAccessToken(tokenStr string) (userID string, err error) {
+// GenerateImpersonationToken — act claimi RFC 8693 ko'rinishida ({"sub": actorID}).
+// sid o'rnida token jti ishlatiladi: RevokeSessionAccessTokens(jti) tokenni muddatidan oldin bekor qiladi.
+// Umr accessTTL bilan cheklanadi — bekor qilish kalitlari shundan keyin Redis dan o'chadi.
+func (p *JWTProvider) GenerateImpersonationToken(userID, actorID string, ttl time.Duration) (string, time.Time, error) {
+	ttl = min(ttl, p.accessTTL)
+	now := time.Now()
+	expiresAt := now.Add(ttl)
+	jti := uuid.New().String()
+	claims := jwt.MapClaims{
+		"userID":        userID,
+		"sid":           jti,
+		"jti":           jti,
+		"act":           map[string]string{"sub": actorID},
+		"exp":           expiresAt.Unix(),
+		"iat":           now.Unix(),
+		issuedAtMsClaim: now.UnixMilli(),
+	}
+	signingKey := p.keys.Active()
+	token := jwt.NewWithClaims(signingKey.Method, claims)
+	token.Header["kid"] = signingKey.ID
+	tokenStr, err := token.SignedString(signingKey.Private)
+	if err != nil {
+		return "", time.Time{}, err
+	}
+	return tokenStr, expiresAt, nil
+}
+
+func (p *JWTProvider) ValidateAccessToken(tokenStr string) (*domain.AccessClaims, error) {
 	token, err := jwt.Parse(tokenStr, func(t *jwt.Token) (interface{}, error) {
-		if _, ok := t.Method.(*jwt.SigningMethodHMAC); !ok {
+		kid, _ := t.Header["kid"].(string)
+		key, ok := p.keys.Lookup(kid)
+		if !ok {
+			return nil, errors.New("unknown key id")
+		}
+		if t.Method.Alg() != key.Method.Alg() {
 			return nil, errors.New("unexpected signing method")
 		}
-		return p.accessSecret, nil
-	})
+		return key.Public, nil
+	}, jwt.WithValidMethods([]string{jwt.SigningMethodRS256.Alg(), jwt.SigningMethodEdDSA.Alg()}))
 	if err != nil || !token.Valid {
-		return "", errors.New("invalid token")
+		return nil, errors.New("invalid token")
 	}
 
 	claims, ok := token.Claims.(jwt.MapClaims)
 	if !ok {
-		return "", errors.New("invalid claims")
+		return nil, errors.New("invalid claims")
 	}
-	userID, ok = claims["userID"].(string)
+	userID, ok := claims["userID"].(string)
 	if !ok {
-		return "", errors.New("invalid userID")
+		return nil, errors.New("invalid userID")
+	}
+	sessionID, _ := claims["sid"].(string)
+	jti, _ := claims["jti"].(string)
+	iat, err := claims.GetIssuedAt()
+	if err != nil || iat == nil {
+		return nil, errors.New("invalid iat")
+	}
+	exp, err := claims.GetExpirationTime()
+	if err != nil || exp == nil {
+		return nil, errors.New("invalid exp")
+	}
+
+	issuedAt := iat.Time
+	if ms, ok := claims[issuedAtMsClaim].(float64); ok {
+		issuedAt = time.UnixMilli(int64(ms))
+	}
+
+	if err := p.checkAccessRevocation(userID, sessionID, issuedAt); err != nil {
+		return nil, err
+	}
+
+	var roles []string
+	if list, ok := claims["roles"].([]interface{}); ok {
+		for _, r := range list {
+			if role, ok := r.(string); ok {
+				roles = append(roles, role)
+			}
+		}
+	}
+
+	var actorID string
+	if act, ok := claims["act"].(map[string]interface{}); ok {
+		actorID, _ = act["sub"].(string)
+	}
+
+	return &domain.AccessClaims{
+		UserID:    userID,
+		SessionID: sessionID,
+		JTI:       jti,
+		Roles:     roles,
+		ActorID:   actorID,
+		IssuedAt:  issuedAt,
+		ExpiresAt: exp.Time,
+	}, nil
+}
+
+// checkAccessRevocation — sessiya denylisti va foydalanuvchi "watermark"ini tekshiradi.
+// Redis xatosi bo'lsa token rad etiladi (fail closed).
+func (p *JWTProvider) checkAccessRevocation(userID, sessionID string, issuedAt time.Time) error {
+	ctx := context.Background()
+	pipe := p.redis.Pipeline()
+	sessionRevoked := pipe.Exists(ctx, "revoked_session:"+sessionID)
+	watermark := pipe.Get(ctx, "tokens_invalid_before:"+userID)
+	if _, err := pipe.Exec(ctx); err != nil && err != redis.Nil {
+		return fmt.Errorf("%w: revocation lookup: %v", domain.ErrServiceUnavailable, err)
+	}
+
+	if sessionID != "" && sessionRevoked.Val() > 0 {
+		return errors.New("session revoked")
+	}
+	if ts, err := watermark.Int64(); err == nil && issuedAt.UnixMilli() < watermarkMillis(ts) {
+		return errors.New("token revoked")
+	}
+	return nil
+}
+
+// RevokeSessionAccessTokens — sessiyaga tegishli barcha access tokenlarni darhol yaroqsiz qiladi.
+// Kalit access token umridan ortiq saqlanmaydi.
+func (p *JWTProvider) RevokeSessionAccessTokens(sessionID string) error {
+	if sessionID == "" {
+		return nil
+	}
+	return p.redis.Set(context.Background(), "revoked_session:"+sessionID, 1, p.accessTTL).Err()
+}
+
+// watermarkMillis — deploydan oldin soniyalarda yozilgan watermarklar accessTTL ichida
+// o'chib ketguncha millisekundga o'tkaziladi
+func watermarkMillis(ts int64) int64 {
+	if ts < 1e11 {
+		return ts * 1000
+	}
+	return ts
+}
+
+// RevokeUserAccessTokens — foydalanuvchining hozirgacha berilgan barcha access tokenlarini
+// yaroqsiz qiladi ("shu vaqtdan oldin berilgan tokenlar yaroqsiz" watermark, millisekundlarda).
+// Shu chaqiruvdan keyin berilgan token (hatto o'sha soniyada ham) yaroqli qoladi.
+func (p *JWTProvider) RevokeUserAccessTokens(userID string) error {
+	return p.redis.Set(context.Background(), "tokens_invalid_before:"+userID, time.Now().UnixMilli(), p.accessTTL).Err()
+}
+
+func (p *JWTProvider) ValidateRefreshToken(tokenStr string) (*domain.RefreshClaims, error) {
+	claims, err := p.parseRefreshToken(tokenStr)
+	if err != nil {
+		return nil, err
+	}
+
+	// check redis
+	ctx := context.Background()
+	val, err := p.redis.Get(ctx, "refresh:"+claims.JTI).Result()
+	if err == redis.Nil {
+		return nil, errors.New("revoked or not found")
+	}
+	if err != nil {
+		return nil, err
+	}
+	if val != claims.UserID {
+		return nil, errors.New("token mismatch")
+	}
+	return claims, nil
+}
+
+// consumeRefreshScript — refresh:<jti> ni o'chirish va refresh_used:<jti> ni yozish bitta
+// atomar qadam: oradagi xato yoki restartda iste'mol qilingan token "noma'lum" bo'lib qolmaydi.
+// 1 => iste'mol qilindi, -1 => avval ishlatilgan (reuse), 0 => noma'lum yoki muddati o'tgan
+var consumeRefreshScript = redis.NewScript(`
+if redis.call('DEL', KEYS[1]) == 1 then
+	redis.call('SET', KEYS[2], ARGV[1], 'PX', ARGV[2])
+	return 1
+end
+if redis.call('EXISTS', KEYS[2]) == 1 then
+	return -1
+end
+return 0
+`)
+
+// ConsumeRefreshToken — refresh tokenni bir martalik qilib iste'mol qiladi.
+// consumeRefreshScript parallel so'rovlardan faqat bittasini yutkazadi. Iste'mol qilingan jti
+// refresh_used:<jti> sifatida eslab qolinadi va u qayta kelsa butun oila (refresh_family:<sid>) bekor qilinadi.
+func (p *JWTProvider) ConsumeRefreshToken(tokenStr string) (*domain.RefreshClaims, error) {
+	claims, err := p.parseRefreshToken(tokenStr)
+	if err != nil {
+		return nil, err
+	}
+
+	ctx := context.Background()
+	keys := []string{"refresh:" + claims.JTI, "refresh_used:" + claims.JTI}
+	res, err := consumeRefreshScript.Run(ctx, p.redis, keys, claims.SessionID, p.refreshTTL.Milliseconds()).Int()
+	if err != nil {
+		return nil, err
+	}
+
+	switch res {
+	case 1:
+		return claims, nil
+	case -1:
+		if err := p.revokeFamily(ctx, claims.SessionID); err != nil {
+			return nil, err
+		}
+		return nil, &domain.RefreshTokenReuseError{UserID: claims.UserID, SessionID: claims.SessionID}
+	default:
+		return nil, domain.ErrInvalidRefreshToken
 	}
-	return userID, nil
 }
 
-func (p *JWTProvider) ValidateRefreshToken(tokenStr string) (string, error) {
+// revokeFamily — sessiyaga tegishli barcha refresh tokenlarni bekor qiladi
+func (p *JWTProvider) revokeFamily(ctx context.Context, sessionID string) error {
+	if sessionID == "" {
+		return nil
+	}
+	jtis, err := p.redis.SMembers(ctx, "refresh_family:"+sessionID).Result()
+	if err != nil {
+		return err
+	}
+	keys := []string{"refresh_family:" + sessionID}
+	for _, jti := range jtis {
+		keys = append(keys, "refresh:"+jti)
+	}
+	return p.redis.Del(ctx, keys...).Err()
+}
+
+func (p *JWTProvider) parseRefreshToken(tokenStr string) (*domain.RefreshClaims, error) {
 	token, err := jwt.Parse(tokenStr, func(t *jwt.Token) (interface{}, error) {
 		if _, ok := t.Method.(*jwt.SigningMethodHMAC); !ok {
 			return nil, errors.New("unexpected signing method")
@@ -96,35 +324,26 @@ func (p *JWTProvider) ValidateRefreshToken(tokenStr string) (string, error) {
 		return p.refreshSecret, nil
 	})
 	if err != nil || !token.Valid {
-		return "", errors.New("invalid token")
+		return nil, domain.ErrInvalidRefreshToken
 	}
 
 	claims, ok := token.Claims.(jwt.MapClaims)
 	if !ok {
-		return "", errors.New("invalid claims")
+		return nil, errors.New("invalid claims")
 	}
 	userID, ok := claims["userID"].(string)
 	if !ok {
-		return "", errors.New("invalid userID")
+		return nil, errors.New("invalid userID")
 	}
 	jti, ok := claims["jti"].(string)
 	if !ok {
-		return "", errors.New("jti missing")
-	}
-
-	// check redis
-	ctx := context.Background()
-	val, err := p.redis.Get(ctx, "refresh:"+jti).Result()
-	if err == redis.Nil {
-		return "", errors.New("revoked or not found")
-	}
-	if err != nil {
-		return "", err
+		return nil, errors.New("jti missing")
 	}
-	if val != userID {
-		return "", errors.New("token mismatch")
+	if typ, _ := claims["typ"].(string); typ == mfaTokenType {
+		return nil, domain.ErrInvalidRefreshToken
 	}
-	return userID, nil
+	sessionID, _ := claims["sid"].(string)
+	return &domain.RefreshClaims{UserID: userID, SessionID: sessionID, JTI: jti}, nil
 }
 
 func (p *JWTProvider) RevokeRefreshToken(tokenStr string) error {
@@ -137,3 +356,86 @@ func (p *JWTProvider) RevokeRefreshToken(tokenStr string) error {
 	jti := claims["jti"].(string)
 	return p.redis.Del(context.Background(), "refresh:"+jti).Err()
 }
+
+// RevokeRefreshJTI — sessiyada saqlangan jti bo'yicha refresh tokenni bekor qiladi
+func (p *JWTProvider) RevokeRefreshJTI(jti string) error {
+	if jti == "" {
+		return nil
+	}
+	return p.redis.Del(context.Background(), "refresh:"+jti).Err()
+}
+
+// JWKS — faol va grace-period dagi barcha tekshirish kalitlari
+func (p *JWTProvider) JWKS() []domain.JWK {
+	return p.keys.JWKS()
+}
+
+const (
+	mfaTokenType = "mfa"
+	mfaTokenTTL  = 5 * time.Minute
+)
+
+// GenerateMFAToken — parol tekshirilgandan keyin beriladigan qisqa muddatli challenge token.
+// U faqat VerifyMFA da ishlatiladi va muvaffaqiyatli tasdiqdan keyin bekor qilinadi.
+func (p *JWTProvider) GenerateMFAToken(challenge domain.MFAChallenge) (string, error) {
+	now := time.Now()
+	jti := uuid.New().String()
+	claims := jwt.MapClaims{
+		"typ":      mfaTokenType,
+		"userID":   challenge.UserID,
+		"jti":      jti,
+		"platform": challenge.Platform,
+		"deviceID": challenge.DeviceID,
+		"exp":      now.Add(mfaTokenTTL).Unix(),
+		"iat":      now.Unix(),
+	}
+	tokenStr, err := jwt.NewWithClaims(jwt.SigningMethodHS256, claims).SignedString(p.refreshSecret)
+	if err != nil {
+		return "", err
+	}
+	if err := p.redis.Set(context.Background(), "mfa:"+jti, challenge.UserID, mfaTokenTTL).Err(); err != nil {
+		return "", err
+	}
+	return tokenStr, nil
+}
+
+func (p *JWTProvider) ValidateMFAToken(tokenStr string) (*domain.MFAChallenge, error) {
+	token, err := jwt.Parse(tokenStr, func(t *jwt.Token) (interface{}, error) {
+		if _, ok := t.Method.(*jwt.SigningMethodHMAC); !ok {
+			return nil, errors.New("unexpected signing method")
+		}
+		return p.refreshSecret, nil
+	})
+	if err != nil || !token.Valid {
+		return nil, domain.ErrInvalidMFAToken
+	}
+
+	claims, ok := token.Claims.(jwt.MapClaims)
+	if !ok {
+		return nil, domain.ErrInvalidMFAToken
+	}
+	if typ, _ := claims["typ"].(string); typ != mfaTokenType {
+		return nil, domain.ErrInvalidMFAToken
+	}
+	userID, _ := claims["userID"].(string)
+	jti, _ := claims["jti"].(string)
+	if userID == "" || jti == "" {
+		return nil, domain.ErrInvalidMFAToken
+	}
+
+	val, err := p.redis.Get(context.Background(), "mfa:"+jti).Result()
+	if err == redis.Nil || (err == nil && val != userID) {
+		return nil, domain.ErrInvalidMFAToken
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	platform, _ := claims["platform"].(string)
+	deviceID, _ := claims["deviceID"].(string)
+	return &domain.MFAChallenge{UserID: userID, JTI: jti, Platform: platform, DeviceID: deviceID}, nil
+}
+
+func (p *JWTProvider) RevokeMFAToken(jti string) error {
+	return p.redis.Del(context.Background(), "mfa:"+jti).Err()
+}
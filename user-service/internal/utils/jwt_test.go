@@ -0,0 +1,121 @@
+package utils
+
+import (
+	"context"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/go-redis/redis/v8"
+)
+
+// newTestJWTProvider — vaqtinchalik kalit va xotiradagi Redis (miniredis) bilan
+func newTestJWTProvider(t *testing.T, accessTTL time.Duration) *JWTProvider {
+	p, _ := newTestJWTProviderWithRedis(t, accessTTL)
+	return p
+}
+
+func newTestJWTProviderWithRedis(t *testing.T, accessTTL time.Duration) (*JWTProvider, *miniredis.Miniredis) {
+	t.Helper()
+	key, err := GenerateEphemeralKey()
+	if err != nil {
+		t.Fatal(err)
+	}
+	keys, err := NewKeySet(key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	mr := miniredis.RunT(t)
+	client := redis.NewClient(&redis.Options{Addr: mr.Addr()})
+	t.Cleanup(func() { client.Close() })
+	return NewJWTProvider(keys, "refresh-secret", accessTTL, time.Hour, client), mr
+}
+
+func TestImpersonationTokenNeverOutlivesRevocationWindow(t *testing.T) {
+	const accessTTL = 15 * time.Minute
+	p := newTestJWTProvider(t, accessTTL)
+
+	tests := []struct {
+		name string
+		ttl  time.Duration
+		want time.Duration
+	}{
+		{"shorter than access ttl", 10 * time.Minute, 10 * time.Minute},
+		{"equal to access ttl", accessTTL, accessTTL},
+		{"longer than access ttl", 24 * time.Hour, accessTTL},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			before := time.Now()
+			_, expiresAt, err := p.GenerateImpersonationToken("user-1", "admin-1", tt.ttl)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if lifetime := expiresAt.Sub(before); lifetime < tt.want-time.Second || lifetime > tt.want+time.Second {
+				t.Errorf("lifetime = %s, want %s", lifetime, tt.want)
+			}
+		})
+	}
+}
+
+func TestRevokeUserAccessTokensSameSecond(t *testing.T) {
+	p := newTestJWTProvider(t, 15*time.Minute)
+
+	before, err := p.GenerateTokens("user-1", "session-1", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	time.Sleep(2 * time.Millisecond)
+	if err := p.RevokeUserAccessTokens("user-1"); err != nil {
+		t.Fatal(err)
+	}
+	time.Sleep(2 * time.Millisecond)
+	// Parol o'zgargandan keyin darhol berilgan token — odatda o'sha soniyada
+	after, err := p.GenerateTokens("user-1", "session-2", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := p.ValidateAccessToken(before.AccessToken); err == nil {
+		t.Error("token issued before the watermark is still valid")
+	}
+	claims, err := p.ValidateAccessToken(after.AccessToken)
+	if err != nil {
+		t.Fatalf("token issued after the watermark rejected: %v", err)
+	}
+	if claims.IssuedAt.Nanosecond()%int(time.Millisecond) != 0 || claims.IssuedAt.Before(time.Now().Add(-time.Second)) {
+		t.Errorf("IssuedAt = %s, want millisecond precision issue time", claims.IssuedAt)
+	}
+}
+
+func TestLegacySecondsWatermark(t *testing.T) {
+	p, mr := newTestJWTProviderWithRedis(t, 15*time.Minute)
+	tokens, err := p.GenerateTokens("user-1", "session-1", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Deploydan oldin yozilgan soniyalardagi watermark
+	mr.Set("tokens_invalid_before:user-1", strconv.FormatInt(time.Now().Add(-time.Minute).Unix(), 10))
+	if _, err := p.ValidateAccessToken(tokens.AccessToken); err != nil {
+		t.Errorf("token newer than legacy watermark rejected: %v", err)
+	}
+	mr.Set("tokens_invalid_before:user-1", strconv.FormatInt(time.Now().Add(time.Minute).Unix(), 10))
+	if _, err := p.ValidateAccessToken(tokens.AccessToken); err == nil {
+		t.Error("token older than legacy watermark accepted")
+	}
+}
+
+func TestWatermarkExpiresWithAccessTTL(t *testing.T) {
+	p, mr := newTestJWTProviderWithRedis(t, 15*time.Minute)
+	if err := p.RevokeUserAccessTokens("user-1"); err != nil {
+		t.Fatal(err)
+	}
+	if ttl := mr.TTL("tokens_invalid_before:user-1"); ttl != 15*time.Minute {
+		t.Errorf("watermark ttl = %s, want access ttl", ttl)
+	}
+	if _, err := p.redis.Get(context.Background(), "tokens_invalid_before:user-1").Int64(); err != nil {
+		t.Errorf("watermark not an integer: %v", err)
+	}
+}
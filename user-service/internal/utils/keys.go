@@ -0,0 +1,166 @@
+package utils
+
+import (
+	"crypto"
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"math/big"
+	"os"
+	"strings"
+
+	"user-service/internal/domain"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+// SigningKey — kid bilan aniqlanadigan asimmetrik kalit (RS256 yoki EdDSA).
+// Faqat tekshirish uchun yuklangan kalitlarda Private nil bo'ladi.
+type SigningKey struct {
+	ID      string
+	Method  jwt.SigningMethod
+	Private crypto.Signer
+	Public  crypto.PublicKey
+}
+
+// KeySet — faol imzolash kaliti va grace-period davomida hali qabul qilinadigan eski kalitlar
+type KeySet struct {
+	active *SigningKey
+	keys   map[string]*SigningKey
+}
+
+func NewKeySet(active *SigningKey, verification ...*SigningKey) (*KeySet, error) {
+	if active == nil || active.Private == nil {
+		return nil, errors.New("active signing key must have a private key")
+	}
+	ks := &KeySet{
+		active: active,
+		keys:   map[string]*SigningKey{active.ID: active},
+	}
+	for _, k := range verification {
+		if _, exists := ks.keys[k.ID]; exists {
+			return nil, fmt.Errorf("duplicate key id: %s", k.ID)
+		}
+		ks.keys[k.ID] = k
+	}
+	return ks, nil
+}
+
+// Active — yangi tokenlar shu kalit bilan imzolanadi
+func (ks *KeySet) Active() *SigningKey {
+	return ks.active
+}
+
+// Lookup — token headeridagi kid bo'yicha tekshirish kalitini qaytaradi
+func (ks *KeySet) Lookup(kid string) (*SigningKey, bool) {
+	k, ok := ks.keys[kid]
+	return k, ok
+}
+
+// JWKS — barcha ochiq kalitlarni JWK ko'rinishida qaytaradi (faol kalit birinchi)
+func (ks *KeySet) JWKS() []domain.JWK {
+	jwks := []domain.JWK{toJWK(ks.active)}
+	for kid, k := range ks.keys {
+		if kid == ks.active.ID {
+			continue
+		}
+		jwks = append(jwks, toJWK(k))
+	}
+	return jwks
+}
+
+// LoadSigningKey — PEM fayldan kalitni o'qiydi. Private kalit (PKCS#1 / PKCS#8)
+// yoki faqat public kalit (PKIX / PKCS#1) bo'lishi mumkin.
+func LoadSigningKey(kid, path string) (*SigningKey, error) {
+	if kid == "" {
+		return nil, errors.New("key id is required")
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	block, _ := pem.Decode(data)
+	if block == nil {
+		return nil, fmt.Errorf("%s: no PEM block found", path)
+	}
+
+	var key any
+	switch block.Type {
+	case "RSA PRIVATE KEY":
+		key, err = x509.ParsePKCS1PrivateKey(block.Bytes)
+	case "PRIVATE KEY":
+		key, err = x509.ParsePKCS8PrivateKey(block.Bytes)
+	case "RSA PUBLIC KEY":
+		key, err = x509.ParsePKCS1PublicKey(block.Bytes)
+	case "PUBLIC KEY":
+		key, err = x509.ParsePKIXPublicKey(block.Bytes)
+	default:
+		return nil, fmt.Errorf("%s: unsupported PEM block %q", path, block.Type)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+
+	switch k := key.(type) {
+	case *rsa.PrivateKey:
+		return &SigningKey{ID: kid, Method: jwt.SigningMethodRS256, Private: k, Public: &k.PublicKey}, nil
+	case *rsa.PublicKey:
+		return &SigningKey{ID: kid, Method: jwt.SigningMethodRS256, Public: k}, nil
+	case ed25519.PrivateKey:
+		return &SigningKey{ID: kid, Method: jwt.SigningMethodEdDSA, Private: k, Public: k.Public()}, nil
+	case ed25519.PublicKey:
+		return &SigningKey{ID: kid, Method: jwt.SigningMethodEdDSA, Public: k}, nil
+	}
+	return nil, fmt.Errorf("%s: unsupported key type %T", path, key)
+}
+
+// ParseVerificationKeys — "kid1=/path/a.pem,kid2=/path/b.pem" ko'rinishidagi ro'yxatni yuklaydi
+func ParseVerificationKeys(spec string) ([]*SigningKey, error) {
+	var keys []*SigningKey
+	for _, item := range strings.Split(spec, ",") {
+		item = strings.TrimSpace(item)
+		if item == "" {
+			continue
+		}
+		kid, path, ok := strings.Cut(item, "=")
+		if !ok {
+			return nil, fmt.Errorf("invalid verification key entry: %q", item)
+		}
+		k, err := LoadSigningKey(strings.TrimSpace(kid), strings.TrimSpace(path))
+		if err != nil {
+			return nil, err
+		}
+		keys = append(keys, k)
+	}
+	return keys, nil
+}
+
+// GenerateEphemeralKey — konfiguratsiyada kalit berilmaganda (lokal ishlab chiqish) ishlatiladi.
+// Servis qayta ishga tushganda barcha access tokenlar yaroqsiz bo'lib qoladi.
+func GenerateEphemeralKey() (*SigningKey, error) {
+	pub, priv, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		return nil, err
+	}
+	return &SigningKey{ID: "ephemeral", Method: jwt.SigningMethodEdDSA, Private: priv, Public: pub}, nil
+}
+
+func toJWK(k *SigningKey) domain.JWK {
+	jwk := domain.JWK{Kid: k.ID, Use: "sig", Alg: k.Method.Alg()}
+	switch pub := k.Public.(type) {
+	case *rsa.PublicKey:
+		jwk.Kty = "RSA"
+		jwk.N = base64.RawURLEncoding.EncodeToString(pub.N.Bytes())
+		jwk.E = base64.RawURLEncoding.EncodeToString(big.NewInt(int64(pub.E)).Bytes())
+	case ed25519.PublicKey:
+		jwk.Kty = "OKP"
+		jwk.Crv = "Ed25519"
+		jwk.X = base64.RawURLEncoding.EncodeToString(pub)
+	}
+	return jwk
+}
@@ -0,0 +1,255 @@
+package utils
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/pem"
+	"math/big"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+func writePEM(t *testing.T, dir, name, blockType string, der []byte) string {
+	t.Helper()
+	path := filepath.Join(dir, name)
+	if err := os.WriteFile(path, pem.EncodeToMemory(&pem.Block{Type: blockType, Bytes: der}), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+// mustDER — x509.Marshal* natijasini xatosiz qaytaradi
+func mustDER(t *testing.T) func([]byte, error) []byte {
+	return func(der []byte, err error) []byte {
+		t.Helper()
+		if err != nil {
+			t.Fatal(err)
+		}
+		return der
+	}
+}
+
+func TestLoadSigningKey(t *testing.T) {
+	dir := t.TempDir()
+	rsaKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+	edPub, edPriv, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	der := mustDER(t)
+	tests := []struct {
+		name        string
+		path        string
+		wantMethod  jwt.SigningMethod
+		wantPrivate bool
+	}{
+		{"rsa pkcs1 private", writePEM(t, dir, "rsa1.pem", "RSA PRIVATE KEY", x509.MarshalPKCS1PrivateKey(rsaKey)), jwt.SigningMethodRS256, true},
+		{"rsa pkcs8 private", writePEM(t, dir, "rsa8.pem", "PRIVATE KEY", der(x509.MarshalPKCS8PrivateKey(rsaKey))), jwt.SigningMethodRS256, true},
+		{"rsa pkcs1 public", writePEM(t, dir, "rsa1.pub", "RSA PUBLIC KEY", x509.MarshalPKCS1PublicKey(&rsaKey.PublicKey)), jwt.SigningMethodRS256, false},
+		{"rsa pkix public", writePEM(t, dir, "rsa.pub", "PUBLIC KEY", der(x509.MarshalPKIXPublicKey(&rsaKey.PublicKey))), jwt.SigningMethodRS256, false},
+		{"ed25519 pkcs8 private", writePEM(t, dir, "ed.pem", "PRIVATE KEY", der(x509.MarshalPKCS8PrivateKey(edPriv))), jwt.SigningMethodEdDSA, true},
+		{"ed25519 pkix public", writePEM(t, dir, "ed.pub", "PUBLIC KEY", der(x509.MarshalPKIXPublicKey(edPub))), jwt.SigningMethodEdDSA, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			k, err := LoadSigningKey("kid-1", tt.path)
+			if err != nil {
+				t.Fatalf("LoadSigningKey: %v", err)
+			}
+			if k.ID != "kid-1" || k.Method != tt.wantMethod {
+				t.Errorf("key = %s/%s, want kid-1/%s", k.ID, k.Method.Alg(), tt.wantMethod.Alg())
+			}
+			if (k.Private != nil) != tt.wantPrivate {
+				t.Errorf("private = %v, want %v", k.Private != nil, tt.wantPrivate)
+			}
+			if k.Public == nil {
+				t.Error("public key missing")
+			}
+		})
+	}
+}
+
+func TestLoadSigningKeyRejects(t *testing.T) {
+	dir := t.TempDir()
+	certificate := writePEM(t, dir, "cert.pem", "CERTIFICATE", []byte("not a key"))
+	garbage := writePEM(t, dir, "garbage.pem", "PRIVATE KEY", []byte("garbage"))
+	noPEM := filepath.Join(dir, "plain.txt")
+	if err := os.WriteFile(noPEM, []byte("hello"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name, kid, path string
+	}{
+		{"empty kid", "", garbage},
+		{"missing file", "kid", filepath.Join(dir, "missing.pem")},
+		{"no pem block", "kid", noPEM},
+		{"unsupported block", "kid", certificate},
+		{"garbage der", "kid", garbage},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := LoadSigningKey(tt.kid, tt.path); err == nil {
+				t.Error("expected error")
+			}
+		})
+	}
+}
+
+func TestParseVerificationKeys(t *testing.T) {
+	dir := t.TempDir()
+	edPub, _, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	der := mustDER(t)
+	path := writePEM(t, dir, "old.pub", "PUBLIC KEY", der(x509.MarshalPKIXPublicKey(edPub)))
+
+	keys, err := ParseVerificationKeys(" old-1 = " + path + " ,, old-2=" + path)
+	if err != nil {
+		t.Fatalf("ParseVerificationKeys: %v", err)
+	}
+	if len(keys) != 2 || keys[0].ID != "old-1" || keys[1].ID != "old-2" {
+		t.Errorf("keys = %+v", keys)
+	}
+	if keys, err := ParseVerificationKeys(""); err != nil || len(keys) != 0 {
+		t.Errorf("empty spec = %v, %v", keys, err)
+	}
+	if _, err := ParseVerificationKeys(path); err == nil {
+		t.Error("entry without kid accepted")
+	}
+}
+
+func TestNewKeySetRejectsDuplicates(t *testing.T) {
+	active, err := GenerateEphemeralKey()
+	if err != nil {
+		t.Fatal(err)
+	}
+	old, err := GenerateEphemeralKey()
+	if err != nil {
+		t.Fatal(err)
+	}
+	old.ID = "old"
+	sameAsActive := &SigningKey{ID: active.ID, Method: old.Method, Public: old.Public}
+
+	if _, err := NewKeySet(active, sameAsActive); err == nil || !strings.Contains(err.Error(), "duplicate key id") {
+		t.Errorf("verification kid equal to active: err = %v", err)
+	}
+	if _, err := NewKeySet(active, old, old); err == nil {
+		t.Error("duplicate verification kids accepted")
+	}
+	if _, err := NewKeySet(&SigningKey{ID: "pub-only", Method: old.Method, Public: old.Public}); err == nil {
+		t.Error("active key without private key accepted")
+	}
+	if _, err := NewKeySet(nil); err == nil {
+		t.Error("nil active key accepted")
+	}
+}
+
+// TestGracePeriodKey — kalit almashtirilgandan keyin eski kalit bilan imzolangan token
+// grace-period ro'yxatida bo'lsa qabul qilinadi, ro'yxatdan chiqarilgach rad etiladi
+func TestGracePeriodKey(t *testing.T) {
+	oldKey, err := GenerateEphemeralKey()
+	if err != nil {
+		t.Fatal(err)
+	}
+	oldKey.ID = "2024-01"
+	newKey, err := GenerateEphemeralKey()
+	if err != nil {
+		t.Fatal(err)
+	}
+	newKey.ID = "2024-06"
+
+	before := newTestJWTProvider(t, 15*time.Minute)
+	before.keys, _ = NewKeySet(oldKey)
+	issued, err := before.GenerateTokens("user-1", "session-1", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	oldPublic := &SigningKey{ID: oldKey.ID, Method: oldKey.Method, Public: oldKey.Public}
+	rotated, err := NewKeySet(newKey, oldPublic)
+	if err != nil {
+		t.Fatal(err)
+	}
+	after := &JWTProvider{keys: rotated, refreshSecret: before.refreshSecret, accessTTL: before.accessTTL, refreshTTL: before.refreshTTL, redis: before.redis}
+	if _, err := after.ValidateAccessToken(issued.AccessToken); err != nil {
+		t.Errorf("token signed with grace-period key rejected: %v", err)
+	}
+	fresh, err := after.GenerateTokens("user-1", "session-2", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if kid := tokenKid(t, fresh.AccessToken); kid != newKey.ID {
+		t.Errorf("new tokens signed with kid %q, want %q", kid, newKey.ID)
+	}
+
+	retired, err := NewKeySet(newKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expired := &JWTProvider{keys: retired, redis: before.redis}
+	if _, err := expired.ValidateAccessToken(issued.AccessToken); err == nil {
+		t.Error("token signed with retired key accepted")
+	}
+}
+
+func tokenKid(t *testing.T, tokenStr string) string {
+	t.Helper()
+	token, _, err := jwt.NewParser().ParseUnverified(tokenStr, jwt.MapClaims{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	kid, _ := token.Header["kid"].(string)
+	return kid
+}
+
+func TestJWKS(t *testing.T) {
+	rsaKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+	active := &SigningKey{ID: "rsa-active", Method: jwt.SigningMethodRS256, Private: rsaKey, Public: &rsaKey.PublicKey}
+	edPub, _, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	old := &SigningKey{ID: "ed-old", Method: jwt.SigningMethodEdDSA, Public: edPub}
+
+	ks, err := NewKeySet(active, old)
+	if err != nil {
+		t.Fatal(err)
+	}
+	jwks := ks.JWKS()
+	if len(jwks) != 2 || jwks[0].Kid != "rsa-active" || jwks[1].Kid != "ed-old" {
+		t.Fatalf("jwks = %+v, want active key first", jwks)
+	}
+
+	r := jwks[0]
+	if r.Kty != "RSA" || r.Alg != "RS256" || r.Use != "sig" {
+		t.Errorf("rsa jwk = %+v", r)
+	}
+	n, _ := base64.RawURLEncoding.DecodeString(r.N)
+	e, _ := base64.RawURLEncoding.DecodeString(r.E)
+	if new(big.Int).SetBytes(n).Cmp(rsaKey.N) != 0 || int(new(big.Int).SetBytes(e).Int64()) != rsaKey.E {
+		t.Error("rsa jwk n/e do not match the public key")
+	}
+
+	o := jwks[1]
+	x, _ := base64.RawURLEncoding.DecodeString(o.X)
+	if o.Kty != "OKP" || o.Crv != "Ed25519" || o.Alg != "EdDSA" || !ed25519.PublicKey(x).Equal(edPub) {
+		t.Errorf("ed25519 jwk = %+v", o)
+	}
+}
@@ -1,19 +1,136 @@
 package utils
 
 import (
-	"golang.org/x/crypto/bcrypt"
+	"crypto/rand"
+	"crypto/subtle"
+	"encoding/base64"
 	"errors"
+	"fmt"
+	"strings"
+
+	"user-service/internal/domain"
+
+	"golang.org/x/crypto/argon2"
+	"golang.org/x/crypto/bcrypt"
 )
 
-func HashPassword(password string) (string, error) {
-	bytes, err := bcrypt.GenerateFromPassword([]byte(password), bcrypt.DefaultCost)
-	return string(bytes), err
+// Argon2Params — Argon2id xarajat parametrlari (hash ichida ham saqlanadi)
+type Argon2Params struct {
+	Memory      uint32 // KiB
+	Iterations  uint32
+	Parallelism uint8
+	SaltLength  uint32
+	KeyLength   uint32
+}
+
+// DefaultArgon2Params — OWASP tavsiyasi darajasida (64 MiB, 3 iteratsiya)
+var DefaultArgon2Params = Argon2Params{
+	Memory:      64 * 1024,
+	Iterations:  3,
+	Parallelism: 2,
+	SaltLength:  16,
+	KeyLength:   32,
+}
+
+var errInvalidHash = errors.New("invalid password hash format")
+
+type passwordHasher struct {
+	params Argon2Params
 }
 
-func CheckPasswordHash(password, hashed string) error {
-	err := bcrypt.CompareHashAndPassword([]byte(hashed), []byte(password))
+// NewPasswordHasher — yangi hashlar Argon2id; mavjud bcrypt hashlar ham tekshiriladi
+// (ular needsRehash=true bilan qaytadi)
+func NewPasswordHasher(params Argon2Params) domain.PasswordHasher {
+	return &passwordHasher{params: params}
+}
+
+// Hash — $argon2id$v=19$m=65536,t=3,p=2$<salt>$<hash> (PHC string format)
+func (h *passwordHasher) Hash(password string) (string, error) {
+	salt := make([]byte, h.params.SaltLength)
+	if _, err := rand.Read(salt); err != nil {
+		return "", err
+	}
+	key := argon2.IDKey([]byte(password), salt, h.params.Iterations, h.params.Memory, h.params.Parallelism, h.params.KeyLength)
+
+	return fmt.Sprintf("$argon2id$v=%d$m=%d,t=%d,p=%d$%s$%s",
+		argon2.Version, h.params.Memory, h.params.Iterations, h.params.Parallelism,
+		base64.RawStdEncoding.EncodeToString(salt),
+		base64.RawStdEncoding.EncodeToString(key),
+	), nil
+}
+
+func (h *passwordHasher) Verify(password, encoded string) (bool, bool, error) {
+	switch {
+	case encoded == "":
+		// Parolsiz hisob (masalan, faqat OAuth orqali yaratilgan)
+		return false, false, nil
+	case strings.HasPrefix(encoded, "$argon2id$"):
+		return h.verifyArgon2(password, encoded)
+	case strings.HasPrefix(encoded, "$2a$"), strings.HasPrefix(encoded, "$2b$"), strings.HasPrefix(encoded, "$2y$"):
+		err := bcrypt.CompareHashAndPassword([]byte(encoded), []byte(password))
+		if errors.Is(err, bcrypt.ErrMismatchedHashAndPassword) {
+			return false, false, nil
+		}
+		if err != nil {
+			return false, false, err
+		}
+		return true, true, nil
+	}
+	return false, false, errInvalidHash
+}
+
+func (h *passwordHasher) verifyArgon2(password, encoded string) (bool, bool, error) {
+	params, salt, key, err := decodeArgon2(encoded)
 	if err != nil {
-		return errors.New("invalid password")
+		return false, false, err
+	}
+
+	candidate := argon2.IDKey([]byte(password), salt, params.Iterations, params.Memory, params.Parallelism, params.KeyLength)
+	if subtle.ConstantTimeCompare(candidate, key) != 1 {
+		return false, false, nil
+	}
+
+	needsRehash := params.Memory != h.params.Memory ||
+		params.Iterations != h.params.Iterations ||
+		params.Parallelism != h.params.Parallelism ||
+		params.KeyLength != h.params.KeyLength ||
+		params.SaltLength < h.params.SaltLength
+	return true, needsRehash, nil
+}
+
+func decodeArgon2(encoded string) (Argon2Params, []byte, []byte, error) {
+	// "", "argon2id", "v=19", "m=...,t=...,p=...", salt, hash
+	parts := strings.Split(encoded, "$")
+	if len(parts) != 6 {
+		return Argon2Params{}, nil, nil, errInvalidHash
+	}
+
+	var version int
+	if _, err := fmt.Sscanf(parts[2], "v=%d", &version); err != nil || version != argon2.Version {
+		return Argon2Params{}, nil, nil, errInvalidHash
+	}
+
+	var p Argon2Params
+	if _, err := fmt.Sscanf(parts[3], "m=%d,t=%d,p=%d", &p.Memory, &p.Iterations, &p.Parallelism); err != nil {
+		return Argon2Params{}, nil, nil, errInvalidHash
+	}
+	// Sscanf ortiqcha qoldiqni e'tiborsiz qoldiradi — qayta formatlab aynan mosligini tekshiramiz
+	if parts[3] != fmt.Sprintf("m=%d,t=%d,p=%d", p.Memory, p.Iterations, p.Parallelism) {
+		return Argon2Params{}, nil, nil, errInvalidHash
+	}
+	if p.Memory == 0 || p.Iterations == 0 || p.Parallelism == 0 {
+		return Argon2Params{}, nil, nil, errInvalidHash
+	}
+
+	salt, err := base64.RawStdEncoding.DecodeString(parts[4])
+	if err != nil || len(salt) == 0 {
+		return Argon2Params{}, nil, nil, errInvalidHash
+	}
+	key, err := base64.RawStdEncoding.DecodeString(parts[5])
+	if err != nil || len(key) == 0 {
+		return Argon2Params{}, nil, nil, errInvalidHash
 	}
-	return nil
+	p.SaltLength = uint32(len(salt))
+	p.KeyLength = uint32(len(key))
+	return p, salt, key, nil
 }
@@ -0,0 +1,228 @@
+package utils
+
+import (
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"testing"
+
+	"golang.org/x/crypto/bcrypt"
+)
+
+// testParams — testlar tez ishlashi uchun yengil parametrlar
+var testParams = Argon2Params{Memory: 1024, Iterations: 1, Parallelism: 1, SaltLength: 16, KeyLength: 32}
+
+// configuredParams — benchmark uchun ARGON2_* env (config.LoadConfig bilan bir xil defaultlar)
+func configuredParams(b *testing.B) Argon2Params {
+	env := func(key string, def uint32) uint32 {
+		v := os.Getenv(key)
+		if v == "" {
+			return def
+		}
+		n, err := strconv.ParseUint(v, 10, 32)
+		if err != nil {
+			b.Fatalf("%s: %v", key, err)
+		}
+		return uint32(n)
+	}
+	p := DefaultArgon2Params
+	p.Memory = env("ARGON2_MEMORY_KIB", p.Memory)
+	p.Iterations = env("ARGON2_ITERATIONS", p.Iterations)
+	p.Parallelism = uint8(env("ARGON2_PARALLELISM", uint32(p.Parallelism)))
+	return p
+}
+
+func TestHashRoundTrip(t *testing.T) {
+	h := NewPasswordHasher(testParams)
+
+	encoded, err := h.Hash("correct horse battery staple")
+	if err != nil {
+		t.Fatalf("Hash: %v", err)
+	}
+	if !strings.HasPrefix(encoded, "$argon2id$v=19$m=1024,t=1,p=1$") {
+		t.Errorf("encoded = %q, want argon2id PHC string with configured params", encoded)
+	}
+
+	params, salt, key, err := decodeArgon2(encoded)
+	if err != nil {
+		t.Fatalf("decodeArgon2: %v", err)
+	}
+	if params != testParams {
+		t.Errorf("decoded params = %+v, want %+v", params, testParams)
+	}
+	if len(salt) != int(testParams.SaltLength) || len(key) != int(testParams.KeyLength) {
+		t.Errorf("salt/key length = %d/%d", len(salt), len(key))
+	}
+
+	ok, needsRehash, err := h.Verify("correct horse battery staple", encoded)
+	if err != nil || !ok || needsRehash {
+		t.Errorf("Verify(correct) = %v, %v, %v; want true, false, nil", ok, needsRehash, err)
+	}
+	ok, _, err = h.Verify("wrong password", encoded)
+	if err != nil || ok {
+		t.Errorf("Verify(wrong) = %v, %v; want false, nil", ok, err)
+	}
+
+	again, err := h.Hash("correct horse battery staple")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if again == encoded {
+		t.Error("two hashes of the same password are identical (salt reused)")
+	}
+}
+
+func TestVerifyNeedsRehash(t *testing.T) {
+	const password = "correct horse battery staple"
+
+	bcryptHash, err := bcrypt.GenerateFromPassword([]byte(password), bcrypt.MinCost)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name    string
+		hashed  func(t *testing.T) string
+		current Argon2Params
+	}{
+		{
+			name:    "legacy bcrypt",
+			hashed:  func(t *testing.T) string { return string(bcryptHash) },
+			current: testParams,
+		},
+		{
+			name:    "memory changed",
+			hashed:  hashWith(testParams, password),
+			current: withParams(func(p *Argon2Params) { p.Memory = 2048 }),
+		},
+		{
+			name:    "iterations changed",
+			hashed:  hashWith(testParams, password),
+			current: withParams(func(p *Argon2Params) { p.Iterations = 2 }),
+		},
+		{
+			name:    "parallelism changed",
+			hashed:  hashWith(testParams, password),
+			current: withParams(func(p *Argon2Params) { p.Parallelism = 2 }),
+		},
+		{
+			name:    "key length changed",
+			hashed:  hashWith(testParams, password),
+			current: withParams(func(p *Argon2Params) { p.KeyLength = 64 }),
+		},
+		{
+			name:    "shorter salt",
+			hashed:  hashWith(withParams(func(p *Argon2Params) { p.SaltLength = 8 }), password),
+			current: testParams,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ok, needsRehash, err := NewPasswordHasher(tt.current).Verify(password, tt.hashed(t))
+			if err != nil || !ok {
+				t.Fatalf("Verify = %v, %v; want true, nil", ok, err)
+			}
+			if !needsRehash {
+				t.Error("needsRehash = false, want true")
+			}
+		})
+	}
+}
+
+func TestVerifyRejectsMalformedHash(t *testing.T) {
+	h := NewPasswordHasher(testParams)
+	valid, err := h.Hash("password")
+	if err != nil {
+		t.Fatal(err)
+	}
+	parts := strings.Split(valid, "$")
+	salt, key := parts[4], parts[5]
+	phc := func(version, params, salt, key string) string {
+		return fmt.Sprintf("$argon2id$%s$%s$%s$%s", version, params, salt, key)
+	}
+
+	tests := []struct {
+		name    string
+		encoded string
+	}{
+		{"unknown scheme", "$scrypt$ln=15,r=8,p=1$c2FsdA$aGFzaA"},
+		{"plain text", "password"},
+		{"too few sections", "$argon2id$v=19$m=1024,t=1,p=1$" + salt},
+		{"too many sections", valid + "$extra"},
+		{"wrong version", phc("v=16", "m=1024,t=1,p=1", salt, key)},
+		{"missing version", phc("19", "m=1024,t=1,p=1", salt, key)},
+		{"params garbled", phc("v=19", "m=abc,t=1,p=1", salt, key)},
+		{"params missing parallelism", phc("v=19", "m=1024,t=1", salt, key)},
+		{"zero memory", phc("v=19", "m=0,t=1,p=1", salt, key)},
+		{"zero iterations", phc("v=19", "m=1024,t=0,p=1", salt, key)},
+		{"zero parallelism", phc("v=19", "m=1024,t=1,p=0", salt, key)},
+		{"parallelism overflow", phc("v=19", "m=1024,t=1,p=256", salt, key)},
+		{"negative memory", phc("v=19", "m=-1,t=1,p=1", salt, key)},
+		{"trailing params", phc("v=19", "m=1024,t=1,p=1,x=2", salt, key)},
+		{"salt not base64", phc("v=19", "m=1024,t=1,p=1", "!!!", key)},
+		{"padded salt", phc("v=19", "m=1024,t=1,p=1", base64.StdEncoding.EncodeToString([]byte("salt1")), key)},
+		{"empty salt", phc("v=19", "m=1024,t=1,p=1", "", key)},
+		{"key not base64", phc("v=19", "m=1024,t=1,p=1", salt, "!!!")},
+		{"empty key", phc("v=19", "m=1024,t=1,p=1", salt, "")},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ok, needsRehash, err := h.Verify("password", tt.encoded)
+			if !errors.Is(err, errInvalidHash) {
+				t.Errorf("err = %v, want errInvalidHash", err)
+			}
+			if ok || needsRehash {
+				t.Errorf("Verify = %v, %v; want false, false", ok, needsRehash)
+			}
+		})
+	}
+}
+
+func TestVerifyPasswordlessAccount(t *testing.T) {
+	ok, needsRehash, err := NewPasswordHasher(testParams).Verify("anything", "")
+	if ok || needsRehash || err != nil {
+		t.Errorf("Verify(empty hash) = %v, %v, %v; want false, false, nil", ok, needsRehash, err)
+	}
+}
+
+func BenchmarkHash(b *testing.B) {
+	h := NewPasswordHasher(configuredParams(b))
+	for i := 0; i < b.N; i++ {
+		if _, err := h.Hash("correct horse battery staple"); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkVerify(b *testing.B) {
+	h := NewPasswordHasher(configuredParams(b))
+	encoded, err := h.Hash("correct horse battery staple")
+	if err != nil {
+		b.Fatal(err)
+	}
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if ok, _, err := h.Verify("correct horse battery staple", encoded); err != nil || !ok {
+			b.Fatalf("Verify = %v, %v", ok, err)
+		}
+	}
+}
+
+func hashWith(params Argon2Params, password string) func(t *testing.T) string {
+	return func(t *testing.T) string {
+		encoded, err := NewPasswordHasher(params).Hash(password)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return encoded
+	}
+}
+
+func withParams(change func(p *Argon2Params)) Argon2Params {
+	p := testParams
+	change(&p)
+	return p
+}
@@ -0,0 +1,148 @@
+package utils
+
+import (
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"user-service/internal/domain"
+)
+
+func TestRefreshRotation(t *testing.T) {
+	p, mr := newTestJWTProviderWithRedis(t, 15*time.Minute)
+
+	first, err := p.GenerateTokens("user-1", "session-1", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	claims, err := p.ConsumeRefreshToken(first.RefreshToken)
+	if err != nil {
+		t.Fatalf("ConsumeRefreshToken: %v", err)
+	}
+	if claims.UserID != "user-1" || claims.SessionID != "session-1" || claims.JTI != first.RefreshJTI {
+		t.Errorf("claims = %+v", claims)
+	}
+	if mr.Exists("refresh:" + first.RefreshJTI) {
+		t.Error("consumed jti still usable")
+	}
+	if !mr.Exists("refresh_used:" + first.RefreshJTI) {
+		t.Error("consumed jti not remembered for reuse detection")
+	}
+	if ttl := mr.TTL("refresh_used:" + first.RefreshJTI); ttl <= 0 || ttl > time.Hour {
+		t.Errorf("refresh_used ttl = %s, want refresh ttl", ttl)
+	}
+
+	// Rotatsiya: shu sessiyada yangi juftlik, u ham bir marta ishlaydi
+	second, err := p.GenerateTokens("user-1", "session-1", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := p.ConsumeRefreshToken(second.RefreshToken); err != nil {
+		t.Fatalf("rotated token: %v", err)
+	}
+}
+
+func TestRefreshReuseRevokesFamily(t *testing.T) {
+	p, mr := newTestJWTProviderWithRedis(t, 15*time.Minute)
+
+	first, err := p.GenerateTokens("user-1", "session-1", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := p.ConsumeRefreshToken(first.RefreshToken); err != nil {
+		t.Fatal(err)
+	}
+	// Qonuniy klient rotatsiya qilgan token — hujumchi eskisini qayta yuboradi
+	current, err := p.GenerateTokens("user-1", "session-1", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	other, err := p.GenerateTokens("user-1", "session-2", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	_, err = p.ConsumeRefreshToken(first.RefreshToken)
+	var reuse *domain.RefreshTokenReuseError
+	if !errors.As(err, &reuse) {
+		t.Fatalf("replay err = %v, want RefreshTokenReuseError", err)
+	}
+	if reuse.UserID != "user-1" || reuse.SessionID != "session-1" {
+		t.Errorf("reuse = %+v", reuse)
+	}
+	if mr.Exists("refresh_family:session-1") {
+		t.Error("refresh_family:session-1 not revoked")
+	}
+	if _, err := p.ConsumeRefreshToken(current.RefreshToken); err == nil {
+		t.Error("current token of the compromised family still works")
+	}
+	if _, err := p.ConsumeRefreshToken(other.RefreshToken); err != nil {
+		t.Errorf("token from another session revoked: %v", err)
+	}
+}
+
+func TestRefreshUnknownToken(t *testing.T) {
+	p, mr := newTestJWTProviderWithRedis(t, 15*time.Minute)
+	tokens, err := p.GenerateTokens("user-1", "session-1", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// Muddati o'tgan yoki logout da bekor qilingan — reuse emas
+	mr.Del("refresh:" + tokens.RefreshJTI)
+
+	if _, err := p.ConsumeRefreshToken(tokens.RefreshToken); !errors.Is(err, domain.ErrInvalidRefreshToken) {
+		t.Errorf("err = %v, want ErrInvalidRefreshToken", err)
+	}
+	if !mr.Exists("refresh_family:session-1") {
+		t.Error("family revoked for a token that was never consumed")
+	}
+	if _, err := p.ConsumeRefreshToken("not-a-jwt"); !errors.Is(err, domain.ErrInvalidRefreshToken) {
+		t.Errorf("garbage err = %v, want ErrInvalidRefreshToken", err)
+	}
+}
+
+func TestRefreshConcurrentConsume(t *testing.T) {
+	p, _ := newTestJWTProviderWithRedis(t, 15*time.Minute)
+	tokens, err := p.GenerateTokens("user-1", "session-1", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	const callers = 32
+	var (
+		wg      sync.WaitGroup
+		mu      sync.Mutex
+		winners int
+		reuses  int
+	)
+	start := make(chan struct{})
+	for i := 0; i < callers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			<-start
+			_, err := p.ConsumeRefreshToken(tokens.RefreshToken)
+			var reuse *domain.RefreshTokenReuseError
+			mu.Lock()
+			defer mu.Unlock()
+			switch {
+			case err == nil:
+				winners++
+			case errors.As(err, &reuse):
+				reuses++
+			default:
+				t.Errorf("unexpected err: %v", err)
+			}
+		}()
+	}
+	close(start)
+	wg.Wait()
+
+	if winners != 1 {
+		t.Errorf("%d callers consumed the same refresh token, want exactly 1", winners)
+	}
+	if reuses != callers-1 {
+		t.Errorf("%d reuse detections, want %d", reuses, callers-1)
+	}
+}
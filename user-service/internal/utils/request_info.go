@@ -1,9 +1,14 @@
 package utils
 
 import (
+	"context"
+	"fmt"
 	"net"
 	"net/http"
 	"strings"
+
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
 )
 
 // GetIP — foydalanuvchi IP manzilini olish
@@ -31,3 +36,97 @@ func GetIP(r *http.Request) string {
 func GetUserAgent(r *http.Request) string {
 	return r.UserAgent()
 }
+
+// TrustedProxies — x-forwarded-for faqat shu tarmoqlardagi peerdan (load balancer, ingress)
+// kelganda hisobga olinadi; boshqa klientlar sarlavhani o'zlari yozishi mumkin
+type TrustedProxies struct {
+	nets []*net.IPNet
+}
+
+// ParseTrustedProxies — CIDR yoki alohida IP ro'yxati ("10.0.0.0/8", "192.168.1.10")
+func ParseTrustedProxies(cidrs []string) (*TrustedProxies, error) {
+	t := &TrustedProxies{}
+	for _, cidr := range cidrs {
+		if !strings.Contains(cidr, "/") {
+			ip := net.ParseIP(cidr)
+			if ip == nil {
+				return nil, fmt.Errorf("invalid trusted proxy %q", cidr)
+			}
+			bits := 8 * net.IPv6len
+			if ip.To4() != nil {
+				ip, bits = ip.To4(), 8*net.IPv4len
+			}
+			t.nets = append(t.nets, &net.IPNet{IP: ip, Mask: net.CIDRMask(bits, bits)})
+			continue
+		}
+		_, network, err := net.ParseCIDR(cidr)
+		if err != nil {
+			return nil, fmt.Errorf("invalid trusted proxy %q: %w", cidr, err)
+		}
+		t.nets = append(t.nets, network)
+	}
+	return t, nil
+}
+
+func (t *TrustedProxies) trusted(ip net.IP) bool {
+	if t == nil || ip == nil {
+		return false
+	}
+	for _, n := range t.nets {
+		if n.Contains(ip) {
+			return true
+		}
+	}
+	return false
+}
+
+// ClientIP — TCP peer ishonchli proxy bo'lsa x-forwarded-for o'ngdan chapga o'qiladi va
+// birinchi ishonchsiz hop qaytariladi (chap tomondagi qiymatlarni klient soxtalashtirishi mumkin).
+// Aks holda peer manzili; topilmasa "".
+func (t *TrustedProxies) ClientIP(ctx context.Context) string {
+	p, ok := peer.FromContext(ctx)
+	if !ok || p.Addr == nil {
+		return ""
+	}
+	client := p.Addr.String()
+	if host, _, err := net.SplitHostPort(client); err == nil {
+		client = host
+	}
+	if !t.trusted(net.ParseIP(client)) {
+		return client
+	}
+
+	md, _ := metadata.FromIncomingContext(ctx)
+	// Bir nechta sarlavha bo'lsa ular ketma-ket qo'shilgan hisoblanadi
+	var hops []string
+	for _, v := range md.Get("x-forwarded-for") {
+		hops = append(hops, strings.Split(v, ",")...)
+	}
+	for i := len(hops) - 1; i >= 0; i-- {
+		hop := net.ParseIP(strings.TrimSpace(hops[i]))
+		if hop == nil {
+			// Buzilgan qiymat — undan chapdagilarga ishonib bo'lmaydi
+			return client
+		}
+		client = hop.String()
+		if !t.trusted(hop) {
+			return client
+		}
+	}
+	return client
+}
+
+// GetIPFromCtx — ClientIP interceptori aniqlagan klient IP; interceptor ulanmagan bo'lsa
+// TCP peer (x-forwarded-for ishonchli proxy ro'yxatisiz hech qachon o'qilmaydi); topilmasa ""
+func GetIPFromCtx(ctx context.Context) string {
+	if ip, ok := ctx.Value(clientIPKey).(string); ok {
+		return ip
+	}
+	var none *TrustedProxies
+	return none.ClientIP(ctx)
+}
+
+// WithClientIP — so'rov boshida bir marta aniqlangan klient IP
+func WithClientIP(ctx context.Context, ip string) context.Context {
+	return context.WithValue(ctx, clientIPKey, ip)
+}
@@ -0,0 +1,82 @@
+package utils
+
+import (
+	"context"
+	"net"
+	"testing"
+
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+)
+
+func requestCtx(peerAddr string, forwardedFor ...string) context.Context {
+	ctx := context.Background()
+	if peerAddr != "" {
+		addr, err := net.ResolveTCPAddr("tcp", peerAddr)
+		if err != nil {
+			panic(err)
+		}
+		ctx = peer.NewContext(ctx, &peer.Peer{Addr: addr})
+	}
+	if len(forwardedFor) > 0 {
+		md := metadata.MD{}
+		for _, v := range forwardedFor {
+			md.Append("x-forwarded-for", v)
+		}
+		ctx = metadata.NewIncomingContext(ctx, md)
+	}
+	return ctx
+}
+
+func TestTrustedProxiesClientIP(t *testing.T) {
+	proxies, err := ParseTrustedProxies([]string{"10.0.0.0/8", "192.168.1.10", "fd00::/8"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name string
+		ctx  context.Context
+		want string
+	}{
+		{"no forwarded header", requestCtx("203.0.113.5:4000"), "203.0.113.5"},
+		{"untrusted peer spoofs header", requestCtx("203.0.113.5:4000", "1.2.3.4"), "203.0.113.5"},
+		{"trusted peer, single hop", requestCtx("10.1.2.3:4000", "198.51.100.7"), "198.51.100.7"},
+		{"client prepends fake hop", requestCtx("10.1.2.3:4000", "1.2.3.4, 198.51.100.7"), "198.51.100.7"},
+		{"chain of trusted proxies", requestCtx("10.1.2.3:4000", "1.2.3.4, 198.51.100.7, 10.9.9.9, 192.168.1.10"), "198.51.100.7"},
+		{"multiple headers", requestCtx("10.1.2.3:4000", "1.2.3.4", "198.51.100.7, 10.9.9.9"), "198.51.100.7"},
+		{"every hop trusted", requestCtx("10.1.2.3:4000", "10.8.8.8, 10.9.9.9"), "10.8.8.8"},
+		{"garbage hop", requestCtx("10.1.2.3:4000", "198.51.100.7, not-an-ip, 10.9.9.9"), "10.9.9.9"},
+		{"garbage only", requestCtx("10.1.2.3:4000", "not-an-ip"), "10.1.2.3"},
+		{"trusted peer without header", requestCtx("10.1.2.3:4000"), "10.1.2.3"},
+		{"single trusted IP", requestCtx("192.168.1.10:4000", "198.51.100.7"), "198.51.100.7"},
+		{"neighbour of trusted IP", requestCtx("192.168.1.11:4000", "198.51.100.7"), "192.168.1.11"},
+		{"ipv6 trusted peer", requestCtx("[fd00::1]:4000", "2001:db8::1"), "2001:db8::1"},
+		{"no peer", requestCtx("", "1.2.3.4"), ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := proxies.ClientIP(tt.ctx); got != tt.want {
+				t.Errorf("ClientIP = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestGetIPFromCtxIgnoresHeaderWithoutResolver(t *testing.T) {
+	ctx := requestCtx("203.0.113.5:4000", "1.2.3.4")
+	if got := GetIPFromCtx(ctx); got != "203.0.113.5" {
+		t.Errorf("GetIPFromCtx = %q, want peer address", got)
+	}
+	if got := GetIPFromCtx(WithClientIP(ctx, "198.51.100.7")); got != "198.51.100.7" {
+		t.Errorf("GetIPFromCtx = %q, want resolved client IP", got)
+	}
+}
+
+func TestParseTrustedProxiesRejectsInvalid(t *testing.T) {
+	for _, cidr := range []string{"10.0.0.0/33", "not-an-ip", "10.0.0/8"} {
+		if _, err := ParseTrustedProxies([]string{cidr}); err == nil {
+			t.Errorf("ParseTrustedProxies(%q) succeeded", cidr)
+		}
+	}
+}
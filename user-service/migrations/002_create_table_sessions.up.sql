@@ -0,0 +1,16 @@
+-- ==================== SESSIONS TABLE ====================
+-- Har bir foydalanuvchi + qurilma juftligi uchun bitta sessiya
+CREATE TABLE sessions (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    device_id TEXT NOT NULL,
+    platform TEXT,                            -- Android, iOS, Web
+    ip_address TEXT,
+    user_agent TEXT,
+    refresh_jti TEXT,                         -- Redis dagi refresh:<jti> kaliti
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    last_seen TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    UNIQUE (user_id, device_id)
+);
+
+CREATE INDEX idx_sessions_user_id ON sessions(user_id);
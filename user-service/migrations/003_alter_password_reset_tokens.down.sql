@@ -0,0 +1,6 @@
+ALTER TABLE password_reset_tokens DROP CONSTRAINT password_reset_tokens_email_fkey;
+ALTER TABLE password_reset_tokens
+    ADD CONSTRAINT password_reset_tokens_email_fkey
+    FOREIGN KEY (email) REFERENCES users(email) ON DELETE CASCADE;
+
+ALTER TABLE password_reset_tokens DROP COLUMN IF EXISTS used_at;
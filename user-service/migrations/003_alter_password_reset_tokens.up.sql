@@ -0,0 +1,8 @@
+-- token ustunida endi tokenning o'zi emas, SHA-256 hash saqlanadi
+ALTER TABLE password_reset_tokens ADD COLUMN used_at TIMESTAMP WITH TIME ZONE;
+
+-- email o'zgarganda reset tokenlar ham yangilanishi uchun
+ALTER TABLE password_reset_tokens DROP CONSTRAINT password_reset_tokens_email_fkey;
+ALTER TABLE password_reset_tokens
+    ADD CONSTRAINT password_reset_tokens_email_fkey
+    FOREIGN KEY (email) REFERENCES users(email) ON UPDATE CASCADE ON DELETE CASCADE;
@@ -0,0 +1,13 @@
+-- ==================== TWO-FACTOR AUTH (TOTP) ====================
+ALTER TABLE users ADD COLUMN totp_secret TEXT;                       -- tasdiqlanmaguncha ham saqlanadi
+ALTER TABLE users ADD COLUMN totp_enabled_at TIMESTAMP WITH TIME ZONE; -- NULL => 2FA o'chiq
+
+CREATE TABLE recovery_codes (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    code_hash TEXT NOT NULL,                  -- SHA-256 hash
+    used_at TIMESTAMP WITH TIME ZONE,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX idx_recovery_codes_user_id ON recovery_codes(user_id);
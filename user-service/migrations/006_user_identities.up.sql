@@ -0,0 +1,13 @@
+-- ==================== EXTERNAL IDENTITIES (OAuth2 / OIDC) ====================
+CREATE TABLE user_identities (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    provider TEXT NOT NULL,                   -- google, apple, github, ...
+    subject TEXT NOT NULL,                    -- provayderdagi doimiy ID (sub)
+    email TEXT,                               -- bog'lash paytidagi email (faqat ma'lumot uchun)
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    UNIQUE (provider, subject),               -- bitta tashqi hisob faqat bitta userga
+    UNIQUE (user_id, provider)                -- userda har provayderdan bittadan
+);
+
+CREATE INDEX idx_user_identities_user_id ON user_identities(user_id);
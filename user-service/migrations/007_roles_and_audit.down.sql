@@ -0,0 +1,5 @@
+DROP TABLE IF EXISTS admin_audit_log;
+
+DROP INDEX IF EXISTS idx_users_registered_at;
+ALTER TABLE users DROP COLUMN IF EXISTS status;
+ALTER TABLE users DROP COLUMN IF EXISTS role;
@@ -0,0 +1,19 @@
+-- ==================== ROLES & ADMIN AUDIT ====================
+ALTER TABLE users ADD COLUMN role TEXT NOT NULL DEFAULT 'user'
+    CONSTRAINT users_role_check CHECK (role IN ('user', 'moderator', 'admin'));
+ALTER TABLE users ADD COLUMN status TEXT NOT NULL DEFAULT 'active'
+    CONSTRAINT users_status_check CHECK (status IN ('active', 'suspended'));
+
+CREATE INDEX idx_users_registered_at ON users(registered_at DESC, id DESC);
+
+CREATE TABLE admin_audit_log (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    actor_id UUID REFERENCES users(id) ON DELETE SET NULL,
+    action TEXT NOT NULL,                     -- SuspendUser, SetRole, ...
+    target_user_id UUID,                      -- FK yo'q: user o'chirilgandan keyin ham yozuv qoladi
+    details JSONB,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX idx_admin_audit_log_target ON admin_audit_log(target_user_id, created_at DESC);
+CREATE INDEX idx_admin_audit_log_actor ON admin_audit_log(actor_id, created_at DESC);
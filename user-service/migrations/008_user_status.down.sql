@@ -0,0 +1,6 @@
+ALTER TABLE users DROP COLUMN IF EXISTS suspended_until;
+ALTER TABLE users DROP COLUMN IF EXISTS status_reason;
+
+UPDATE users SET status = 'suspended' WHERE status IN ('banned', 'pending_deletion');
+ALTER TABLE users DROP CONSTRAINT users_status_check;
+ALTER TABLE users ADD CONSTRAINT users_status_check CHECK (status IN ('active', 'suspended'));
@@ -0,0 +1,7 @@
+-- ==================== ACCOUNT STATUS ====================
+ALTER TABLE users DROP CONSTRAINT users_status_check;
+ALTER TABLE users ADD CONSTRAINT users_status_check
+    CHECK (status IN ('active', 'suspended', 'banned', 'pending_deletion'));
+
+ALTER TABLE users ADD COLUMN status_reason TEXT;
+ALTER TABLE users ADD COLUMN suspended_until TIMESTAMP WITH TIME ZONE; -- NULL => muddatsiz (faqat suspended uchun)
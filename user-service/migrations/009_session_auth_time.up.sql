@@ -0,0 +1,6 @@
+-- ==================== STEP-UP RE-AUTHENTICATION ====================
+-- auth_time — sessiyada oxirgi marta parol/TOTP (yoki to'liq login) tasdiqlangan vaqt
+ALTER TABLE sessions ADD COLUMN auth_time TIMESTAMP WITH TIME ZONE;
+UPDATE sessions SET auth_time = created_at;
+ALTER TABLE sessions ALTER COLUMN auth_time SET NOT NULL;
+ALTER TABLE sessions ALTER COLUMN auth_time SET DEFAULT CURRENT_TIMESTAMP;
@@ -0,0 +1,19 @@
+-- ==================== LOGIN HISTORY ====================
+CREATE TABLE login_events (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    user_id UUID REFERENCES users(id) ON DELETE CASCADE, -- NULL => noma'lum email bilan urinish
+    email TEXT,
+    method TEXT NOT NULL,                     -- password, login_code, mfa, register, provider:<name>
+    outcome TEXT NOT NULL
+        CHECK (outcome IN ('success', 'mfa_required', 'failure', 'locked', 'blocked')),
+    failure_reason TEXT,
+    ip_address TEXT,
+    user_agent TEXT,
+    location TEXT,                            -- "Country, City"
+    country_code TEXT,
+    platform TEXT,
+    device_id TEXT,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX idx_login_events_user ON login_events(user_id, created_at DESC, id DESC);
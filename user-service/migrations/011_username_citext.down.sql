@@ -0,0 +1,2 @@
+ALTER TABLE users DROP COLUMN IF EXISTS username_changed_at;
+ALTER TABLE users ALTER COLUMN username TYPE TEXT;
@@ -0,0 +1,15 @@
+-- ==================== USERNAME: KICHIK-KATTA HARFGA BEFARQ ====================
+CREATE EXTENSION IF NOT EXISTS citext;
+
+-- Faqat harf registri bilan farq qiluvchi usernamelar (eng eskisidan tashqari) id qismi bilan ajratiladi,
+-- aks holda CITEXT ga o'tkazishda UNIQUE buziladi
+UPDATE users u
+SET username = u.username || '_' || substr(replace(u.id::text, '-', ''), 1, 6)
+WHERE EXISTS (
+    SELECT 1 FROM users o
+    WHERE lower(o.username) = lower(u.username)
+      AND (o.registered_at, o.id) < (u.registered_at, u.id)
+);
+
+ALTER TABLE users ALTER COLUMN username TYPE CITEXT;      -- users_username_key endi registrga befarq
+ALTER TABLE users ADD COLUMN username_changed_at TIMESTAMP WITH TIME ZONE; -- o'zgartirish cooldowni uchun
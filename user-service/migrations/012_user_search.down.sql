@@ -0,0 +1,4 @@
+DROP INDEX IF EXISTS idx_users_full_name_trgm;
+DROP INDEX IF EXISTS idx_users_username_trgm;
+
+ALTER TABLE users DROP COLUMN IF EXISTS discoverable;
@@ -0,0 +1,8 @@
+-- ==================== USER DIRECTORY SEARCH ====================
+CREATE EXTENSION IF NOT EXISTS pg_trgm;
+
+ALTER TABLE users ADD COLUMN discoverable BOOLEAN NOT NULL DEFAULT TRUE; -- false => qidiruvda ko'rinmaydi
+
+-- Prefiks (LIKE 'q%') va fuzzy (%, similarity) qidiruv uchun; so'rovlar aynan shu ifodalardan foydalanadi
+CREATE INDEX idx_users_username_trgm ON users USING GIN (lower(username::text) gin_trgm_ops);
+CREATE INDEX idx_users_full_name_trgm ON users USING GIN (lower(full_name) gin_trgm_ops);
@@ -0,0 +1,2 @@
+ALTER TABLE users DROP COLUMN IF EXISTS show_avatar;
+ALTER TABLE users DROP COLUMN IF EXISTS show_full_name;
@@ -0,0 +1,4 @@
+-- ==================== PROFILE VISIBILITY ====================
+-- Boshqa foydalanuvchilar (GetUserProfile, SearchUsers, BatchGetUsers) ko'radigan ixtiyoriy maydonlar
+ALTER TABLE users ADD COLUMN show_full_name BOOLEAN NOT NULL DEFAULT TRUE;
+ALTER TABLE users ADD COLUMN show_avatar BOOLEAN NOT NULL DEFAULT TRUE;
@@ -0,0 +1,4 @@
+-- ==================== PRESENCE ====================
+-- Online holat Redis da; bu yerda faqat offline bo'lgan paytdagi oxirgi heartbeat saqlanadi
+ALTER TABLE users ADD COLUMN last_seen_at TIMESTAMP WITH TIME ZONE;
+ALTER TABLE users ADD COLUMN hide_last_seen BOOLEAN NOT NULL DEFAULT FALSE; -- true => online/last seen boshqalarga ko'rinmaydi
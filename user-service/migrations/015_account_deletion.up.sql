@@ -0,0 +1,4 @@
+-- ==================== ACCOUNT DELETION ====================
+-- pending_deletion accountlar uchun suspended_until — butunlay o'chirish vaqti
+CREATE INDEX idx_users_pending_deletion ON users (suspended_until)
+    WHERE status = 'pending_deletion';
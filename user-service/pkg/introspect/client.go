@@ -0,0 +1,127 @@
+// Package introspect — boshqa chat-app servislari (websocket gateway, chat, notification)
+// uchun user access tokenlarini user-service orqali tekshiruvchi klient.
+//
+//	conn, _ := grpc.NewClient("user-service:8081", grpc.WithTransportCredentials(...))
+//	client := introspect.NewClient(conn, introspect.Config{ServiceToken: os.Getenv("USER_SERVICE_TOKEN")})
+//	info, err := client.Introspect(ctx, accessToken)
+//	if err != nil || !info.Active { /* ulanishni rad etish */ }
+package introspect
+
+import (
+	"context"
+	"crypto/sha256"
+	"sync"
+	"time"
+
+	pb "user-service/protos/user"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+)
+
+// Config — CacheTTL revokatsiya qancha kechikib sezilishini belgilaydi (user-service da
+// bekor qilingan token keshdan ko'pi bilan CacheTTL davomida "active" bo'lib qoladi)
+type Config struct {
+	ServiceToken     string        // SERVICE_TOKENS dagi shu servisga berilgan token
+	CacheTTL         time.Duration // active natijalar uchun (standart 30s)
+	NegativeCacheTTL time.Duration // inactive natijalar uchun (standart 5s)
+	MaxEntries       int           // standart 10000
+}
+
+// TokenInfo — introspection natijasi
+type TokenInfo struct {
+	Active    bool
+	UserID    string
+	SessionID string
+	Scopes    []string
+	ActorID   string // bo'sh bo'lmasa — support xodimi impersonatsiya qilmoqda
+	IssuedAt  time.Time
+	ExpiresAt time.Time
+}
+
+type cacheEntry struct {
+	info      *TokenInfo
+	expiresAt time.Time
+}
+
+type Client struct {
+	rpc pb.UserServiceClient
+	cfg Config
+
+	mu    sync.Mutex
+	cache map[[sha256.Size]byte]cacheEntry
+}
+
+func NewClient(conn grpc.ClientConnInterface, cfg Config) *Client {
+	if cfg.CacheTTL <= 0 {
+		cfg.CacheTTL = 30 * time.Second
+	}
+	if cfg.NegativeCacheTTL <= 0 {
+		cfg.NegativeCacheTTL = 5 * time.Second
+	}
+	if cfg.MaxEntries <= 0 {
+		cfg.MaxEntries = 10000
+	}
+	return &Client{
+		rpc:   pb.NewUserServiceClient(conn),
+		cfg:   cfg,
+		cache: make(map[[sha256.Size]byte]cacheEntry),
+	}
+}
+
+// Introspect — avval keshdan, bo'lmasa user-service dan. Tarmoq xatolari keshlanmaydi.
+func (c *Client) Introspect(ctx context.Context, token string) (*TokenInfo, error) {
+	// Kesh kaliti — token hash (xotirada tokenlarning o'zi saqlanmaydi)
+	key := sha256.Sum256([]byte(token))
+	now := time.Now()
+
+	c.mu.Lock()
+	if e, ok := c.cache[key]; ok && now.Before(e.expiresAt) {
+		c.mu.Unlock()
+		return e.info, nil
+	}
+	c.mu.Unlock()
+
+	ctx = metadata.AppendToOutgoingContext(ctx, "authorization", "Bearer "+c.cfg.ServiceToken)
+	resp, err := c.rpc.IntrospectToken(ctx, &pb.IntrospectTokenRequest{Token: token})
+	if err != nil {
+		return nil, err
+	}
+
+	info := &TokenInfo{Active: resp.Active}
+	ttl := c.cfg.NegativeCacheTTL
+	if resp.Active {
+		info.UserID = resp.UserId
+		info.SessionID = resp.SessionId
+		info.Scopes = resp.Scopes
+		info.ActorID = resp.ActorId
+		info.IssuedAt = resp.IssuedAt.AsTime()
+		info.ExpiresAt = resp.ExpiresAt.AsTime()
+
+		// Token muddati tugagandan keyin keshdan qaytmasligi kerak
+		ttl = min(c.cfg.CacheTTL, time.Until(info.ExpiresAt))
+	}
+	if ttl > 0 {
+		c.store(key, info, now.Add(ttl))
+	}
+	return info, nil
+}
+
+func (c *Client) store(key [sha256.Size]byte, info *TokenInfo, expiresAt time.Time) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if len(c.cache) >= c.cfg.MaxEntries {
+		now := time.Now()
+		for k, e := range c.cache {
+			if !now.Before(e.expiresAt) {
+				delete(c.cache, k)
+			}
+		}
+		// Hammasi hali yaroqli bo'lsa — keshni tozalab, qaytadan to'ldiramiz
+		if len(c.cache) >= c.cfg.MaxEntries {
+			clear(c.cache)
+		}
+	}
+	c.cache[key] = cacheEntry{info: info, expiresAt: expiresAt}
+}
@@ -23,22 +23,29 @@ const (
 )
 
 type User struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
-	Username      string                 `protobuf:"bytes,2,opt,name=username,proto3" json:"username,omitempty"`
-	Email         string                 `protobuf:"bytes,3,opt,name=email,proto3" json:"email,omitempty"`
-	FullName      string                 `protobuf:"bytes,4,opt,name=full_name,json=fullName,proto3" json:"full_name,omitempty"`
-	AvatarUrl     string                 `protobuf:"bytes,5,opt,name=avatar_url,json=avatarUrl,proto3" json:"avatar_url,omitempty"`
-	Language      string                 `protobuf:"bytes,6,opt,name=language,proto3" json:"language,omitempty"`
-	Platform      string                 `protobuf:"bytes,7,opt,name=platform,proto3" json:"platform,omitempty"`
-	DeviceId      string                 `protobuf:"bytes,8,opt,name=device_id,json=deviceId,proto3" json:"device_id,omitempty"`
-	RegisteredIp  string                 `protobuf:"bytes,9,opt,name=registered_ip,json=registeredIp,proto3" json:"registered_ip,omitempty"`
-	UserAgent     string                 `protobuf:"bytes,10,opt,name=user_agent,json=userAgent,proto3" json:"user_agent,omitempty"`
-	Location      string                 `protobuf:"bytes,11,opt,name=location,proto3" json:"location,omitempty"`
-	RegisteredAt  *timestamppb.Timestamp `protobuf:"bytes,12,opt,name=registered_at,json=registeredAt,proto3" json:"registered_at,omitempty"`
-	UpdatedAt     *timestamppb.Timestamp `protobuf:"bytes,13,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	Id             string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Username       string                 `protobuf:"bytes,2,opt,name=username,proto3" json:"username,omitempty"`
+	Email          string                 `protobuf:"bytes,3,opt,name=email,proto3" json:"email,omitempty"`
+	FullName       string                 `protobuf:"bytes,4,opt,name=full_name,json=fullName,proto3" json:"full_name,omitempty"`
+	AvatarUrl      string                 `protobuf:"bytes,5,opt,name=avatar_url,json=avatarUrl,proto3" json:"avatar_url,omitempty"`
+	Language       string                 `protobuf:"bytes,6,opt,name=language,proto3" json:"language,omitempty"`
+	Platform       string                 `protobuf:"bytes,7,opt,name=platform,proto3" json:"platform,omitempty"`
+	DeviceId       string                 `protobuf:"bytes,8,opt,name=device_id,json=deviceId,proto3" json:"device_id,omitempty"`
+	RegisteredIp   string                 `protobuf:"bytes,9,opt,name=registered_ip,json=registeredIp,proto3" json:"registered_ip,omitempty"`
+	UserAgent      string                 `protobuf:"bytes,10,opt,name=user_agent,json=userAgent,proto3" json:"user_agent,omitempty"`
+	Location       string                 `protobuf:"bytes,11,opt,name=location,proto3" json:"location,omitempty"`
+	RegisteredAt   *timestamppb.Timestamp `protobuf:"bytes,12,opt,name=registered_at,json=registeredAt,proto3" json:"registered_at,omitempty"`
+	UpdatedAt      *timestamppb.Timestamp `protobuf:"bytes,13,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	EmailVerified  bool                   `protobuf:"varint,14,opt,name=email_verified,json=emailVerified,proto3" json:"email_verified,omitempty"`
+	PendingEmail   string                 `protobuf:"bytes,15,opt,name=pending_email,json=pendingEmail,proto3" json:"pending_email,omitempty"` // tasdiqlanishini kutayotgan yangi email
+	Role           string                 `protobuf:"bytes,16,opt,name=role,proto3" json:"role,omitempty"`                                     // user, moderator, admin
+	Status         string                 `protobuf:"bytes,17,opt,name=status,proto3" json:"status,omitempty"`                                 // active, suspended, banned, pending_deletion
+	StatusReason   string                 `protobuf:"bytes,18,opt,name=status_reason,json=statusReason,proto3" json:"status_reason,omitempty"`
+	SuspendedUntil *timestamppb.Timestamp `protobuf:"bytes,19,opt,name=suspended_until,json=suspendedUntil,proto3" json:"suspended_until,omitempty"` // bo'sh => muddatsiz
+	Privacy        *PrivacySettings       `protobuf:"bytes,20,opt,name=privacy,proto3" json:"privacy,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
 }
 
 func (x *User) Reset() {
@@ -162,6 +169,193 @@ func (x *User) GetUpdatedAt() *timestamppb.Timestamp {
 	return nil
 }
 
+func (x *User) GetEmailVerified() bool {
+	if x != nil {
+		return x.EmailVerified
+	}
+	return false
+}
+
+func (x *User) GetPendingEmail() string {
+	if x != nil {
+		return x.PendingEmail
+	}
+	return ""
+}
+
+func (x *User) GetRole() string {
+	if x != nil {
+		return x.Role
+	}
+	return ""
+}
+
+func (x *User) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *User) GetStatusReason() string {
+	if x != nil {
+		return x.StatusReason
+	}
+	return ""
+}
+
+func (x *User) GetSuspendedUntil() *timestamppb.Timestamp {
+	if x != nil {
+		return x.SuspendedUntil
+	}
+	return nil
+}
+
+func (x *User) GetPrivacy() *PrivacySettings {
+	if x != nil {
+		return x.Privacy
+	}
+	return nil
+}
+
+type PrivacySettings struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Discoverable  bool                   `protobuf:"varint,1,opt,name=discoverable,proto3" json:"discoverable,omitempty"` // false => SearchUsers natijalarida chiqmaydi
+	ShowFullName  bool                   `protobuf:"varint,2,opt,name=show_full_name,json=showFullName,proto3" json:"show_full_name,omitempty"`
+	ShowAvatar    bool                   `protobuf:"varint,3,opt,name=show_avatar,json=showAvatar,proto3" json:"show_avatar,omitempty"`
+	HideLastSeen  bool                   `protobuf:"varint,4,opt,name=hide_last_seen,json=hideLastSeen,proto3" json:"hide_last_seen,omitempty"` // true => online holati va oxirgi ko'rilgan vaqt boshqalarga berilmaydi
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PrivacySettings) Reset() {
+	*x = PrivacySettings{}
+	mi := &file_protos_user_user_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PrivacySettings) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PrivacySettings) ProtoMessage() {}
+
+func (x *PrivacySettings) ProtoReflect() protoreflect.Message {
+	mi := &file_protos_user_user_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PrivacySettings.ProtoReflect.Descriptor instead.
+func (*PrivacySettings) Descriptor() ([]byte, []int) {
+	return file_protos_user_user_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *PrivacySettings) GetDiscoverable() bool {
+	if x != nil {
+		return x.Discoverable
+	}
+	return false
+}
+
+func (x *PrivacySettings) GetShowFullName() bool {
+	if x != nil {
+		return x.ShowFullName
+	}
+	return false
+}
+
+func (x *PrivacySettings) GetShowAvatar() bool {
+	if x != nil {
+		return x.ShowAvatar
+	}
+	return false
+}
+
+func (x *PrivacySettings) GetHideLastSeen() bool {
+	if x != nil {
+		return x.HideLastSeen
+	}
+	return false
+}
+
+// PublicProfile — boshqa foydalanuvchilarga ko'rinadigan maydonlar (email, IP, qurilma yo'q).
+// full_name va avatar_url egasi yashirgan bo'lsa bo'sh keladi.
+type PublicProfile struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Username      string                 `protobuf:"bytes,2,opt,name=username,proto3" json:"username,omitempty"`
+	FullName      string                 `protobuf:"bytes,3,opt,name=full_name,json=fullName,proto3" json:"full_name,omitempty"`
+	AvatarUrl     string                 `protobuf:"bytes,4,opt,name=avatar_url,json=avatarUrl,proto3" json:"avatar_url,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PublicProfile) Reset() {
+	*x = PublicProfile{}
+	mi := &file_protos_user_user_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PublicProfile) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PublicProfile) ProtoMessage() {}
+
+func (x *PublicProfile) ProtoReflect() protoreflect.Message {
+	mi := &file_protos_user_user_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PublicProfile.ProtoReflect.Descriptor instead.
+func (*PublicProfile) Descriptor() ([]byte, []int) {
+	return file_protos_user_user_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *PublicProfile) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *PublicProfile) GetUsername() string {
+	if x != nil {
+		return x.Username
+	}
+	return ""
+}
+
+func (x *PublicProfile) GetFullName() string {
+	if x != nil {
+		return x.FullName
+	}
+	return ""
+}
+
+func (x *PublicProfile) GetAvatarUrl() string {
+	if x != nil {
+		return x.AvatarUrl
+	}
+	return ""
+}
+
 type RegisterRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Username      string                 `protobuf:"bytes,1,opt,name=username,proto3" json:"username,omitempty"`
@@ -178,7 +372,7 @@ type RegisterRequest struct {
 
 func (x *RegisterRequest) Reset() {
 	*x = RegisterRequest{}
-	mi := &file_protos_user_user_proto_msgTypes[1]
+	mi := &file_protos_user_user_proto_msgTypes[3]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -190,7 +384,7 @@ func (x *RegisterRequest) String() string {
 func (*RegisterRequest) ProtoMessage() {}
 
 func (x *RegisterRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_protos_user_user_proto_msgTypes[1]
+	mi := &file_protos_user_user_proto_msgTypes[3]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -203,7 +397,7 @@ func (x *RegisterRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use RegisterRequest.ProtoReflect.Descriptor instead.
 func (*RegisterRequest) Descriptor() ([]byte, []int) {
-	return file_protos_user_user_proto_rawDescGZIP(), []int{1}
+	return file_protos_user_user_proto_rawDescGZIP(), []int{3}
 }
 
 func (x *RegisterRequest) GetUsername() string {
@@ -274,7 +468,7 @@ type LoginRequest struct {
 
 func (x *LoginRequest) Reset() {
 	*x = LoginRequest{}
-	mi := &file_protos_user_user_proto_msgTypes[2]
+	mi := &file_protos_user_user_proto_msgTypes[4]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -286,7 +480,7 @@ func (x *LoginRequest) String() string {
 func (*LoginRequest) ProtoMessage() {}
 
 func (x *LoginRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_protos_user_user_proto_msgTypes[2]
+	mi := &file_protos_user_user_proto_msgTypes[4]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -299,7 +493,7 @@ func (x *LoginRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use LoginRequest.ProtoReflect.Descriptor instead.
 func (*LoginRequest) Descriptor() ([]byte, []int) {
-	return file_protos_user_user_proto_rawDescGZIP(), []int{2}
+	return file_protos_user_user_proto_rawDescGZIP(), []int{4}
 }
 
 func (x *LoginRequest) GetEmail() string {
@@ -330,28 +524,28 @@ func (x *LoginRequest) GetDeviceId() string {
 	return ""
 }
 
-type RefreshTokenRequest struct {
+type RequestLoginCodeRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	RefreshToken  string                 `protobuf:"bytes,1,opt,name=refresh_token,json=refreshToken,proto3" json:"refresh_token,omitempty"`
+	Email         string                 `protobuf:"bytes,1,opt,name=email,proto3" json:"email,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *RefreshTokenRequest) Reset() {
-	*x = RefreshTokenRequest{}
-	mi := &file_protos_user_user_proto_msgTypes[3]
+func (x *RequestLoginCodeRequest) Reset() {
+	*x = RequestLoginCodeRequest{}
+	mi := &file_protos_user_user_proto_msgTypes[5]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *RefreshTokenRequest) String() string {
+func (x *RequestLoginCodeRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*RefreshTokenRequest) ProtoMessage() {}
+func (*RequestLoginCodeRequest) ProtoMessage() {}
 
-func (x *RefreshTokenRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_protos_user_user_proto_msgTypes[3]
+func (x *RequestLoginCodeRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_protos_user_user_proto_msgTypes[5]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -362,41 +556,43 @@ func (x *RefreshTokenRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use RefreshTokenRequest.ProtoReflect.Descriptor instead.
-func (*RefreshTokenRequest) Descriptor() ([]byte, []int) {
-	return file_protos_user_user_proto_rawDescGZIP(), []int{3}
+// Deprecated: Use RequestLoginCodeRequest.ProtoReflect.Descriptor instead.
+func (*RequestLoginCodeRequest) Descriptor() ([]byte, []int) {
+	return file_protos_user_user_proto_rawDescGZIP(), []int{5}
 }
 
-func (x *RefreshTokenRequest) GetRefreshToken() string {
+func (x *RequestLoginCodeRequest) GetEmail() string {
 	if x != nil {
-		return x.RefreshToken
+		return x.Email
 	}
 	return ""
 }
 
-type ChangePasswordRequest struct {
+type VerifyLoginCodeRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	OldPassword   string                 `protobuf:"bytes,1,opt,name=old_password,json=oldPassword,proto3" json:"old_password,omitempty"`
-	NewPassword   string                 `protobuf:"bytes,2,opt,name=new_password,json=newPassword,proto3" json:"new_password,omitempty"`
+	Email         string                 `protobuf:"bytes,1,opt,name=email,proto3" json:"email,omitempty"`
+	Code          string                 `protobuf:"bytes,2,opt,name=code,proto3" json:"code,omitempty"`
+	Platform      string                 `protobuf:"bytes,3,opt,name=platform,proto3" json:"platform,omitempty"`
+	DeviceId      string                 `protobuf:"bytes,4,opt,name=device_id,json=deviceId,proto3" json:"device_id,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *ChangePasswordRequest) Reset() {
-	*x = ChangePasswordRequest{}
-	mi := &file_protos_user_user_proto_msgTypes[4]
+func (x *VerifyLoginCodeRequest) Reset() {
+	*x = VerifyLoginCodeRequest{}
+	mi := &file_protos_user_user_proto_msgTypes[6]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *ChangePasswordRequest) String() string {
+func (x *VerifyLoginCodeRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ChangePasswordRequest) ProtoMessage() {}
+func (*VerifyLoginCodeRequest) ProtoMessage() {}
 
-func (x *ChangePasswordRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_protos_user_user_proto_msgTypes[4]
+func (x *VerifyLoginCodeRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_protos_user_user_proto_msgTypes[6]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -407,47 +603,2569 @@ func (x *ChangePasswordRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ChangePasswordRequest.ProtoReflect.Descriptor instead.
-func (*ChangePasswordRequest) Descriptor() ([]byte, []int) {
-	return file_protos_user_user_proto_rawDescGZIP(), []int{4}
+// Deprecated: Use VerifyLoginCodeRequest.ProtoReflect.Descriptor instead.
+func (*VerifyLoginCodeRequest) Descriptor() ([]byte, []int) {
+	return file_protos_user_user_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *VerifyLoginCodeRequest) GetEmail() string {
+	if x != nil {
+		return x.Email
+	}
+	return ""
+}
+
+func (x *VerifyLoginCodeRequest) GetCode() string {
+	if x != nil {
+		return x.Code
+	}
+	return ""
+}
+
+func (x *VerifyLoginCodeRequest) GetPlatform() string {
+	if x != nil {
+		return x.Platform
+	}
+	return ""
+}
+
+func (x *VerifyLoginCodeRequest) GetDeviceId() string {
+	if x != nil {
+		return x.DeviceId
+	}
+	return ""
+}
+
+type RefreshTokenRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	RefreshToken  string                 `protobuf:"bytes,1,opt,name=refresh_token,json=refreshToken,proto3" json:"refresh_token,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RefreshTokenRequest) Reset() {
+	*x = RefreshTokenRequest{}
+	mi := &file_protos_user_user_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RefreshTokenRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RefreshTokenRequest) ProtoMessage() {}
+
+func (x *RefreshTokenRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_protos_user_user_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RefreshTokenRequest.ProtoReflect.Descriptor instead.
+func (*RefreshTokenRequest) Descriptor() ([]byte, []int) {
+	return file_protos_user_user_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *RefreshTokenRequest) GetRefreshToken() string {
+	if x != nil {
+		return x.RefreshToken
+	}
+	return ""
+}
+
+type ReauthenticateRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Types that are valid to be assigned to Method:
+	//
+	//	*ReauthenticateRequest_Password
+	//	*ReauthenticateRequest_TotpCode
+	Method        isReauthenticateRequest_Method `protobuf_oneof:"method"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ReauthenticateRequest) Reset() {
+	*x = ReauthenticateRequest{}
+	mi := &file_protos_user_user_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReauthenticateRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReauthenticateRequest) ProtoMessage() {}
+
+func (x *ReauthenticateRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_protos_user_user_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReauthenticateRequest.ProtoReflect.Descriptor instead.
+func (*ReauthenticateRequest) Descriptor() ([]byte, []int) {
+	return file_protos_user_user_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *ReauthenticateRequest) GetMethod() isReauthenticateRequest_Method {
+	if x != nil {
+		return x.Method
+	}
+	return nil
+}
+
+func (x *ReauthenticateRequest) GetPassword() string {
+	if x != nil {
+		if x, ok := x.Method.(*ReauthenticateRequest_Password); ok {
+			return x.Password
+		}
+	}
+	return ""
+}
+
+func (x *ReauthenticateRequest) GetTotpCode() string {
+	if x != nil {
+		if x, ok := x.Method.(*ReauthenticateRequest_TotpCode); ok {
+			return x.TotpCode
+		}
+	}
+	return ""
+}
+
+type isReauthenticateRequest_Method interface {
+	isReauthenticateRequest_Method()
+}
+
+type ReauthenticateRequest_Password struct {
+	Password string `protobuf:"bytes,1,opt,name=password,proto3,oneof"`
+}
+
+type ReauthenticateRequest_TotpCode struct {
+	TotpCode string `protobuf:"bytes,2,opt,name=totp_code,json=totpCode,proto3,oneof"` // TOTP kodi yoki tiklash kodi
+}
+
+func (*ReauthenticateRequest_Password) isReauthenticateRequest_Method() {}
+
+func (*ReauthenticateRequest_TotpCode) isReauthenticateRequest_Method() {}
+
+type ReauthenticateResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	AuthTime      *timestamppb.Timestamp `protobuf:"bytes,1,opt,name=auth_time,json=authTime,proto3" json:"auth_time,omitempty"`
+	ValidUntil    *timestamppb.Timestamp `protobuf:"bytes,2,opt,name=valid_until,json=validUntil,proto3" json:"valid_until,omitempty"` // nozik amallar shu vaqtgacha ruxsat etiladi
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ReauthenticateResponse) Reset() {
+	*x = ReauthenticateResponse{}
+	mi := &file_protos_user_user_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReauthenticateResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReauthenticateResponse) ProtoMessage() {}
+
+func (x *ReauthenticateResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_protos_user_user_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReauthenticateResponse.ProtoReflect.Descriptor instead.
+func (*ReauthenticateResponse) Descriptor() ([]byte, []int) {
+	return file_protos_user_user_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *ReauthenticateResponse) GetAuthTime() *timestamppb.Timestamp {
+	if x != nil {
+		return x.AuthTime
+	}
+	return nil
+}
+
+func (x *ReauthenticateResponse) GetValidUntil() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ValidUntil
+	}
+	return nil
+}
+
+type ChangePasswordRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	OldPassword   string                 `protobuf:"bytes,1,opt,name=old_password,json=oldPassword,proto3" json:"old_password,omitempty"`
+	NewPassword   string                 `protobuf:"bytes,2,opt,name=new_password,json=newPassword,proto3" json:"new_password,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ChangePasswordRequest) Reset() {
+	*x = ChangePasswordRequest{}
+	mi := &file_protos_user_user_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ChangePasswordRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ChangePasswordRequest) ProtoMessage() {}
+
+func (x *ChangePasswordRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_protos_user_user_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ChangePasswordRequest.ProtoReflect.Descriptor instead.
+func (*ChangePasswordRequest) Descriptor() ([]byte, []int) {
+	return file_protos_user_user_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *ChangePasswordRequest) GetOldPassword() string {
+	if x != nil {
+		return x.OldPassword
+	}
+	return ""
+}
+
+func (x *ChangePasswordRequest) GetNewPassword() string {
+	if x != nil {
+		return x.NewPassword
+	}
+	return ""
+}
+
+type ForgotPasswordRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Email         string                 `protobuf:"bytes,1,opt,name=email,proto3" json:"email,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ForgotPasswordRequest) Reset() {
+	*x = ForgotPasswordRequest{}
+	mi := &file_protos_user_user_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ForgotPasswordRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ForgotPasswordRequest) ProtoMessage() {}
+
+func (x *ForgotPasswordRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_protos_user_user_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ForgotPasswordRequest.ProtoReflect.Descriptor instead.
+func (*ForgotPasswordRequest) Descriptor() ([]byte, []int) {
+	return file_protos_user_user_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *ForgotPasswordRequest) GetEmail() string {
+	if x != nil {
+		return x.Email
+	}
+	return ""
+}
+
+type ResetPasswordRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Token         string                 `protobuf:"bytes,1,opt,name=token,proto3" json:"token,omitempty"`
+	NewPassword   string                 `protobuf:"bytes,2,opt,name=new_password,json=newPassword,proto3" json:"new_password,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ResetPasswordRequest) Reset() {
+	*x = ResetPasswordRequest{}
+	mi := &file_protos_user_user_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ResetPasswordRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ResetPasswordRequest) ProtoMessage() {}
+
+func (x *ResetPasswordRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_protos_user_user_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ResetPasswordRequest.ProtoReflect.Descriptor instead.
+func (*ResetPasswordRequest) Descriptor() ([]byte, []int) {
+	return file_protos_user_user_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *ResetPasswordRequest) GetToken() string {
+	if x != nil {
+		return x.Token
+	}
+	return ""
+}
+
+func (x *ResetPasswordRequest) GetNewPassword() string {
+	if x != nil {
+		return x.NewPassword
+	}
+	return ""
+}
+
+type VerifyEmailRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Token         string                 `protobuf:"bytes,1,opt,name=token,proto3" json:"token,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *VerifyEmailRequest) Reset() {
+	*x = VerifyEmailRequest{}
+	mi := &file_protos_user_user_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *VerifyEmailRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*VerifyEmailRequest) ProtoMessage() {}
+
+func (x *VerifyEmailRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_protos_user_user_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use VerifyEmailRequest.ProtoReflect.Descriptor instead.
+func (*VerifyEmailRequest) Descriptor() ([]byte, []int) {
+	return file_protos_user_user_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *VerifyEmailRequest) GetToken() string {
+	if x != nil {
+		return x.Token
+	}
+	return ""
+}
+
+type VerifyMFARequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	MfaToken      string                 `protobuf:"bytes,1,opt,name=mfa_token,json=mfaToken,proto3" json:"mfa_token,omitempty"`
+	Code          string                 `protobuf:"bytes,2,opt,name=code,proto3" json:"code,omitempty"` // TOTP kodi yoki tiklash kodi
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *VerifyMFARequest) Reset() {
+	*x = VerifyMFARequest{}
+	mi := &file_protos_user_user_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *VerifyMFARequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*VerifyMFARequest) ProtoMessage() {}
+
+func (x *VerifyMFARequest) ProtoReflect() protoreflect.Message {
+	mi := &file_protos_user_user_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use VerifyMFARequest.ProtoReflect.Descriptor instead.
+func (*VerifyMFARequest) Descriptor() ([]byte, []int) {
+	return file_protos_user_user_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *VerifyMFARequest) GetMfaToken() string {
+	if x != nil {
+		return x.MfaToken
+	}
+	return ""
+}
+
+func (x *VerifyMFARequest) GetCode() string {
+	if x != nil {
+		return x.Code
+	}
+	return ""
+}
+
+type EnableTOTPResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Secret        string                 `protobuf:"bytes,1,opt,name=secret,proto3" json:"secret,omitempty"`
+	OtpauthUri    string                 `protobuf:"bytes,2,opt,name=otpauth_uri,json=otpauthUri,proto3" json:"otpauth_uri,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *EnableTOTPResponse) Reset() {
+	*x = EnableTOTPResponse{}
+	mi := &file_protos_user_user_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *EnableTOTPResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*EnableTOTPResponse) ProtoMessage() {}
+
+func (x *EnableTOTPResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_protos_user_user_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use EnableTOTPResponse.ProtoReflect.Descriptor instead.
+func (*EnableTOTPResponse) Descriptor() ([]byte, []int) {
+	return file_protos_user_user_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *EnableTOTPResponse) GetSecret() string {
+	if x != nil {
+		return x.Secret
+	}
+	return ""
+}
+
+func (x *EnableTOTPResponse) GetOtpauthUri() string {
+	if x != nil {
+		return x.OtpauthUri
+	}
+	return ""
+}
+
+type ConfirmTOTPRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Code          string                 `protobuf:"bytes,1,opt,name=code,proto3" json:"code,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ConfirmTOTPRequest) Reset() {
+	*x = ConfirmTOTPRequest{}
+	mi := &file_protos_user_user_proto_msgTypes[16]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ConfirmTOTPRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ConfirmTOTPRequest) ProtoMessage() {}
+
+func (x *ConfirmTOTPRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_protos_user_user_proto_msgTypes[16]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ConfirmTOTPRequest.ProtoReflect.Descriptor instead.
+func (*ConfirmTOTPRequest) Descriptor() ([]byte, []int) {
+	return file_protos_user_user_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *ConfirmTOTPRequest) GetCode() string {
+	if x != nil {
+		return x.Code
+	}
+	return ""
+}
+
+type RecoveryCodesResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	RecoveryCodes []string               `protobuf:"bytes,1,rep,name=recovery_codes,json=recoveryCodes,proto3" json:"recovery_codes,omitempty"` // faqat bir marta ko'rsatiladi
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RecoveryCodesResponse) Reset() {
+	*x = RecoveryCodesResponse{}
+	mi := &file_protos_user_user_proto_msgTypes[17]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RecoveryCodesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RecoveryCodesResponse) ProtoMessage() {}
+
+func (x *RecoveryCodesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_protos_user_user_proto_msgTypes[17]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RecoveryCodesResponse.ProtoReflect.Descriptor instead.
+func (*RecoveryCodesResponse) Descriptor() ([]byte, []int) {
+	return file_protos_user_user_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *RecoveryCodesResponse) GetRecoveryCodes() []string {
+	if x != nil {
+		return x.RecoveryCodes
+	}
+	return nil
+}
+
+type DisableTOTPRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Code          string                 `protobuf:"bytes,1,opt,name=code,proto3" json:"code,omitempty"` // TOTP kodi yoki tiklash kodi
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DisableTOTPRequest) Reset() {
+	*x = DisableTOTPRequest{}
+	mi := &file_protos_user_user_proto_msgTypes[18]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DisableTOTPRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DisableTOTPRequest) ProtoMessage() {}
+
+func (x *DisableTOTPRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_protos_user_user_proto_msgTypes[18]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DisableTOTPRequest.ProtoReflect.Descriptor instead.
+func (*DisableTOTPRequest) Descriptor() ([]byte, []int) {
+	return file_protos_user_user_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *DisableTOTPRequest) GetCode() string {
+	if x != nil {
+		return x.Code
+	}
+	return ""
+}
+
+type ProviderLoginRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Provider      string                 `protobuf:"bytes,1,opt,name=provider,proto3" json:"provider,omitempty"` // google, apple, github, ...
+	Code          string                 `protobuf:"bytes,2,opt,name=code,proto3" json:"code,omitempty"`         // authorization code
+	RedirectUri   string                 `protobuf:"bytes,3,opt,name=redirect_uri,json=redirectUri,proto3" json:"redirect_uri,omitempty"`
+	CodeVerifier  string                 `protobuf:"bytes,4,opt,name=code_verifier,json=codeVerifier,proto3" json:"code_verifier,omitempty"` // PKCE
+	Nonce         string                 `protobuf:"bytes,5,opt,name=nonce,proto3" json:"nonce,omitempty"`                                   // authorize so'rovida yuborilgan nonce
+	Platform      string                 `protobuf:"bytes,6,opt,name=platform,proto3" json:"platform,omitempty"`
+	DeviceId      string                 `protobuf:"bytes,7,opt,name=device_id,json=deviceId,proto3" json:"device_id,omitempty"`
+	Language      string                 `protobuf:"bytes,8,opt,name=language,proto3" json:"language,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ProviderLoginRequest) Reset() {
+	*x = ProviderLoginRequest{}
+	mi := &file_protos_user_user_proto_msgTypes[19]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ProviderLoginRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ProviderLoginRequest) ProtoMessage() {}
+
+func (x *ProviderLoginRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_protos_user_user_proto_msgTypes[19]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ProviderLoginRequest.ProtoReflect.Descriptor instead.
+func (*ProviderLoginRequest) Descriptor() ([]byte, []int) {
+	return file_protos_user_user_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *ProviderLoginRequest) GetProvider() string {
+	if x != nil {
+		return x.Provider
+	}
+	return ""
+}
+
+func (x *ProviderLoginRequest) GetCode() string {
+	if x != nil {
+		return x.Code
+	}
+	return ""
+}
+
+func (x *ProviderLoginRequest) GetRedirectUri() string {
+	if x != nil {
+		return x.RedirectUri
+	}
+	return ""
+}
+
+func (x *ProviderLoginRequest) GetCodeVerifier() string {
+	if x != nil {
+		return x.CodeVerifier
+	}
+	return ""
+}
+
+func (x *ProviderLoginRequest) GetNonce() string {
+	if x != nil {
+		return x.Nonce
+	}
+	return ""
+}
+
+func (x *ProviderLoginRequest) GetPlatform() string {
+	if x != nil {
+		return x.Platform
+	}
+	return ""
+}
+
+func (x *ProviderLoginRequest) GetDeviceId() string {
+	if x != nil {
+		return x.DeviceId
+	}
+	return ""
+}
+
+func (x *ProviderLoginRequest) GetLanguage() string {
+	if x != nil {
+		return x.Language
+	}
+	return ""
+}
+
+type LinkIdentityRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Provider      string                 `protobuf:"bytes,1,opt,name=provider,proto3" json:"provider,omitempty"`
+	Code          string                 `protobuf:"bytes,2,opt,name=code,proto3" json:"code,omitempty"`
+	RedirectUri   string                 `protobuf:"bytes,3,opt,name=redirect_uri,json=redirectUri,proto3" json:"redirect_uri,omitempty"`
+	CodeVerifier  string                 `protobuf:"bytes,4,opt,name=code_verifier,json=codeVerifier,proto3" json:"code_verifier,omitempty"`
+	Nonce         string                 `protobuf:"bytes,5,opt,name=nonce,proto3" json:"nonce,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *LinkIdentityRequest) Reset() {
+	*x = LinkIdentityRequest{}
+	mi := &file_protos_user_user_proto_msgTypes[20]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *LinkIdentityRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LinkIdentityRequest) ProtoMessage() {}
+
+func (x *LinkIdentityRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_protos_user_user_proto_msgTypes[20]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LinkIdentityRequest.ProtoReflect.Descriptor instead.
+func (*LinkIdentityRequest) Descriptor() ([]byte, []int) {
+	return file_protos_user_user_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *LinkIdentityRequest) GetProvider() string {
+	if x != nil {
+		return x.Provider
+	}
+	return ""
+}
+
+func (x *LinkIdentityRequest) GetCode() string {
+	if x != nil {
+		return x.Code
+	}
+	return ""
+}
+
+func (x *LinkIdentityRequest) GetRedirectUri() string {
+	if x != nil {
+		return x.RedirectUri
+	}
+	return ""
+}
+
+func (x *LinkIdentityRequest) GetCodeVerifier() string {
+	if x != nil {
+		return x.CodeVerifier
+	}
+	return ""
+}
+
+func (x *LinkIdentityRequest) GetNonce() string {
+	if x != nil {
+		return x.Nonce
+	}
+	return ""
+}
+
+type UnlinkIdentityRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Provider      string                 `protobuf:"bytes,1,opt,name=provider,proto3" json:"provider,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UnlinkIdentityRequest) Reset() {
+	*x = UnlinkIdentityRequest{}
+	mi := &file_protos_user_user_proto_msgTypes[21]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UnlinkIdentityRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UnlinkIdentityRequest) ProtoMessage() {}
+
+func (x *UnlinkIdentityRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_protos_user_user_proto_msgTypes[21]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UnlinkIdentityRequest.ProtoReflect.Descriptor instead.
+func (*UnlinkIdentityRequest) Descriptor() ([]byte, []int) {
+	return file_protos_user_user_proto_rawDescGZIP(), []int{21}
+}
+
+func (x *UnlinkIdentityRequest) GetProvider() string {
+	if x != nil {
+		return x.Provider
+	}
+	return ""
+}
+
+type Identity struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Provider      string                 `protobuf:"bytes,1,opt,name=provider,proto3" json:"provider,omitempty"`
+	Email         string                 `protobuf:"bytes,2,opt,name=email,proto3" json:"email,omitempty"`
+	CreatedAt     *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Identity) Reset() {
+	*x = Identity{}
+	mi := &file_protos_user_user_proto_msgTypes[22]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Identity) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Identity) ProtoMessage() {}
+
+func (x *Identity) ProtoReflect() protoreflect.Message {
+	mi := &file_protos_user_user_proto_msgTypes[22]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Identity.ProtoReflect.Descriptor instead.
+func (*Identity) Descriptor() ([]byte, []int) {
+	return file_protos_user_user_proto_rawDescGZIP(), []int{22}
+}
+
+func (x *Identity) GetProvider() string {
+	if x != nil {
+		return x.Provider
+	}
+	return ""
+}
+
+func (x *Identity) GetEmail() string {
+	if x != nil {
+		return x.Email
+	}
+	return ""
+}
+
+func (x *Identity) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+type IdentityList struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Identities    []*Identity            `protobuf:"bytes,1,rep,name=identities,proto3" json:"identities,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *IdentityList) Reset() {
+	*x = IdentityList{}
+	mi := &file_protos_user_user_proto_msgTypes[23]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *IdentityList) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*IdentityList) ProtoMessage() {}
+
+func (x *IdentityList) ProtoReflect() protoreflect.Message {
+	mi := &file_protos_user_user_proto_msgTypes[23]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use IdentityList.ProtoReflect.Descriptor instead.
+func (*IdentityList) Descriptor() ([]byte, []int) {
+	return file_protos_user_user_proto_rawDescGZIP(), []int{23}
+}
+
+func (x *IdentityList) GetIdentities() []*Identity {
+	if x != nil {
+		return x.Identities
+	}
+	return nil
+}
+
+type UpdateUsernameRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Username      string                 `protobuf:"bytes,1,opt,name=username,proto3" json:"username,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpdateUsernameRequest) Reset() {
+	*x = UpdateUsernameRequest{}
+	mi := &file_protos_user_user_proto_msgTypes[24]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateUsernameRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateUsernameRequest) ProtoMessage() {}
+
+func (x *UpdateUsernameRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_protos_user_user_proto_msgTypes[24]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateUsernameRequest.ProtoReflect.Descriptor instead.
+func (*UpdateUsernameRequest) Descriptor() ([]byte, []int) {
+	return file_protos_user_user_proto_rawDescGZIP(), []int{24}
+}
+
+func (x *UpdateUsernameRequest) GetUsername() string {
+	if x != nil {
+		return x.Username
+	}
+	return ""
+}
+
+// Berilmagan maydonlar o'zgarmaydi
+type UpdatePrivacySettingsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Discoverable  *bool                  `protobuf:"varint,1,opt,name=discoverable,proto3,oneof" json:"discoverable,omitempty"`
+	ShowFullName  *bool                  `protobuf:"varint,2,opt,name=show_full_name,json=showFullName,proto3,oneof" json:"show_full_name,omitempty"`
+	ShowAvatar    *bool                  `protobuf:"varint,3,opt,name=show_avatar,json=showAvatar,proto3,oneof" json:"show_avatar,omitempty"`
+	HideLastSeen  *bool                  `protobuf:"varint,4,opt,name=hide_last_seen,json=hideLastSeen,proto3,oneof" json:"hide_last_seen,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpdatePrivacySettingsRequest) Reset() {
+	*x = UpdatePrivacySettingsRequest{}
+	mi := &file_protos_user_user_proto_msgTypes[25]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdatePrivacySettingsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdatePrivacySettingsRequest) ProtoMessage() {}
+
+func (x *UpdatePrivacySettingsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_protos_user_user_proto_msgTypes[25]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdatePrivacySettingsRequest.ProtoReflect.Descriptor instead.
+func (*UpdatePrivacySettingsRequest) Descriptor() ([]byte, []int) {
+	return file_protos_user_user_proto_rawDescGZIP(), []int{25}
+}
+
+func (x *UpdatePrivacySettingsRequest) GetDiscoverable() bool {
+	if x != nil && x.Discoverable != nil {
+		return *x.Discoverable
+	}
+	return false
+}
+
+func (x *UpdatePrivacySettingsRequest) GetShowFullName() bool {
+	if x != nil && x.ShowFullName != nil {
+		return *x.ShowFullName
+	}
+	return false
+}
+
+func (x *UpdatePrivacySettingsRequest) GetShowAvatar() bool {
+	if x != nil && x.ShowAvatar != nil {
+		return *x.ShowAvatar
+	}
+	return false
+}
+
+func (x *UpdatePrivacySettingsRequest) GetHideLastSeen() bool {
+	if x != nil && x.HideLastSeen != nil {
+		return *x.HideLastSeen
+	}
+	return false
+}
+
+type GetUserProfileRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Types that are valid to be assigned to Lookup:
+	//
+	//	*GetUserProfileRequest_Id
+	//	*GetUserProfileRequest_Username
+	Lookup        isGetUserProfileRequest_Lookup `protobuf_oneof:"lookup"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetUserProfileRequest) Reset() {
+	*x = GetUserProfileRequest{}
+	mi := &file_protos_user_user_proto_msgTypes[26]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetUserProfileRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetUserProfileRequest) ProtoMessage() {}
+
+func (x *GetUserProfileRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_protos_user_user_proto_msgTypes[26]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetUserProfileRequest.ProtoReflect.Descriptor instead.
+func (*GetUserProfileRequest) Descriptor() ([]byte, []int) {
+	return file_protos_user_user_proto_rawDescGZIP(), []int{26}
+}
+
+func (x *GetUserProfileRequest) GetLookup() isGetUserProfileRequest_Lookup {
+	if x != nil {
+		return x.Lookup
+	}
+	return nil
+}
+
+func (x *GetUserProfileRequest) GetId() string {
+	if x != nil {
+		if x, ok := x.Lookup.(*GetUserProfileRequest_Id); ok {
+			return x.Id
+		}
+	}
+	return ""
+}
+
+func (x *GetUserProfileRequest) GetUsername() string {
+	if x != nil {
+		if x, ok := x.Lookup.(*GetUserProfileRequest_Username); ok {
+			return x.Username
+		}
+	}
+	return ""
+}
+
+type isGetUserProfileRequest_Lookup interface {
+	isGetUserProfileRequest_Lookup()
+}
+
+type GetUserProfileRequest_Id struct {
+	Id string `protobuf:"bytes,1,opt,name=id,proto3,oneof"`
+}
+
+type GetUserProfileRequest_Username struct {
+	Username string `protobuf:"bytes,2,opt,name=username,proto3,oneof"` // registrga befarq
+}
+
+func (*GetUserProfileRequest_Id) isGetUserProfileRequest_Lookup() {}
+
+func (*GetUserProfileRequest_Username) isGetUserProfileRequest_Lookup() {}
+
+type BatchGetUsersRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Ids           []string               `protobuf:"bytes,1,rep,name=ids,proto3" json:"ids,omitempty"` // ko'pi bilan 200 ta
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *BatchGetUsersRequest) Reset() {
+	*x = BatchGetUsersRequest{}
+	mi := &file_protos_user_user_proto_msgTypes[27]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BatchGetUsersRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BatchGetUsersRequest) ProtoMessage() {}
+
+func (x *BatchGetUsersRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_protos_user_user_proto_msgTypes[27]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BatchGetUsersRequest.ProtoReflect.Descriptor instead.
+func (*BatchGetUsersRequest) Descriptor() ([]byte, []int) {
+	return file_protos_user_user_proto_rawDescGZIP(), []int{27}
+}
+
+func (x *BatchGetUsersRequest) GetIds() []string {
+	if x != nil {
+		return x.Ids
+	}
+	return nil
+}
+
+type BatchGetUsersResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Users         []*PublicProfile       `protobuf:"bytes,1,rep,name=users,proto3" json:"users,omitempty"`                             // so'rovdagi tartibda
+	MissingIds    []string               `protobuf:"bytes,2,rep,name=missing_ids,json=missingIds,proto3" json:"missing_ids,omitempty"` // topilmagan yoki noto'g'ri IDlar
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *BatchGetUsersResponse) Reset() {
+	*x = BatchGetUsersResponse{}
+	mi := &file_protos_user_user_proto_msgTypes[28]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BatchGetUsersResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BatchGetUsersResponse) ProtoMessage() {}
+
+func (x *BatchGetUsersResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_protos_user_user_proto_msgTypes[28]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BatchGetUsersResponse.ProtoReflect.Descriptor instead.
+func (*BatchGetUsersResponse) Descriptor() ([]byte, []int) {
+	return file_protos_user_user_proto_rawDescGZIP(), []int{28}
+}
+
+func (x *BatchGetUsersResponse) GetUsers() []*PublicProfile {
+	if x != nil {
+		return x.Users
+	}
+	return nil
+}
+
+func (x *BatchGetUsersResponse) GetMissingIds() []string {
+	if x != nil {
+		return x.MissingIds
+	}
+	return nil
+}
+
+type SearchUsersRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Query         string                 `protobuf:"bytes,1,opt,name=query,proto3" json:"query,omitempty"`                          // 2..64 belgi; username va ism bo'yicha prefiks + fuzzy
+	PageSize      int32                  `protobuf:"varint,2,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`   // standart 20, maksimal 50
+	PageToken     string                 `protobuf:"bytes,3,opt,name=page_token,json=pageToken,proto3" json:"page_token,omitempty"` // oldingi javobdagi next_page_token
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SearchUsersRequest) Reset() {
+	*x = SearchUsersRequest{}
+	mi := &file_protos_user_user_proto_msgTypes[29]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SearchUsersRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SearchUsersRequest) ProtoMessage() {}
+
+func (x *SearchUsersRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_protos_user_user_proto_msgTypes[29]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SearchUsersRequest.ProtoReflect.Descriptor instead.
+func (*SearchUsersRequest) Descriptor() ([]byte, []int) {
+	return file_protos_user_user_proto_rawDescGZIP(), []int{29}
+}
+
+func (x *SearchUsersRequest) GetQuery() string {
+	if x != nil {
+		return x.Query
+	}
+	return ""
+}
+
+func (x *SearchUsersRequest) GetPageSize() int32 {
+	if x != nil {
+		return x.PageSize
+	}
+	return 0
+}
+
+func (x *SearchUsersRequest) GetPageToken() string {
+	if x != nil {
+		return x.PageToken
+	}
+	return ""
+}
+
+type SearchUsersResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Users         []*PublicProfile       `protobuf:"bytes,1,rep,name=users,proto3" json:"users,omitempty"` // moslik darajasi bo'yicha tartiblangan
+	NextPageToken string                 `protobuf:"bytes,2,opt,name=next_page_token,json=nextPageToken,proto3" json:"next_page_token,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SearchUsersResponse) Reset() {
+	*x = SearchUsersResponse{}
+	mi := &file_protos_user_user_proto_msgTypes[30]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SearchUsersResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SearchUsersResponse) ProtoMessage() {}
+
+func (x *SearchUsersResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_protos_user_user_proto_msgTypes[30]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SearchUsersResponse.ProtoReflect.Descriptor instead.
+func (*SearchUsersResponse) Descriptor() ([]byte, []int) {
+	return file_protos_user_user_proto_rawDescGZIP(), []int{30}
+}
+
+func (x *SearchUsersResponse) GetUsers() []*PublicProfile {
+	if x != nil {
+		return x.Users
+	}
+	return nil
+}
+
+func (x *SearchUsersResponse) GetNextPageToken() string {
+	if x != nil {
+		return x.NextPageToken
+	}
+	return ""
+}
+
+type CheckUsernameAvailabilityRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Username      string                 `protobuf:"bytes,1,opt,name=username,proto3" json:"username,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CheckUsernameAvailabilityRequest) Reset() {
+	*x = CheckUsernameAvailabilityRequest{}
+	mi := &file_protos_user_user_proto_msgTypes[31]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CheckUsernameAvailabilityRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CheckUsernameAvailabilityRequest) ProtoMessage() {}
+
+func (x *CheckUsernameAvailabilityRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_protos_user_user_proto_msgTypes[31]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CheckUsernameAvailabilityRequest.ProtoReflect.Descriptor instead.
+func (*CheckUsernameAvailabilityRequest) Descriptor() ([]byte, []int) {
+	return file_protos_user_user_proto_rawDescGZIP(), []int{31}
+}
+
+func (x *CheckUsernameAvailabilityRequest) GetUsername() string {
+	if x != nil {
+		return x.Username
+	}
+	return ""
+}
+
+// reason — band yoki siyosatga mos kelmasa sababi; suggestions — bo'sh muqobil variantlar
+type UsernameAvailability struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Available     bool                   `protobuf:"varint,1,opt,name=available,proto3" json:"available,omitempty"`
+	Reason        string                 `protobuf:"bytes,2,opt,name=reason,proto3" json:"reason,omitempty"`
+	Suggestions   []string               `protobuf:"bytes,3,rep,name=suggestions,proto3" json:"suggestions,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UsernameAvailability) Reset() {
+	*x = UsernameAvailability{}
+	mi := &file_protos_user_user_proto_msgTypes[32]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UsernameAvailability) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UsernameAvailability) ProtoMessage() {}
+
+func (x *UsernameAvailability) ProtoReflect() protoreflect.Message {
+	mi := &file_protos_user_user_proto_msgTypes[32]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UsernameAvailability.ProtoReflect.Descriptor instead.
+func (*UsernameAvailability) Descriptor() ([]byte, []int) {
+	return file_protos_user_user_proto_rawDescGZIP(), []int{32}
+}
+
+func (x *UsernameAvailability) GetAvailable() bool {
+	if x != nil {
+		return x.Available
+	}
+	return false
+}
+
+func (x *UsernameAvailability) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+func (x *UsernameAvailability) GetSuggestions() []string {
+	if x != nil {
+		return x.Suggestions
+	}
+	return nil
+}
+
+type UpdateEmailRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Email         string                 `protobuf:"bytes,1,opt,name=email,proto3" json:"email,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpdateEmailRequest) Reset() {
+	*x = UpdateEmailRequest{}
+	mi := &file_protos_user_user_proto_msgTypes[33]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateEmailRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateEmailRequest) ProtoMessage() {}
+
+func (x *UpdateEmailRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_protos_user_user_proto_msgTypes[33]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateEmailRequest.ProtoReflect.Descriptor instead.
+func (*UpdateEmailRequest) Descriptor() ([]byte, []int) {
+	return file_protos_user_user_proto_rawDescGZIP(), []int{33}
+}
+
+func (x *UpdateEmailRequest) GetEmail() string {
+	if x != nil {
+		return x.Email
+	}
+	return ""
+}
+
+type UpdateFullNameRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	FullName      string                 `protobuf:"bytes,1,opt,name=full_name,json=fullName,proto3" json:"full_name,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpdateFullNameRequest) Reset() {
+	*x = UpdateFullNameRequest{}
+	mi := &file_protos_user_user_proto_msgTypes[34]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateFullNameRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateFullNameRequest) ProtoMessage() {}
+
+func (x *UpdateFullNameRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_protos_user_user_proto_msgTypes[34]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateFullNameRequest.ProtoReflect.Descriptor instead.
+func (*UpdateFullNameRequest) Descriptor() ([]byte, []int) {
+	return file_protos_user_user_proto_rawDescGZIP(), []int{34}
+}
+
+func (x *UpdateFullNameRequest) GetFullName() string {
+	if x != nil {
+		return x.FullName
+	}
+	return ""
+}
+
+type UpdateAvatarRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	AvatarUrl     string                 `protobuf:"bytes,1,opt,name=avatar_url,json=avatarUrl,proto3" json:"avatar_url,omitempty"` // empty string => remove avatar
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpdateAvatarRequest) Reset() {
+	*x = UpdateAvatarRequest{}
+	mi := &file_protos_user_user_proto_msgTypes[35]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateAvatarRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateAvatarRequest) ProtoMessage() {}
+
+func (x *UpdateAvatarRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_protos_user_user_proto_msgTypes[35]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateAvatarRequest.ProtoReflect.Descriptor instead.
+func (*UpdateAvatarRequest) Descriptor() ([]byte, []int) {
+	return file_protos_user_user_proto_rawDescGZIP(), []int{35}
+}
+
+func (x *UpdateAvatarRequest) GetAvatarUrl() string {
+	if x != nil {
+		return x.AvatarUrl
+	}
+	return ""
+}
+
+type UpdateLanguageRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Language      string                 `protobuf:"bytes,1,opt,name=language,proto3" json:"language,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpdateLanguageRequest) Reset() {
+	*x = UpdateLanguageRequest{}
+	mi := &file_protos_user_user_proto_msgTypes[36]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateLanguageRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateLanguageRequest) ProtoMessage() {}
+
+func (x *UpdateLanguageRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_protos_user_user_proto_msgTypes[36]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateLanguageRequest.ProtoReflect.Descriptor instead.
+func (*UpdateLanguageRequest) Descriptor() ([]byte, []int) {
+	return file_protos_user_user_proto_rawDescGZIP(), []int{36}
+}
+
+func (x *UpdateLanguageRequest) GetLanguage() string {
+	if x != nil {
+		return x.Language
+	}
+	return ""
+}
+
+type Session struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	DeviceId      string                 `protobuf:"bytes,1,opt,name=device_id,json=deviceId,proto3" json:"device_id,omitempty"`
+	Platform      string                 `protobuf:"bytes,2,opt,name=platform,proto3" json:"platform,omitempty"`
+	IpAddress     string                 `protobuf:"bytes,3,opt,name=ip_address,json=ipAddress,proto3" json:"ip_address,omitempty"`
+	LastSeen      *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=last_seen,json=lastSeen,proto3" json:"last_seen,omitempty"`
+	UserAgent     string                 `protobuf:"bytes,5,opt,name=user_agent,json=userAgent,proto3" json:"user_agent,omitempty"`
+	CreatedAt     *timestamppb.Timestamp `protobuf:"bytes,6,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	Current       bool                   `protobuf:"varint,7,opt,name=current,proto3" json:"current,omitempty"`                  // true => so'rov yuborilgan sessiya
+	AuthTime      *timestamppb.Timestamp `protobuf:"bytes,8,opt,name=auth_time,json=authTime,proto3" json:"auth_time,omitempty"` // oxirgi login yoki Reauthenticate
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Session) Reset() {
+	*x = Session{}
+	mi := &file_protos_user_user_proto_msgTypes[37]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Session) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Session) ProtoMessage() {}
+
+func (x *Session) ProtoReflect() protoreflect.Message {
+	mi := &file_protos_user_user_proto_msgTypes[37]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Session.ProtoReflect.Descriptor instead.
+func (*Session) Descriptor() ([]byte, []int) {
+	return file_protos_user_user_proto_rawDescGZIP(), []int{37}
+}
+
+func (x *Session) GetDeviceId() string {
+	if x != nil {
+		return x.DeviceId
+	}
+	return ""
+}
+
+func (x *Session) GetPlatform() string {
+	if x != nil {
+		return x.Platform
+	}
+	return ""
+}
+
+func (x *Session) GetIpAddress() string {
+	if x != nil {
+		return x.IpAddress
+	}
+	return ""
+}
+
+func (x *Session) GetLastSeen() *timestamppb.Timestamp {
+	if x != nil {
+		return x.LastSeen
+	}
+	return nil
+}
+
+func (x *Session) GetUserAgent() string {
+	if x != nil {
+		return x.UserAgent
+	}
+	return ""
+}
+
+func (x *Session) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+func (x *Session) GetCurrent() bool {
+	if x != nil {
+		return x.Current
+	}
+	return false
+}
+
+func (x *Session) GetAuthTime() *timestamppb.Timestamp {
+	if x != nil {
+		return x.AuthTime
+	}
+	return nil
+}
+
+type SessionList struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Sessions      []*Session             `protobuf:"bytes,1,rep,name=sessions,proto3" json:"sessions,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SessionList) Reset() {
+	*x = SessionList{}
+	mi := &file_protos_user_user_proto_msgTypes[38]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SessionList) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SessionList) ProtoMessage() {}
+
+func (x *SessionList) ProtoReflect() protoreflect.Message {
+	mi := &file_protos_user_user_proto_msgTypes[38]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SessionList.ProtoReflect.Descriptor instead.
+func (*SessionList) Descriptor() ([]byte, []int) {
+	return file_protos_user_user_proto_rawDescGZIP(), []int{38}
+}
+
+func (x *SessionList) GetSessions() []*Session {
+	if x != nil {
+		return x.Sessions
+	}
+	return nil
+}
+
+type RevokeSessionRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	DeviceId      string                 `protobuf:"bytes,1,opt,name=device_id,json=deviceId,proto3" json:"device_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RevokeSessionRequest) Reset() {
+	*x = RevokeSessionRequest{}
+	mi := &file_protos_user_user_proto_msgTypes[39]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RevokeSessionRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RevokeSessionRequest) ProtoMessage() {}
+
+func (x *RevokeSessionRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_protos_user_user_proto_msgTypes[39]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RevokeSessionRequest.ProtoReflect.Descriptor instead.
+func (*RevokeSessionRequest) Descriptor() ([]byte, []int) {
+	return file_protos_user_user_proto_rawDescGZIP(), []int{39}
+}
+
+func (x *RevokeSessionRequest) GetDeviceId() string {
+	if x != nil {
+		return x.DeviceId
+	}
+	return ""
+}
+
+type HeartbeatRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Offline       bool                   `protobuf:"varint,1,opt,name=offline,proto3" json:"offline,omitempty"` // true => ilova yopilmoqda: qurilma TTL kutilmasdan offline bo'ladi
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *HeartbeatRequest) Reset() {
+	*x = HeartbeatRequest{}
+	mi := &file_protos_user_user_proto_msgTypes[40]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *HeartbeatRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*HeartbeatRequest) ProtoMessage() {}
+
+func (x *HeartbeatRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_protos_user_user_proto_msgTypes[40]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use HeartbeatRequest.ProtoReflect.Descriptor instead.
+func (*HeartbeatRequest) Descriptor() ([]byte, []int) {
+	return file_protos_user_user_proto_rawDescGZIP(), []int{40}
+}
+
+func (x *HeartbeatRequest) GetOffline() bool {
+	if x != nil {
+		return x.Offline
+	}
+	return false
+}
+
+type HeartbeatResponse struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	IntervalSeconds int32                  `protobuf:"varint,1,opt,name=interval_seconds,json=intervalSeconds,proto3" json:"interval_seconds,omitempty"` // keyingi heartbeatgacha
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *HeartbeatResponse) Reset() {
+	*x = HeartbeatResponse{}
+	mi := &file_protos_user_user_proto_msgTypes[41]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *HeartbeatResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*HeartbeatResponse) ProtoMessage() {}
+
+func (x *HeartbeatResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_protos_user_user_proto_msgTypes[41]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use HeartbeatResponse.ProtoReflect.Descriptor instead.
+func (*HeartbeatResponse) Descriptor() ([]byte, []int) {
+	return file_protos_user_user_proto_rawDescGZIP(), []int{41}
+}
+
+func (x *HeartbeatResponse) GetIntervalSeconds() int32 {
+	if x != nil {
+		return x.IntervalSeconds
+	}
+	return 0
+}
+
+type WatchPresenceRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserIds       []string               `protobuf:"bytes,1,rep,name=user_ids,json=userIds,proto3" json:"user_ids,omitempty"` // ko'pi bilan 100 ta
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *WatchPresenceRequest) Reset() {
+	*x = WatchPresenceRequest{}
+	mi := &file_protos_user_user_proto_msgTypes[42]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *WatchPresenceRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WatchPresenceRequest) ProtoMessage() {}
+
+func (x *WatchPresenceRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_protos_user_user_proto_msgTypes[42]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WatchPresenceRequest.ProtoReflect.Descriptor instead.
+func (*WatchPresenceRequest) Descriptor() ([]byte, []int) {
+	return file_protos_user_user_proto_rawDescGZIP(), []int{42}
+}
+
+func (x *WatchPresenceRequest) GetUserIds() []string {
+	if x != nil {
+		return x.UserIds
+	}
+	return nil
+}
+
+// Avval har bir user uchun joriy holat, keyin faqat o'zgarishlar keladi.
+// hidden => egasi "hide last seen" ni yoqqan: online va last_seen berilmaydi
+type Presence struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Online        bool                   `protobuf:"varint,2,opt,name=online,proto3" json:"online,omitempty"`
+	LastSeen      *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=last_seen,json=lastSeen,proto3" json:"last_seen,omitempty"`
+	Hidden        bool                   `protobuf:"varint,4,opt,name=hidden,proto3" json:"hidden,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Presence) Reset() {
+	*x = Presence{}
+	mi := &file_protos_user_user_proto_msgTypes[43]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Presence) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Presence) ProtoMessage() {}
+
+func (x *Presence) ProtoReflect() protoreflect.Message {
+	mi := &file_protos_user_user_proto_msgTypes[43]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Presence.ProtoReflect.Descriptor instead.
+func (*Presence) Descriptor() ([]byte, []int) {
+	return file_protos_user_user_proto_rawDescGZIP(), []int{43}
+}
+
+func (x *Presence) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *Presence) GetOnline() bool {
+	if x != nil {
+		return x.Online
+	}
+	return false
+}
+
+func (x *Presence) GetLastSeen() *timestamppb.Timestamp {
+	if x != nil {
+		return x.LastSeen
+	}
+	return nil
+}
+
+func (x *Presence) GetHidden() bool {
+	if x != nil {
+		return x.Hidden
+	}
+	return false
+}
+
+type GetLoginHistoryRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	PageSize      int32                  `protobuf:"varint,1,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`   // standart 20, maksimal 100
+	PageToken     string                 `protobuf:"bytes,2,opt,name=page_token,json=pageToken,proto3" json:"page_token,omitempty"` // oldingi javobdagi next_page_token
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetLoginHistoryRequest) Reset() {
+	*x = GetLoginHistoryRequest{}
+	mi := &file_protos_user_user_proto_msgTypes[44]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetLoginHistoryRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetLoginHistoryRequest) ProtoMessage() {}
+
+func (x *GetLoginHistoryRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_protos_user_user_proto_msgTypes[44]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetLoginHistoryRequest.ProtoReflect.Descriptor instead.
+func (*GetLoginHistoryRequest) Descriptor() ([]byte, []int) {
+	return file_protos_user_user_proto_rawDescGZIP(), []int{44}
+}
+
+func (x *GetLoginHistoryRequest) GetPageSize() int32 {
+	if x != nil {
+		return x.PageSize
+	}
+	return 0
+}
+
+func (x *GetLoginHistoryRequest) GetPageToken() string {
+	if x != nil {
+		return x.PageToken
+	}
+	return ""
+}
+
+type LoginEvent struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Method        string                 `protobuf:"bytes,1,opt,name=method,proto3" json:"method,omitempty"`   // password, login_code, mfa, register, provider:<name>
+	Outcome       string                 `protobuf:"bytes,2,opt,name=outcome,proto3" json:"outcome,omitempty"` // success, mfa_required, failure, locked, blocked
+	FailureReason string                 `protobuf:"bytes,3,opt,name=failure_reason,json=failureReason,proto3" json:"failure_reason,omitempty"`
+	IpAddress     string                 `protobuf:"bytes,4,opt,name=ip_address,json=ipAddress,proto3" json:"ip_address,omitempty"`
+	UserAgent     string                 `protobuf:"bytes,5,opt,name=user_agent,json=userAgent,proto3" json:"user_agent,omitempty"`
+	Location      string                 `protobuf:"bytes,6,opt,name=location,proto3" json:"location,omitempty"` // "Country, City"
+	CountryCode   string                 `protobuf:"bytes,7,opt,name=country_code,json=countryCode,proto3" json:"country_code,omitempty"`
+	Platform      string                 `protobuf:"bytes,8,opt,name=platform,proto3" json:"platform,omitempty"`
+	DeviceId      string                 `protobuf:"bytes,9,opt,name=device_id,json=deviceId,proto3" json:"device_id,omitempty"`
+	CreatedAt     *timestamppb.Timestamp `protobuf:"bytes,10,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *LoginEvent) Reset() {
+	*x = LoginEvent{}
+	mi := &file_protos_user_user_proto_msgTypes[45]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *LoginEvent) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LoginEvent) ProtoMessage() {}
+
+func (x *LoginEvent) ProtoReflect() protoreflect.Message {
+	mi := &file_protos_user_user_proto_msgTypes[45]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LoginEvent.ProtoReflect.Descriptor instead.
+func (*LoginEvent) Descriptor() ([]byte, []int) {
+	return file_protos_user_user_proto_rawDescGZIP(), []int{45}
+}
+
+func (x *LoginEvent) GetMethod() string {
+	if x != nil {
+		return x.Method
+	}
+	return ""
+}
+
+func (x *LoginEvent) GetOutcome() string {
+	if x != nil {
+		return x.Outcome
+	}
+	return ""
+}
+
+func (x *LoginEvent) GetFailureReason() string {
+	if x != nil {
+		return x.FailureReason
+	}
+	return ""
+}
+
+func (x *LoginEvent) GetIpAddress() string {
+	if x != nil {
+		return x.IpAddress
+	}
+	return ""
+}
+
+func (x *LoginEvent) GetUserAgent() string {
+	if x != nil {
+		return x.UserAgent
+	}
+	return ""
+}
+
+func (x *LoginEvent) GetLocation() string {
+	if x != nil {
+		return x.Location
+	}
+	return ""
+}
+
+func (x *LoginEvent) GetCountryCode() string {
+	if x != nil {
+		return x.CountryCode
+	}
+	return ""
+}
+
+func (x *LoginEvent) GetPlatform() string {
+	if x != nil {
+		return x.Platform
+	}
+	return ""
+}
+
+func (x *LoginEvent) GetDeviceId() string {
+	if x != nil {
+		return x.DeviceId
+	}
+	return ""
+}
+
+func (x *LoginEvent) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+type LoginHistory struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Events        []*LoginEvent          `protobuf:"bytes,1,rep,name=events,proto3" json:"events,omitempty"`
+	NextPageToken string                 `protobuf:"bytes,2,opt,name=next_page_token,json=nextPageToken,proto3" json:"next_page_token,omitempty"` // bo'sh => oxirgi sahifa
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *LoginHistory) Reset() {
+	*x = LoginHistory{}
+	mi := &file_protos_user_user_proto_msgTypes[46]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *LoginHistory) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LoginHistory) ProtoMessage() {}
+
+func (x *LoginHistory) ProtoReflect() protoreflect.Message {
+	mi := &file_protos_user_user_proto_msgTypes[46]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LoginHistory.ProtoReflect.Descriptor instead.
+func (*LoginHistory) Descriptor() ([]byte, []int) {
+	return file_protos_user_user_proto_rawDescGZIP(), []int{46}
+}
+
+func (x *LoginHistory) GetEvents() []*LoginEvent {
+	if x != nil {
+		return x.Events
+	}
+	return nil
+}
+
+func (x *LoginHistory) GetNextPageToken() string {
+	if x != nil {
+		return x.NextPageToken
+	}
+	return ""
+}
+
+type JsonWebKey struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Kty           string                 `protobuf:"bytes,1,opt,name=kty,proto3" json:"kty,omitempty"`
+	Kid           string                 `protobuf:"bytes,2,opt,name=kid,proto3" json:"kid,omitempty"`
+	Use           string                 `protobuf:"bytes,3,opt,name=use,proto3" json:"use,omitempty"`
+	Alg           string                 `protobuf:"bytes,4,opt,name=alg,proto3" json:"alg,omitempty"`
+	N             string                 `protobuf:"bytes,5,opt,name=n,proto3" json:"n,omitempty"`     // RSA modulus
+	E             string                 `protobuf:"bytes,6,opt,name=e,proto3" json:"e,omitempty"`     // RSA exponent
+	Crv           string                 `protobuf:"bytes,7,opt,name=crv,proto3" json:"crv,omitempty"` // OKP curve (Ed25519)
+	X             string                 `protobuf:"bytes,8,opt,name=x,proto3" json:"x,omitempty"`     // OKP public key
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *JsonWebKey) Reset() {
+	*x = JsonWebKey{}
+	mi := &file_protos_user_user_proto_msgTypes[47]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *JsonWebKey) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*JsonWebKey) ProtoMessage() {}
+
+func (x *JsonWebKey) ProtoReflect() protoreflect.Message {
+	mi := &file_protos_user_user_proto_msgTypes[47]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use JsonWebKey.ProtoReflect.Descriptor instead.
+func (*JsonWebKey) Descriptor() ([]byte, []int) {
+	return file_protos_user_user_proto_rawDescGZIP(), []int{47}
+}
+
+func (x *JsonWebKey) GetKty() string {
+	if x != nil {
+		return x.Kty
+	}
+	return ""
+}
+
+func (x *JsonWebKey) GetKid() string {
+	if x != nil {
+		return x.Kid
+	}
+	return ""
+}
+
+func (x *JsonWebKey) GetUse() string {
+	if x != nil {
+		return x.Use
+	}
+	return ""
+}
+
+func (x *JsonWebKey) GetAlg() string {
+	if x != nil {
+		return x.Alg
+	}
+	return ""
+}
+
+func (x *JsonWebKey) GetN() string {
+	if x != nil {
+		return x.N
+	}
+	return ""
+}
+
+func (x *JsonWebKey) GetE() string {
+	if x != nil {
+		return x.E
+	}
+	return ""
+}
+
+func (x *JsonWebKey) GetCrv() string {
+	if x != nil {
+		return x.Crv
+	}
+	return ""
+}
+
+func (x *JsonWebKey) GetX() string {
+	if x != nil {
+		return x.X
+	}
+	return ""
+}
+
+type JWKSResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Keys          []*JsonWebKey          `protobuf:"bytes,1,rep,name=keys,proto3" json:"keys,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *JWKSResponse) Reset() {
+	*x = JWKSResponse{}
+	mi := &file_protos_user_user_proto_msgTypes[48]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *JWKSResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*JWKSResponse) ProtoMessage() {}
+
+func (x *JWKSResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_protos_user_user_proto_msgTypes[48]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use JWKSResponse.ProtoReflect.Descriptor instead.
+func (*JWKSResponse) Descriptor() ([]byte, []int) {
+	return file_protos_user_user_proto_rawDescGZIP(), []int{48}
+}
+
+func (x *JWKSResponse) GetKeys() []*JsonWebKey {
+	if x != nil {
+		return x.Keys
+	}
+	return nil
+}
+
+type IntrospectTokenRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Token         string                 `protobuf:"bytes,1,opt,name=token,proto3" json:"token,omitempty"` // user access tokeni
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *IntrospectTokenRequest) Reset() {
+	*x = IntrospectTokenRequest{}
+	mi := &file_protos_user_user_proto_msgTypes[49]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *IntrospectTokenRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*IntrospectTokenRequest) ProtoMessage() {}
+
+func (x *IntrospectTokenRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_protos_user_user_proto_msgTypes[49]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use IntrospectTokenRequest.ProtoReflect.Descriptor instead.
+func (*IntrospectTokenRequest) Descriptor() ([]byte, []int) {
+	return file_protos_user_user_proto_rawDescGZIP(), []int{49}
+}
+
+func (x *IntrospectTokenRequest) GetToken() string {
+	if x != nil {
+		return x.Token
+	}
+	return ""
+}
+
+type IntrospectTokenResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Active        bool                   `protobuf:"varint,1,opt,name=active,proto3" json:"active,omitempty"` // false => qolgan maydonlar bo'sh
+	UserId        string                 `protobuf:"bytes,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	SessionId     string                 `protobuf:"bytes,3,opt,name=session_id,json=sessionId,proto3" json:"session_id,omitempty"`
+	Scopes        []string               `protobuf:"bytes,4,rep,name=scopes,proto3" json:"scopes,omitempty"` // tokendagi rollar
+	IssuedAt      *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=issued_at,json=issuedAt,proto3" json:"issued_at,omitempty"`
+	ExpiresAt     *timestamppb.Timestamp `protobuf:"bytes,6,opt,name=expires_at,json=expiresAt,proto3" json:"expires_at,omitempty"`
+	ActorId       string                 `protobuf:"bytes,7,opt,name=actor_id,json=actorId,proto3" json:"actor_id,omitempty"` // impersonatsiya tokeni bo'lsa — support xodimi ID si
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *IntrospectTokenResponse) Reset() {
+	*x = IntrospectTokenResponse{}
+	mi := &file_protos_user_user_proto_msgTypes[50]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *IntrospectTokenResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*IntrospectTokenResponse) ProtoMessage() {}
+
+func (x *IntrospectTokenResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_protos_user_user_proto_msgTypes[50]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use IntrospectTokenResponse.ProtoReflect.Descriptor instead.
+func (*IntrospectTokenResponse) Descriptor() ([]byte, []int) {
+	return file_protos_user_user_proto_rawDescGZIP(), []int{50}
+}
+
+func (x *IntrospectTokenResponse) GetActive() bool {
+	if x != nil {
+		return x.Active
+	}
+	return false
+}
+
+func (x *IntrospectTokenResponse) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *IntrospectTokenResponse) GetSessionId() string {
+	if x != nil {
+		return x.SessionId
+	}
+	return ""
+}
+
+func (x *IntrospectTokenResponse) GetScopes() []string {
+	if x != nil {
+		return x.Scopes
+	}
+	return nil
+}
+
+func (x *IntrospectTokenResponse) GetIssuedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.IssuedAt
+	}
+	return nil
 }
 
-func (x *ChangePasswordRequest) GetOldPassword() string {
+func (x *IntrospectTokenResponse) GetExpiresAt() *timestamppb.Timestamp {
 	if x != nil {
-		return x.OldPassword
+		return x.ExpiresAt
 	}
-	return ""
+	return nil
 }
 
-func (x *ChangePasswordRequest) GetNewPassword() string {
+func (x *IntrospectTokenResponse) GetActorId() string {
 	if x != nil {
-		return x.NewPassword
+		return x.ActorId
 	}
 	return ""
 }
 
-type ForgotPasswordRequest struct {
+type ListUsersRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Email         string                 `protobuf:"bytes,1,opt,name=email,proto3" json:"email,omitempty"`
+	PageSize      int32                  `protobuf:"varint,1,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`   // standart 50, maksimal 200
+	PageToken     string                 `protobuf:"bytes,2,opt,name=page_token,json=pageToken,proto3" json:"page_token,omitempty"` // oldingi javobdagi next_page_token
+	Query         string                 `protobuf:"bytes,3,opt,name=query,proto3" json:"query,omitempty"`                          // username yoki email bo'yicha qidiruv
+	Role          string                 `protobuf:"bytes,4,opt,name=role,proto3" json:"role,omitempty"`
+	Status        string                 `protobuf:"bytes,5,opt,name=status,proto3" json:"status,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *ForgotPasswordRequest) Reset() {
-	*x = ForgotPasswordRequest{}
-	mi := &file_protos_user_user_proto_msgTypes[5]
+func (x *ListUsersRequest) Reset() {
+	*x = ListUsersRequest{}
+	mi := &file_protos_user_user_proto_msgTypes[51]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *ForgotPasswordRequest) String() string {
+func (x *ListUsersRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ForgotPasswordRequest) ProtoMessage() {}
+func (*ListUsersRequest) ProtoMessage() {}
 
-func (x *ForgotPasswordRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_protos_user_user_proto_msgTypes[5]
+func (x *ListUsersRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_protos_user_user_proto_msgTypes[51]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -458,92 +3176,69 @@ func (x *ForgotPasswordRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ForgotPasswordRequest.ProtoReflect.Descriptor instead.
-func (*ForgotPasswordRequest) Descriptor() ([]byte, []int) {
-	return file_protos_user_user_proto_rawDescGZIP(), []int{5}
+// Deprecated: Use ListUsersRequest.ProtoReflect.Descriptor instead.
+func (*ListUsersRequest) Descriptor() ([]byte, []int) {
+	return file_protos_user_user_proto_rawDescGZIP(), []int{51}
 }
 
-func (x *ForgotPasswordRequest) GetEmail() string {
+func (x *ListUsersRequest) GetPageSize() int32 {
 	if x != nil {
-		return x.Email
+		return x.PageSize
 	}
-	return ""
-}
-
-type ResetPasswordRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Token         string                 `protobuf:"bytes,1,opt,name=token,proto3" json:"token,omitempty"`
-	NewPassword   string                 `protobuf:"bytes,2,opt,name=new_password,json=newPassword,proto3" json:"new_password,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
-}
-
-func (x *ResetPasswordRequest) Reset() {
-	*x = ResetPasswordRequest{}
-	mi := &file_protos_user_user_proto_msgTypes[6]
-	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-	ms.StoreMessageInfo(mi)
-}
-
-func (x *ResetPasswordRequest) String() string {
-	return protoimpl.X.MessageStringOf(x)
+	return 0
 }
 
-func (*ResetPasswordRequest) ProtoMessage() {}
-
-func (x *ResetPasswordRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_protos_user_user_proto_msgTypes[6]
+func (x *ListUsersRequest) GetPageToken() string {
 	if x != nil {
-		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-		if ms.LoadMessageInfo() == nil {
-			ms.StoreMessageInfo(mi)
-		}
-		return ms
+		return x.PageToken
 	}
-	return mi.MessageOf(x)
+	return ""
 }
 
-// Deprecated: Use ResetPasswordRequest.ProtoReflect.Descriptor instead.
-func (*ResetPasswordRequest) Descriptor() ([]byte, []int) {
-	return file_protos_user_user_proto_rawDescGZIP(), []int{6}
+func (x *ListUsersRequest) GetQuery() string {
+	if x != nil {
+		return x.Query
+	}
+	return ""
 }
 
-func (x *ResetPasswordRequest) GetToken() string {
+func (x *ListUsersRequest) GetRole() string {
 	if x != nil {
-		return x.Token
+		return x.Role
 	}
 	return ""
 }
 
-func (x *ResetPasswordRequest) GetNewPassword() string {
+func (x *ListUsersRequest) GetStatus() string {
 	if x != nil {
-		return x.NewPassword
+		return x.Status
 	}
 	return ""
 }
 
-type UpdateUsernameRequest struct {
+type ListUsersResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Username      string                 `protobuf:"bytes,1,opt,name=username,proto3" json:"username,omitempty"`
+	Users         []*User                `protobuf:"bytes,1,rep,name=users,proto3" json:"users,omitempty"`
+	NextPageToken string                 `protobuf:"bytes,2,opt,name=next_page_token,json=nextPageToken,proto3" json:"next_page_token,omitempty"` // bo'sh => oxirgi sahifa
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *UpdateUsernameRequest) Reset() {
-	*x = UpdateUsernameRequest{}
-	mi := &file_protos_user_user_proto_msgTypes[7]
+func (x *ListUsersResponse) Reset() {
+	*x = ListUsersResponse{}
+	mi := &file_protos_user_user_proto_msgTypes[52]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *UpdateUsernameRequest) String() string {
+func (x *ListUsersResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*UpdateUsernameRequest) ProtoMessage() {}
+func (*ListUsersResponse) ProtoMessage() {}
 
-func (x *UpdateUsernameRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_protos_user_user_proto_msgTypes[7]
+func (x *ListUsersResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_protos_user_user_proto_msgTypes[52]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -554,40 +3249,47 @@ func (x *UpdateUsernameRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use UpdateUsernameRequest.ProtoReflect.Descriptor instead.
-func (*UpdateUsernameRequest) Descriptor() ([]byte, []int) {
-	return file_protos_user_user_proto_rawDescGZIP(), []int{7}
+// Deprecated: Use ListUsersResponse.ProtoReflect.Descriptor instead.
+func (*ListUsersResponse) Descriptor() ([]byte, []int) {
+	return file_protos_user_user_proto_rawDescGZIP(), []int{52}
 }
 
-func (x *UpdateUsernameRequest) GetUsername() string {
+func (x *ListUsersResponse) GetUsers() []*User {
 	if x != nil {
-		return x.Username
+		return x.Users
+	}
+	return nil
+}
+
+func (x *ListUsersResponse) GetNextPageToken() string {
+	if x != nil {
+		return x.NextPageToken
 	}
 	return ""
 }
 
-type UpdateEmailRequest struct {
+type AdminUserRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Email         string                 `protobuf:"bytes,1,opt,name=email,proto3" json:"email,omitempty"`
+	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *UpdateEmailRequest) Reset() {
-	*x = UpdateEmailRequest{}
-	mi := &file_protos_user_user_proto_msgTypes[8]
+func (x *AdminUserRequest) Reset() {
+	*x = AdminUserRequest{}
+	mi := &file_protos_user_user_proto_msgTypes[53]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *UpdateEmailRequest) String() string {
+func (x *AdminUserRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*UpdateEmailRequest) ProtoMessage() {}
+func (*AdminUserRequest) ProtoMessage() {}
 
-func (x *UpdateEmailRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_protos_user_user_proto_msgTypes[8]
+func (x *AdminUserRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_protos_user_user_proto_msgTypes[53]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -598,40 +3300,42 @@ func (x *UpdateEmailRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use UpdateEmailRequest.ProtoReflect.Descriptor instead.
-func (*UpdateEmailRequest) Descriptor() ([]byte, []int) {
-	return file_protos_user_user_proto_rawDescGZIP(), []int{8}
+// Deprecated: Use AdminUserRequest.ProtoReflect.Descriptor instead.
+func (*AdminUserRequest) Descriptor() ([]byte, []int) {
+	return file_protos_user_user_proto_rawDescGZIP(), []int{53}
 }
 
-func (x *UpdateEmailRequest) GetEmail() string {
+func (x *AdminUserRequest) GetUserId() string {
 	if x != nil {
-		return x.Email
+		return x.UserId
 	}
 	return ""
 }
 
-type UpdateFullNameRequest struct {
+type SuspendUserRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	FullName      string                 `protobuf:"bytes,1,opt,name=full_name,json=fullName,proto3" json:"full_name,omitempty"`
+	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Reason        string                 `protobuf:"bytes,2,opt,name=reason,proto3" json:"reason,omitempty"`
+	Until         *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=until,proto3" json:"until,omitempty"` // bo'sh => muddatsiz
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *UpdateFullNameRequest) Reset() {
-	*x = UpdateFullNameRequest{}
-	mi := &file_protos_user_user_proto_msgTypes[9]
+func (x *SuspendUserRequest) Reset() {
+	*x = SuspendUserRequest{}
+	mi := &file_protos_user_user_proto_msgTypes[54]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *UpdateFullNameRequest) String() string {
+func (x *SuspendUserRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*UpdateFullNameRequest) ProtoMessage() {}
+func (*SuspendUserRequest) ProtoMessage() {}
 
-func (x *UpdateFullNameRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_protos_user_user_proto_msgTypes[9]
+func (x *SuspendUserRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_protos_user_user_proto_msgTypes[54]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -642,40 +3346,55 @@ func (x *UpdateFullNameRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use UpdateFullNameRequest.ProtoReflect.Descriptor instead.
-func (*UpdateFullNameRequest) Descriptor() ([]byte, []int) {
-	return file_protos_user_user_proto_rawDescGZIP(), []int{9}
+// Deprecated: Use SuspendUserRequest.ProtoReflect.Descriptor instead.
+func (*SuspendUserRequest) Descriptor() ([]byte, []int) {
+	return file_protos_user_user_proto_rawDescGZIP(), []int{54}
 }
 
-func (x *UpdateFullNameRequest) GetFullName() string {
+func (x *SuspendUserRequest) GetUserId() string {
 	if x != nil {
-		return x.FullName
+		return x.UserId
 	}
 	return ""
 }
 
-type UpdateAvatarRequest struct {
+func (x *SuspendUserRequest) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+func (x *SuspendUserRequest) GetUntil() *timestamppb.Timestamp {
+	if x != nil {
+		return x.Until
+	}
+	return nil
+}
+
+type BanUserRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	AvatarUrl     string                 `protobuf:"bytes,1,opt,name=avatar_url,json=avatarUrl,proto3" json:"avatar_url,omitempty"` // empty string => remove avatar
+	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Reason        string                 `protobuf:"bytes,2,opt,name=reason,proto3" json:"reason,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *UpdateAvatarRequest) Reset() {
-	*x = UpdateAvatarRequest{}
-	mi := &file_protos_user_user_proto_msgTypes[10]
+func (x *BanUserRequest) Reset() {
+	*x = BanUserRequest{}
+	mi := &file_protos_user_user_proto_msgTypes[55]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *UpdateAvatarRequest) String() string {
+func (x *BanUserRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*UpdateAvatarRequest) ProtoMessage() {}
+func (*BanUserRequest) ProtoMessage() {}
 
-func (x *UpdateAvatarRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_protos_user_user_proto_msgTypes[10]
+func (x *BanUserRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_protos_user_user_proto_msgTypes[55]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -686,40 +3405,48 @@ func (x *UpdateAvatarRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use UpdateAvatarRequest.ProtoReflect.Descriptor instead.
-func (*UpdateAvatarRequest) Descriptor() ([]byte, []int) {
-	return file_protos_user_user_proto_rawDescGZIP(), []int{10}
+// Deprecated: Use BanUserRequest.ProtoReflect.Descriptor instead.
+func (*BanUserRequest) Descriptor() ([]byte, []int) {
+	return file_protos_user_user_proto_rawDescGZIP(), []int{55}
 }
 
-func (x *UpdateAvatarRequest) GetAvatarUrl() string {
+func (x *BanUserRequest) GetUserId() string {
 	if x != nil {
-		return x.AvatarUrl
+		return x.UserId
 	}
 	return ""
 }
 
-type UpdateLanguageRequest struct {
+func (x *BanUserRequest) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+type SetRoleRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Language      string                 `protobuf:"bytes,1,opt,name=language,proto3" json:"language,omitempty"`
+	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Role          string                 `protobuf:"bytes,2,opt,name=role,proto3" json:"role,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *UpdateLanguageRequest) Reset() {
-	*x = UpdateLanguageRequest{}
-	mi := &file_protos_user_user_proto_msgTypes[11]
+func (x *SetRoleRequest) Reset() {
+	*x = SetRoleRequest{}
+	mi := &file_protos_user_user_proto_msgTypes[56]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *UpdateLanguageRequest) String() string {
+func (x *SetRoleRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*UpdateLanguageRequest) ProtoMessage() {}
+func (*SetRoleRequest) ProtoMessage() {}
 
-func (x *UpdateLanguageRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_protos_user_user_proto_msgTypes[11]
+func (x *SetRoleRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_protos_user_user_proto_msgTypes[56]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -730,43 +3457,48 @@ func (x *UpdateLanguageRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use UpdateLanguageRequest.ProtoReflect.Descriptor instead.
-func (*UpdateLanguageRequest) Descriptor() ([]byte, []int) {
-	return file_protos_user_user_proto_rawDescGZIP(), []int{11}
+// Deprecated: Use SetRoleRequest.ProtoReflect.Descriptor instead.
+func (*SetRoleRequest) Descriptor() ([]byte, []int) {
+	return file_protos_user_user_proto_rawDescGZIP(), []int{56}
 }
 
-func (x *UpdateLanguageRequest) GetLanguage() string {
+func (x *SetRoleRequest) GetUserId() string {
 	if x != nil {
-		return x.Language
+		return x.UserId
 	}
 	return ""
 }
 
-type Session struct {
+func (x *SetRoleRequest) GetRole() string {
+	if x != nil {
+		return x.Role
+	}
+	return ""
+}
+
+type ImpersonateRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	DeviceId      string                 `protobuf:"bytes,1,opt,name=device_id,json=deviceId,proto3" json:"device_id,omitempty"`
-	Platform      string                 `protobuf:"bytes,2,opt,name=platform,proto3" json:"platform,omitempty"`
-	IpAddress     string                 `protobuf:"bytes,3,opt,name=ip_address,json=ipAddress,proto3" json:"ip_address,omitempty"`
-	LastSeen      *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=last_seen,json=lastSeen,proto3" json:"last_seen,omitempty"`
+	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Reason        string                 `protobuf:"bytes,2,opt,name=reason,proto3" json:"reason,omitempty"` // majburiy, auditga yoziladi
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *Session) Reset() {
-	*x = Session{}
-	mi := &file_protos_user_user_proto_msgTypes[12]
+func (x *ImpersonateRequest) Reset() {
+	*x = ImpersonateRequest{}
+	mi := &file_protos_user_user_proto_msgTypes[57]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *Session) String() string {
+func (x *ImpersonateRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*Session) ProtoMessage() {}
+func (*ImpersonateRequest) ProtoMessage() {}
 
-func (x *Session) ProtoReflect() protoreflect.Message {
-	mi := &file_protos_user_user_proto_msgTypes[12]
+func (x *ImpersonateRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_protos_user_user_proto_msgTypes[57]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -777,61 +3509,49 @@ func (x *Session) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use Session.ProtoReflect.Descriptor instead.
-func (*Session) Descriptor() ([]byte, []int) {
-	return file_protos_user_user_proto_rawDescGZIP(), []int{12}
-}
-
-func (x *Session) GetDeviceId() string {
-	if x != nil {
-		return x.DeviceId
-	}
-	return ""
+// Deprecated: Use ImpersonateRequest.ProtoReflect.Descriptor instead.
+func (*ImpersonateRequest) Descriptor() ([]byte, []int) {
+	return file_protos_user_user_proto_rawDescGZIP(), []int{57}
 }
 
-func (x *Session) GetPlatform() string {
+func (x *ImpersonateRequest) GetUserId() string {
 	if x != nil {
-		return x.Platform
+		return x.UserId
 	}
 	return ""
 }
 
-func (x *Session) GetIpAddress() string {
+func (x *ImpersonateRequest) GetReason() string {
 	if x != nil {
-		return x.IpAddress
+		return x.Reason
 	}
 	return ""
 }
 
-func (x *Session) GetLastSeen() *timestamppb.Timestamp {
-	if x != nil {
-		return x.LastSeen
-	}
-	return nil
-}
-
-type SessionList struct {
+type ImpersonateResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Sessions      []*Session             `protobuf:"bytes,1,rep,name=sessions,proto3" json:"sessions,omitempty"`
+	AccessToken   string                 `protobuf:"bytes,1,opt,name=access_token,json=accessToken,proto3" json:"access_token,omitempty"` // refresh tokensiz, qisqa muddatli; "act" claimida xodim ID si
+	ExpiresAt     *timestamppb.Timestamp `protobuf:"bytes,2,opt,name=expires_at,json=expiresAt,proto3" json:"expires_at,omitempty"`
+	User          *User                  `protobuf:"bytes,3,opt,name=user,proto3" json:"user,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *SessionList) Reset() {
-	*x = SessionList{}
-	mi := &file_protos_user_user_proto_msgTypes[13]
+func (x *ImpersonateResponse) Reset() {
+	*x = ImpersonateResponse{}
+	mi := &file_protos_user_user_proto_msgTypes[58]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *SessionList) String() string {
+func (x *ImpersonateResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*SessionList) ProtoMessage() {}
+func (*ImpersonateResponse) ProtoMessage() {}
 
-func (x *SessionList) ProtoReflect() protoreflect.Message {
-	mi := &file_protos_user_user_proto_msgTypes[13]
+func (x *ImpersonateResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_protos_user_user_proto_msgTypes[58]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -842,14 +3562,28 @@ func (x *SessionList) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use SessionList.ProtoReflect.Descriptor instead.
-func (*SessionList) Descriptor() ([]byte, []int) {
-	return file_protos_user_user_proto_rawDescGZIP(), []int{13}
+// Deprecated: Use ImpersonateResponse.ProtoReflect.Descriptor instead.
+func (*ImpersonateResponse) Descriptor() ([]byte, []int) {
+	return file_protos_user_user_proto_rawDescGZIP(), []int{58}
 }
 
-func (x *SessionList) GetSessions() []*Session {
+func (x *ImpersonateResponse) GetAccessToken() string {
 	if x != nil {
-		return x.Sessions
+		return x.AccessToken
+	}
+	return ""
+}
+
+func (x *ImpersonateResponse) GetExpiresAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ExpiresAt
+	}
+	return nil
+}
+
+func (x *ImpersonateResponse) GetUser() *User {
+	if x != nil {
+		return x.User
 	}
 	return nil
 }
@@ -862,7 +3596,7 @@ type Empty struct {
 
 func (x *Empty) Reset() {
 	*x = Empty{}
-	mi := &file_protos_user_user_proto_msgTypes[14]
+	mi := &file_protos_user_user_proto_msgTypes[59]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -874,7 +3608,7 @@ func (x *Empty) String() string {
 func (*Empty) ProtoMessage() {}
 
 func (x *Empty) ProtoReflect() protoreflect.Message {
-	mi := &file_protos_user_user_proto_msgTypes[14]
+	mi := &file_protos_user_user_proto_msgTypes[59]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -887,7 +3621,7 @@ func (x *Empty) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use Empty.ProtoReflect.Descriptor instead.
 func (*Empty) Descriptor() ([]byte, []int) {
-	return file_protos_user_user_proto_rawDescGZIP(), []int{14}
+	return file_protos_user_user_proto_rawDescGZIP(), []int{59}
 }
 
 type AuthResponse struct {
@@ -895,13 +3629,15 @@ type AuthResponse struct {
 	AccessToken   string                 `protobuf:"bytes,1,opt,name=access_token,json=accessToken,proto3" json:"access_token,omitempty"`
 	RefreshToken  string                 `protobuf:"bytes,2,opt,name=refresh_token,json=refreshToken,proto3" json:"refresh_token,omitempty"`
 	User          *User                  `protobuf:"bytes,3,opt,name=user,proto3" json:"user,omitempty"`
+	MfaRequired   bool                   `protobuf:"varint,4,opt,name=mfa_required,json=mfaRequired,proto3" json:"mfa_required,omitempty"` // true => tokenlar bo'sh, mfa_token bilan VerifyMFA chaqirilsin
+	MfaToken      string                 `protobuf:"bytes,5,opt,name=mfa_token,json=mfaToken,proto3" json:"mfa_token,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
 func (x *AuthResponse) Reset() {
 	*x = AuthResponse{}
-	mi := &file_protos_user_user_proto_msgTypes[15]
+	mi := &file_protos_user_user_proto_msgTypes[60]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -913,7 +3649,7 @@ func (x *AuthResponse) String() string {
 func (*AuthResponse) ProtoMessage() {}
 
 func (x *AuthResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_protos_user_user_proto_msgTypes[15]
+	mi := &file_protos_user_user_proto_msgTypes[60]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -926,7 +3662,7 @@ func (x *AuthResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use AuthResponse.ProtoReflect.Descriptor instead.
 func (*AuthResponse) Descriptor() ([]byte, []int) {
-	return file_protos_user_user_proto_rawDescGZIP(), []int{15}
+	return file_protos_user_user_proto_rawDescGZIP(), []int{60}
 }
 
 func (x *AuthResponse) GetAccessToken() string {
@@ -950,11 +3686,25 @@ func (x *AuthResponse) GetUser() *User {
 	return nil
 }
 
+func (x *AuthResponse) GetMfaRequired() bool {
+	if x != nil {
+		return x.MfaRequired
+	}
+	return false
+}
+
+func (x *AuthResponse) GetMfaToken() string {
+	if x != nil {
+		return x.MfaToken
+	}
+	return ""
+}
+
 var File_protos_user_user_proto protoreflect.FileDescriptor
 
 const file_protos_user_user_proto_rawDesc = "" +
 	"\n" +
-	"\x16protos/user/user.proto\x12\x04user\x1a\x1fgoogle/protobuf/timestamp.proto\"\xb5\x03\n" +
+	"\x16protos/user/user.proto\x12\x04user\x1a\x1fgoogle/protobuf/timestamp.proto\"\xc8\x05\n" +
 	"\x04User\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\tR\x02id\x12\x1a\n" +
 	"\busername\x18\x02 \x01(\tR\busername\x12\x14\n" +
@@ -972,7 +3722,26 @@ const file_protos_user_user_proto_rawDesc = "" +
 	"\blocation\x18\v \x01(\tR\blocation\x12?\n" +
 	"\rregistered_at\x18\f \x01(\v2\x1a.google.protobuf.TimestampR\fregisteredAt\x129\n" +
 	"\n" +
-	"updated_at\x18\r \x01(\v2\x1a.google.protobuf.TimestampR\tupdatedAt\"\xf0\x01\n" +
+	"updated_at\x18\r \x01(\v2\x1a.google.protobuf.TimestampR\tupdatedAt\x12%\n" +
+	"\x0eemail_verified\x18\x0e \x01(\bR\remailVerified\x12#\n" +
+	"\rpending_email\x18\x0f \x01(\tR\fpendingEmail\x12\x12\n" +
+	"\x04role\x18\x10 \x01(\tR\x04role\x12\x16\n" +
+	"\x06status\x18\x11 \x01(\tR\x06status\x12#\n" +
+	"\rstatus_reason\x18\x12 \x01(\tR\fstatusReason\x12C\n" +
+	"\x0fsuspended_until\x18\x13 \x01(\v2\x1a.google.protobuf.TimestampR\x0esuspendedUntil\x12/\n" +
+	"\aprivacy\x18\x14 \x01(\v2\x15.user.PrivacySettingsR\aprivacy\"\xa2\x01\n" +
+	"\x0fPrivacySettings\x12\"\n" +
+	"\fdiscoverable\x18\x01 \x01(\bR\fdiscoverable\x12$\n" +
+	"\x0eshow_full_name\x18\x02 \x01(\bR\fshowFullName\x12\x1f\n" +
+	"\vshow_avatar\x18\x03 \x01(\bR\n" +
+	"showAvatar\x12$\n" +
+	"\x0ehide_last_seen\x18\x04 \x01(\bR\fhideLastSeen\"w\n" +
+	"\rPublicProfile\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x1a\n" +
+	"\busername\x18\x02 \x01(\tR\busername\x12\x1b\n" +
+	"\tfull_name\x18\x03 \x01(\tR\bfullName\x12\x1d\n" +
+	"\n" +
+	"avatar_url\x18\x04 \x01(\tR\tavatarUrl\"\xf0\x01\n" +
 	"\x0fRegisterRequest\x12\x1a\n" +
 	"\busername\x18\x01 \x01(\tR\busername\x12\x14\n" +
 	"\x05email\x18\x02 \x01(\tR\x05email\x12\x1a\n" +
@@ -987,9 +3756,24 @@ const file_protos_user_user_proto_rawDesc = "" +
 	"\x05email\x18\x01 \x01(\tR\x05email\x12\x1a\n" +
 	"\bpassword\x18\x02 \x01(\tR\bpassword\x12\x1a\n" +
 	"\bplatform\x18\x03 \x01(\tR\bplatform\x12\x1b\n" +
+	"\tdevice_id\x18\x04 \x01(\tR\bdeviceId\"/\n" +
+	"\x17RequestLoginCodeRequest\x12\x14\n" +
+	"\x05email\x18\x01 \x01(\tR\x05email\"{\n" +
+	"\x16VerifyLoginCodeRequest\x12\x14\n" +
+	"\x05email\x18\x01 \x01(\tR\x05email\x12\x12\n" +
+	"\x04code\x18\x02 \x01(\tR\x04code\x12\x1a\n" +
+	"\bplatform\x18\x03 \x01(\tR\bplatform\x12\x1b\n" +
 	"\tdevice_id\x18\x04 \x01(\tR\bdeviceId\":\n" +
 	"\x13RefreshTokenRequest\x12#\n" +
-	"\rrefresh_token\x18\x01 \x01(\tR\frefreshToken\"]\n" +
+	"\rrefresh_token\x18\x01 \x01(\tR\frefreshToken\"^\n" +
+	"\x15ReauthenticateRequest\x12\x1c\n" +
+	"\bpassword\x18\x01 \x01(\tH\x00R\bpassword\x12\x1d\n" +
+	"\ttotp_code\x18\x02 \x01(\tH\x00R\btotpCodeB\b\n" +
+	"\x06method\"\x8e\x01\n" +
+	"\x16ReauthenticateResponse\x127\n" +
+	"\tauth_time\x18\x01 \x01(\v2\x1a.google.protobuf.TimestampR\bauthTime\x12;\n" +
+	"\vvalid_until\x18\x02 \x01(\v2\x1a.google.protobuf.TimestampR\n" +
+	"validUntil\"]\n" +
 	"\x15ChangePasswordRequest\x12!\n" +
 	"\fold_password\x18\x01 \x01(\tR\voldPassword\x12!\n" +
 	"\fnew_password\x18\x02 \x01(\tR\vnewPassword\"-\n" +
@@ -997,9 +3781,84 @@ const file_protos_user_user_proto_rawDesc = "" +
 	"\x05email\x18\x01 \x01(\tR\x05email\"O\n" +
 	"\x14ResetPasswordRequest\x12\x14\n" +
 	"\x05token\x18\x01 \x01(\tR\x05token\x12!\n" +
-	"\fnew_password\x18\x02 \x01(\tR\vnewPassword\"3\n" +
+	"\fnew_password\x18\x02 \x01(\tR\vnewPassword\"*\n" +
+	"\x12VerifyEmailRequest\x12\x14\n" +
+	"\x05token\x18\x01 \x01(\tR\x05token\"C\n" +
+	"\x10VerifyMFARequest\x12\x1b\n" +
+	"\tmfa_token\x18\x01 \x01(\tR\bmfaToken\x12\x12\n" +
+	"\x04code\x18\x02 \x01(\tR\x04code\"M\n" +
+	"\x12EnableTOTPResponse\x12\x16\n" +
+	"\x06secret\x18\x01 \x01(\tR\x06secret\x12\x1f\n" +
+	"\votpauth_uri\x18\x02 \x01(\tR\n" +
+	"otpauthUri\"(\n" +
+	"\x12ConfirmTOTPRequest\x12\x12\n" +
+	"\x04code\x18\x01 \x01(\tR\x04code\">\n" +
+	"\x15RecoveryCodesResponse\x12%\n" +
+	"\x0erecovery_codes\x18\x01 \x03(\tR\rrecoveryCodes\"(\n" +
+	"\x12DisableTOTPRequest\x12\x12\n" +
+	"\x04code\x18\x01 \x01(\tR\x04code\"\xf9\x01\n" +
+	"\x14ProviderLoginRequest\x12\x1a\n" +
+	"\bprovider\x18\x01 \x01(\tR\bprovider\x12\x12\n" +
+	"\x04code\x18\x02 \x01(\tR\x04code\x12!\n" +
+	"\fredirect_uri\x18\x03 \x01(\tR\vredirectUri\x12#\n" +
+	"\rcode_verifier\x18\x04 \x01(\tR\fcodeVerifier\x12\x14\n" +
+	"\x05nonce\x18\x05 \x01(\tR\x05nonce\x12\x1a\n" +
+	"\bplatform\x18\x06 \x01(\tR\bplatform\x12\x1b\n" +
+	"\tdevice_id\x18\a \x01(\tR\bdeviceId\x12\x1a\n" +
+	"\blanguage\x18\b \x01(\tR\blanguage\"\xa3\x01\n" +
+	"\x13LinkIdentityRequest\x12\x1a\n" +
+	"\bprovider\x18\x01 \x01(\tR\bprovider\x12\x12\n" +
+	"\x04code\x18\x02 \x01(\tR\x04code\x12!\n" +
+	"\fredirect_uri\x18\x03 \x01(\tR\vredirectUri\x12#\n" +
+	"\rcode_verifier\x18\x04 \x01(\tR\fcodeVerifier\x12\x14\n" +
+	"\x05nonce\x18\x05 \x01(\tR\x05nonce\"3\n" +
+	"\x15UnlinkIdentityRequest\x12\x1a\n" +
+	"\bprovider\x18\x01 \x01(\tR\bprovider\"w\n" +
+	"\bIdentity\x12\x1a\n" +
+	"\bprovider\x18\x01 \x01(\tR\bprovider\x12\x14\n" +
+	"\x05email\x18\x02 \x01(\tR\x05email\x129\n" +
+	"\n" +
+	"created_at\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\">\n" +
+	"\fIdentityList\x12.\n" +
+	"\n" +
+	"identities\x18\x01 \x03(\v2\x0e.user.IdentityR\n" +
+	"identities\"3\n" +
 	"\x15UpdateUsernameRequest\x12\x1a\n" +
-	"\busername\x18\x01 \x01(\tR\busername\"*\n" +
+	"\busername\x18\x01 \x01(\tR\busername\"\x8a\x02\n" +
+	"\x1cUpdatePrivacySettingsRequest\x12'\n" +
+	"\fdiscoverable\x18\x01 \x01(\bH\x00R\fdiscoverable\x88\x01\x01\x12)\n" +
+	"\x0eshow_full_name\x18\x02 \x01(\bH\x01R\fshowFullName\x88\x01\x01\x12$\n" +
+	"\vshow_avatar\x18\x03 \x01(\bH\x02R\n" +
+	"showAvatar\x88\x01\x01\x12)\n" +
+	"\x0ehide_last_seen\x18\x04 \x01(\bH\x03R\fhideLastSeen\x88\x01\x01B\x0f\n" +
+	"\r_discoverableB\x11\n" +
+	"\x0f_show_full_nameB\x0e\n" +
+	"\f_show_avatarB\x11\n" +
+	"\x0f_hide_last_seen\"Q\n" +
+	"\x15GetUserProfileRequest\x12\x10\n" +
+	"\x02id\x18\x01 \x01(\tH\x00R\x02id\x12\x1c\n" +
+	"\busername\x18\x02 \x01(\tH\x00R\busernameB\b\n" +
+	"\x06lookup\"(\n" +
+	"\x14BatchGetUsersRequest\x12\x10\n" +
+	"\x03ids\x18\x01 \x03(\tR\x03ids\"c\n" +
+	"\x15BatchGetUsersResponse\x12)\n" +
+	"\x05users\x18\x01 \x03(\v2\x13.user.PublicProfileR\x05users\x12\x1f\n" +
+	"\vmissing_ids\x18\x02 \x03(\tR\n" +
+	"missingIds\"f\n" +
+	"\x12SearchUsersRequest\x12\x14\n" +
+	"\x05query\x18\x01 \x01(\tR\x05query\x12\x1b\n" +
+	"\tpage_size\x18\x02 \x01(\x05R\bpageSize\x12\x1d\n" +
+	"\n" +
+	"page_token\x18\x03 \x01(\tR\tpageToken\"h\n" +
+	"\x13SearchUsersResponse\x12)\n" +
+	"\x05users\x18\x01 \x03(\v2\x13.user.PublicProfileR\x05users\x12&\n" +
+	"\x0fnext_page_token\x18\x02 \x01(\tR\rnextPageToken\">\n" +
+	" CheckUsernameAvailabilityRequest\x12\x1a\n" +
+	"\busername\x18\x01 \x01(\tR\busername\"n\n" +
+	"\x14UsernameAvailability\x12\x1c\n" +
+	"\tavailable\x18\x01 \x01(\bR\tavailable\x12\x16\n" +
+	"\x06reason\x18\x02 \x01(\tR\x06reason\x12 \n" +
+	"\vsuggestions\x18\x03 \x03(\tR\vsuggestions\"*\n" +
 	"\x12UpdateEmailRequest\x12\x14\n" +
 	"\x05email\x18\x01 \x01(\tR\x05email\"4\n" +
 	"\x15UpdateFullNameRequest\x12\x1b\n" +
@@ -1008,31 +3867,139 @@ const file_protos_user_user_proto_rawDesc = "" +
 	"\n" +
 	"avatar_url\x18\x01 \x01(\tR\tavatarUrl\"3\n" +
 	"\x15UpdateLanguageRequest\x12\x1a\n" +
-	"\blanguage\x18\x01 \x01(\tR\blanguage\"\x9a\x01\n" +
+	"\blanguage\x18\x01 \x01(\tR\blanguage\"\xc7\x02\n" +
 	"\aSession\x12\x1b\n" +
 	"\tdevice_id\x18\x01 \x01(\tR\bdeviceId\x12\x1a\n" +
 	"\bplatform\x18\x02 \x01(\tR\bplatform\x12\x1d\n" +
 	"\n" +
 	"ip_address\x18\x03 \x01(\tR\tipAddress\x127\n" +
-	"\tlast_seen\x18\x04 \x01(\v2\x1a.google.protobuf.TimestampR\blastSeen\"8\n" +
+	"\tlast_seen\x18\x04 \x01(\v2\x1a.google.protobuf.TimestampR\blastSeen\x12\x1d\n" +
+	"\n" +
+	"user_agent\x18\x05 \x01(\tR\tuserAgent\x129\n" +
+	"\n" +
+	"created_at\x18\x06 \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x12\x18\n" +
+	"\acurrent\x18\a \x01(\bR\acurrent\x127\n" +
+	"\tauth_time\x18\b \x01(\v2\x1a.google.protobuf.TimestampR\bauthTime\"8\n" +
 	"\vSessionList\x12)\n" +
-	"\bsessions\x18\x01 \x03(\v2\r.user.SessionR\bsessions\"\a\n" +
-	"\x05Empty\"v\n" +
+	"\bsessions\x18\x01 \x03(\v2\r.user.SessionR\bsessions\"3\n" +
+	"\x14RevokeSessionRequest\x12\x1b\n" +
+	"\tdevice_id\x18\x01 \x01(\tR\bdeviceId\",\n" +
+	"\x10HeartbeatRequest\x12\x18\n" +
+	"\aoffline\x18\x01 \x01(\bR\aoffline\">\n" +
+	"\x11HeartbeatResponse\x12)\n" +
+	"\x10interval_seconds\x18\x01 \x01(\x05R\x0fintervalSeconds\"1\n" +
+	"\x14WatchPresenceRequest\x12\x19\n" +
+	"\buser_ids\x18\x01 \x03(\tR\auserIds\"\x8c\x01\n" +
+	"\bPresence\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\x12\x16\n" +
+	"\x06online\x18\x02 \x01(\bR\x06online\x127\n" +
+	"\tlast_seen\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampR\blastSeen\x12\x16\n" +
+	"\x06hidden\x18\x04 \x01(\bR\x06hidden\"T\n" +
+	"\x16GetLoginHistoryRequest\x12\x1b\n" +
+	"\tpage_size\x18\x01 \x01(\x05R\bpageSize\x12\x1d\n" +
+	"\n" +
+	"page_token\x18\x02 \x01(\tR\tpageToken\"\xd6\x02\n" +
+	"\n" +
+	"LoginEvent\x12\x16\n" +
+	"\x06method\x18\x01 \x01(\tR\x06method\x12\x18\n" +
+	"\aoutcome\x18\x02 \x01(\tR\aoutcome\x12%\n" +
+	"\x0efailure_reason\x18\x03 \x01(\tR\rfailureReason\x12\x1d\n" +
+	"\n" +
+	"ip_address\x18\x04 \x01(\tR\tipAddress\x12\x1d\n" +
+	"\n" +
+	"user_agent\x18\x05 \x01(\tR\tuserAgent\x12\x1a\n" +
+	"\blocation\x18\x06 \x01(\tR\blocation\x12!\n" +
+	"\fcountry_code\x18\a \x01(\tR\vcountryCode\x12\x1a\n" +
+	"\bplatform\x18\b \x01(\tR\bplatform\x12\x1b\n" +
+	"\tdevice_id\x18\t \x01(\tR\bdeviceId\x129\n" +
+	"\n" +
+	"created_at\x18\n" +
+	" \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\"`\n" +
+	"\fLoginHistory\x12(\n" +
+	"\x06events\x18\x01 \x03(\v2\x10.user.LoginEventR\x06events\x12&\n" +
+	"\x0fnext_page_token\x18\x02 \x01(\tR\rnextPageToken\"\x90\x01\n" +
+	"\n" +
+	"JsonWebKey\x12\x10\n" +
+	"\x03kty\x18\x01 \x01(\tR\x03kty\x12\x10\n" +
+	"\x03kid\x18\x02 \x01(\tR\x03kid\x12\x10\n" +
+	"\x03use\x18\x03 \x01(\tR\x03use\x12\x10\n" +
+	"\x03alg\x18\x04 \x01(\tR\x03alg\x12\f\n" +
+	"\x01n\x18\x05 \x01(\tR\x01n\x12\f\n" +
+	"\x01e\x18\x06 \x01(\tR\x01e\x12\x10\n" +
+	"\x03crv\x18\a \x01(\tR\x03crv\x12\f\n" +
+	"\x01x\x18\b \x01(\tR\x01x\"4\n" +
+	"\fJWKSResponse\x12$\n" +
+	"\x04keys\x18\x01 \x03(\v2\x10.user.JsonWebKeyR\x04keys\".\n" +
+	"\x16IntrospectTokenRequest\x12\x14\n" +
+	"\x05token\x18\x01 \x01(\tR\x05token\"\x90\x02\n" +
+	"\x17IntrospectTokenResponse\x12\x16\n" +
+	"\x06active\x18\x01 \x01(\bR\x06active\x12\x17\n" +
+	"\auser_id\x18\x02 \x01(\tR\x06userId\x12\x1d\n" +
+	"\n" +
+	"session_id\x18\x03 \x01(\tR\tsessionId\x12\x16\n" +
+	"\x06scopes\x18\x04 \x03(\tR\x06scopes\x127\n" +
+	"\tissued_at\x18\x05 \x01(\v2\x1a.google.protobuf.TimestampR\bissuedAt\x129\n" +
+	"\n" +
+	"expires_at\x18\x06 \x01(\v2\x1a.google.protobuf.TimestampR\texpiresAt\x12\x19\n" +
+	"\bactor_id\x18\a \x01(\tR\aactorId\"\x90\x01\n" +
+	"\x10ListUsersRequest\x12\x1b\n" +
+	"\tpage_size\x18\x01 \x01(\x05R\bpageSize\x12\x1d\n" +
+	"\n" +
+	"page_token\x18\x02 \x01(\tR\tpageToken\x12\x14\n" +
+	"\x05query\x18\x03 \x01(\tR\x05query\x12\x12\n" +
+	"\x04role\x18\x04 \x01(\tR\x04role\x12\x16\n" +
+	"\x06status\x18\x05 \x01(\tR\x06status\"]\n" +
+	"\x11ListUsersResponse\x12 \n" +
+	"\x05users\x18\x01 \x03(\v2\n" +
+	".user.UserR\x05users\x12&\n" +
+	"\x0fnext_page_token\x18\x02 \x01(\tR\rnextPageToken\"+\n" +
+	"\x10AdminUserRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\"w\n" +
+	"\x12SuspendUserRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\x12\x16\n" +
+	"\x06reason\x18\x02 \x01(\tR\x06reason\x120\n" +
+	"\x05until\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampR\x05until\"A\n" +
+	"\x0eBanUserRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\x12\x16\n" +
+	"\x06reason\x18\x02 \x01(\tR\x06reason\"=\n" +
+	"\x0eSetRoleRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\x12\x12\n" +
+	"\x04role\x18\x02 \x01(\tR\x04role\"E\n" +
+	"\x12ImpersonateRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\x12\x16\n" +
+	"\x06reason\x18\x02 \x01(\tR\x06reason\"\x93\x01\n" +
+	"\x13ImpersonateResponse\x12!\n" +
+	"\faccess_token\x18\x01 \x01(\tR\vaccessToken\x129\n" +
+	"\n" +
+	"expires_at\x18\x02 \x01(\v2\x1a.google.protobuf.TimestampR\texpiresAt\x12\x1e\n" +
+	"\x04user\x18\x03 \x01(\v2\n" +
+	".user.UserR\x04user\"\a\n" +
+	"\x05Empty\"\xb6\x01\n" +
 	"\fAuthResponse\x12!\n" +
 	"\faccess_token\x18\x01 \x01(\tR\vaccessToken\x12#\n" +
 	"\rrefresh_token\x18\x02 \x01(\tR\frefreshToken\x12\x1e\n" +
 	"\x04user\x18\x03 \x01(\v2\n" +
-	".user.UserR\x04user2\xa8\x06\n" +
+	".user.UserR\x04user\x12!\n" +
+	"\fmfa_required\x18\x04 \x01(\bR\vmfaRequired\x12\x1b\n" +
+	"\tmfa_token\x18\x05 \x01(\tR\bmfaToken2\xe3\x12\n" +
 	"\vUserService\x125\n" +
 	"\bRegister\x12\x15.user.RegisterRequest\x1a\x12.user.AuthResponse\x12/\n" +
 	"\x05Login\x12\x12.user.LoginRequest\x1a\x12.user.AuthResponse\x12=\n" +
 	"\fRefreshToken\x12\x19.user.RefreshTokenRequest\x1a\x12.user.AuthResponse\x12\"\n" +
-	"\x06Logout\x12\v.user.Empty\x1a\v.user.Empty\x12%\n" +
+	"\x06Logout\x12\v.user.Empty\x1a\v.user.Empty\x127\n" +
+	"\tVerifyMFA\x12\x16.user.VerifyMFARequest\x1a\x12.user.AuthResponse\x12>\n" +
+	"\x10RequestLoginCode\x12\x1d.user.RequestLoginCodeRequest\x1a\v.user.Empty\x12C\n" +
+	"\x0fVerifyLoginCode\x12\x1c.user.VerifyLoginCodeRequest\x1a\x12.user.AuthResponse\x12C\n" +
+	"\x11LoginWithProvider\x12\x1a.user.ProviderLoginRequest\x1a\x12.user.AuthResponse\x129\n" +
+	"\fLinkIdentity\x12\x19.user.LinkIdentityRequest\x1a\x0e.user.Identity\x12:\n" +
+	"\x0eUnlinkIdentity\x12\x1b.user.UnlinkIdentityRequest\x1a\v.user.Empty\x120\n" +
+	"\rGetIdentities\x12\v.user.Empty\x1a\x12.user.IdentityList\x12%\n" +
 	"\n" +
 	"GetProfile\x12\v.user.Empty\x1a\n" +
 	".user.User\x129\n" +
 	"\x0eUpdateUsername\x12\x1b.user.UpdateUsernameRequest\x1a\n" +
-	".user.User\x123\n" +
+	".user.User\x12_\n" +
+	"\x19CheckUsernameAvailability\x12&.user.CheckUsernameAvailabilityRequest\x1a\x1a.user.UsernameAvailability\x123\n" +
 	"\vUpdateEmail\x12\x18.user.UpdateEmailRequest\x1a\n" +
 	".user.User\x129\n" +
 	"\x0eUpdateFullName\x12\x1b.user.UpdateFullNameRequest\x1a\n" +
@@ -1040,12 +4007,45 @@ const file_protos_user_user_proto_rawDesc = "" +
 	"\fUpdateAvatar\x12\x19.user.UpdateAvatarRequest\x1a\n" +
 	".user.User\x129\n" +
 	"\x0eUpdateLanguage\x12\x1b.user.UpdateLanguageRequest\x1a\n" +
-	".user.User\x12:\n" +
+	".user.User\x12G\n" +
+	"\x15UpdatePrivacySettings\x12\".user.UpdatePrivacySettingsRequest\x1a\n" +
+	".user.User\x12B\n" +
+	"\x0eGetUserProfile\x12\x1b.user.GetUserProfileRequest\x1a\x13.user.PublicProfile\x12B\n" +
+	"\vSearchUsers\x12\x18.user.SearchUsersRequest\x1a\x19.user.SearchUsersResponse\x12K\n" +
+	"\x0eReauthenticate\x12\x1b.user.ReauthenticateRequest\x1a\x1c.user.ReauthenticateResponse\x12:\n" +
 	"\x0eChangePassword\x12\x1b.user.ChangePasswordRequest\x1a\v.user.Empty\x12:\n" +
 	"\x0eForgotPassword\x12\x1b.user.ForgotPasswordRequest\x1a\v.user.Empty\x128\n" +
-	"\rResetPassword\x12\x1a.user.ResetPasswordRequest\x1a\v.user.Empty\x12)\n" +
+	"\rResetPassword\x12\x1a.user.ResetPasswordRequest\x1a\v.user.Empty\x121\n" +
+	"\x15SendVerificationEmail\x12\v.user.Empty\x1a\v.user.Empty\x124\n" +
+	"\vVerifyEmail\x12\x18.user.VerifyEmailRequest\x1a\v.user.Empty\x123\n" +
+	"\n" +
+	"EnableTOTP\x12\v.user.Empty\x1a\x18.user.EnableTOTPResponse\x12D\n" +
+	"\vConfirmTOTP\x12\x18.user.ConfirmTOTPRequest\x1a\x1b.user.RecoveryCodesResponse\x124\n" +
+	"\vDisableTOTP\x12\x18.user.DisableTOTPRequest\x1a\v.user.Empty\x12)\n" +
 	"\rDeleteAccount\x12\v.user.Empty\x1a\v.user.Empty\x12-\n" +
-	"\vGetSessions\x12\v.user.Empty\x1a\x11.user.SessionListB\x03Z\x01.b\x06proto3"
+	"\vGetSessions\x12\v.user.Empty\x1a\x11.user.SessionList\x128\n" +
+	"\rRevokeSession\x12\x1a.user.RevokeSessionRequest\x1a\v.user.Empty\x122\n" +
+	"\x16RevokeAllOtherSessions\x12\v.user.Empty\x1a\v.user.Empty\x12C\n" +
+	"\x0fGetLoginHistory\x12\x1c.user.GetLoginHistoryRequest\x1a\x12.user.LoginHistory\x12<\n" +
+	"\tHeartbeat\x12\x16.user.HeartbeatRequest\x1a\x17.user.HeartbeatResponse\x12=\n" +
+	"\rWatchPresence\x12\x1a.user.WatchPresenceRequest\x1a\x0e.user.Presence0\x01\x12*\n" +
+	"\aGetJWKS\x12\v.user.Empty\x1a\x12.user.JWKSResponse\x12N\n" +
+	"\x0fIntrospectToken\x12\x1c.user.IntrospectTokenRequest\x1a\x1d.user.IntrospectTokenResponse\x12H\n" +
+	"\rBatchGetUsers\x12\x1a.user.BatchGetUsersRequest\x1a\x1b.user.BatchGetUsersResponse2\xb7\x03\n" +
+	"\fAdminService\x12<\n" +
+	"\tListUsers\x12\x16.user.ListUsersRequest\x1a\x17.user.ListUsersResponse\x12-\n" +
+	"\aGetUser\x12\x16.user.AdminUserRequest\x1a\n" +
+	".user.User\x123\n" +
+	"\vSuspendUser\x12\x18.user.SuspendUserRequest\x1a\n" +
+	".user.User\x12+\n" +
+	"\aBanUser\x12\x14.user.BanUserRequest\x1a\n" +
+	".user.User\x123\n" +
+	"\rUnsuspendUser\x12\x16.user.AdminUserRequest\x1a\n" +
+	".user.User\x122\n" +
+	"\vForceLogout\x12\x16.user.AdminUserRequest\x1a\v.user.Empty\x12+\n" +
+	"\aSetRole\x12\x14.user.SetRoleRequest\x1a\n" +
+	".user.User\x12B\n" +
+	"\vImpersonate\x12\x18.user.ImpersonateRequest\x1a\x19.user.ImpersonateResponseB\x03Z\x01.b\x06proto3"
 
 var (
 	file_protos_user_user_proto_rawDescOnce sync.Once
@@ -1059,67 +4059,198 @@ func file_protos_user_user_proto_rawDescGZIP() []byte {
 	return file_protos_user_user_proto_rawDescData
 }
 
-var file_protos_user_user_proto_msgTypes = make([]protoimpl.MessageInfo, 16)
+var file_protos_user_user_proto_msgTypes = make([]protoimpl.MessageInfo, 61)
 var file_protos_user_user_proto_goTypes = []any{
-	(*User)(nil),                  // 0: user.User
-	(*RegisterRequest)(nil),       // 1: user.RegisterRequest
-	(*LoginRequest)(nil),          // 2: user.LoginRequest
-	(*RefreshTokenRequest)(nil),   // 3: user.RefreshTokenRequest
-	(*ChangePasswordRequest)(nil), // 4: user.ChangePasswordRequest
-	(*ForgotPasswordRequest)(nil), // 5: user.ForgotPasswordRequest
-	(*ResetPasswordRequest)(nil),  // 6: user.ResetPasswordRequest
-	(*UpdateUsernameRequest)(nil), // 7: user.UpdateUsernameRequest
-	(*UpdateEmailRequest)(nil),    // 8: user.UpdateEmailRequest
-	(*UpdateFullNameRequest)(nil), // 9: user.UpdateFullNameRequest
-	(*UpdateAvatarRequest)(nil),   // 10: user.UpdateAvatarRequest
-	(*UpdateLanguageRequest)(nil), // 11: user.UpdateLanguageRequest
-	(*Session)(nil),               // 12: user.Session
-	(*SessionList)(nil),           // 13: user.SessionList
-	(*Empty)(nil),                 // 14: user.Empty
-	(*AuthResponse)(nil),          // 15: user.AuthResponse
-	(*timestamppb.Timestamp)(nil), // 16: google.protobuf.Timestamp
+	(*User)(nil),                             // 0: user.User
+	(*PrivacySettings)(nil),                  // 1: user.PrivacySettings
+	(*PublicProfile)(nil),                    // 2: user.PublicProfile
+	(*RegisterRequest)(nil),                  // 3: user.RegisterRequest
+	(*LoginRequest)(nil),                     // 4: user.LoginRequest
+	(*RequestLoginCodeRequest)(nil),          // 5: user.RequestLoginCodeRequest
+	(*VerifyLoginCodeRequest)(nil),           // 6: user.VerifyLoginCodeRequest
+	(*RefreshTokenRequest)(nil),              // 7: user.RefreshTokenRequest
+	(*ReauthenticateRequest)(nil),            // 8: user.ReauthenticateRequest
+	(*ReauthenticateResponse)(nil),           // 9: user.ReauthenticateResponse
+	(*ChangePasswordRequest)(nil),            // 10: user.ChangePasswordRequest
+	(*ForgotPasswordRequest)(nil),            // 11: user.ForgotPasswordRequest
+	(*ResetPasswordRequest)(nil),             // 12: user.ResetPasswordRequest
+	(*VerifyEmailRequest)(nil),               // 13: user.VerifyEmailRequest
+	(*VerifyMFARequest)(nil),                 // 14: user.VerifyMFARequest
+	(*EnableTOTPResponse)(nil),               // 15: user.EnableTOTPResponse
+	(*ConfirmTOTPRequest)(nil),               // 16: user.ConfirmTOTPRequest
+	(*RecoveryCodesResponse)(nil),            // 17: user.RecoveryCodesResponse
+	(*DisableTOTPRequest)(nil),               // 18: user.DisableTOTPRequest
+	(*ProviderLoginRequest)(nil),             // 19: user.ProviderLoginRequest
+	(*LinkIdentityRequest)(nil),              // 20: user.LinkIdentityRequest
+	(*UnlinkIdentityRequest)(nil),            // 21: user.UnlinkIdentityRequest
+	(*Identity)(nil),                         // 22: user.Identity
+	(*IdentityList)(nil),                     // 23: user.IdentityList
+	(*UpdateUsernameRequest)(nil),            // 24: user.UpdateUsernameRequest
+	(*UpdatePrivacySettingsRequest)(nil),     // 25: user.UpdatePrivacySettingsRequest
+	(*GetUserProfileRequest)(nil),            // 26: user.GetUserProfileRequest
+	(*BatchGetUsersRequest)(nil),             // 27: user.BatchGetUsersRequest
+	(*BatchGetUsersResponse)(nil),            // 28: user.BatchGetUsersResponse
+	(*SearchUsersRequest)(nil),               // 29: user.SearchUsersRequest
+	(*SearchUsersResponse)(nil),              // 30: user.SearchUsersResponse
+	(*CheckUsernameAvailabilityRequest)(nil), // 31: user.CheckUsernameAvailabilityRequest
+	(*UsernameAvailability)(nil),             // 32: user.UsernameAvailability
+	(*UpdateEmailRequest)(nil),               // 33: user.UpdateEmailRequest
+	(*UpdateFullNameRequest)(nil),            // 34: user.UpdateFullNameRequest
+	(*UpdateAvatarRequest)(nil),              // 35: user.UpdateAvatarRequest
+	(*UpdateLanguageRequest)(nil),            // 36: user.UpdateLanguageRequest
+	(*Session)(nil),                          // 37: user.Session
+	(*SessionList)(nil),                      // 38: user.SessionList
+	(*RevokeSessionRequest)(nil),             // 39: user.RevokeSessionRequest
+	(*HeartbeatRequest)(nil),                 // 40: user.HeartbeatRequest
+	(*HeartbeatResponse)(nil),                // 41: user.HeartbeatResponse
+	(*WatchPresenceRequest)(nil),             // 42: user.WatchPresenceRequest
+	(*Presence)(nil),                         // 43: user.Presence
+	(*GetLoginHistoryRequest)(nil),           // 44: user.GetLoginHistoryRequest
+	(*LoginEvent)(nil),                       // 45: user.LoginEvent
+	(*LoginHistory)(nil),                     // 46: user.LoginHistory
+	(*JsonWebKey)(nil),                       // 47: user.JsonWebKey
+	(*JWKSResponse)(nil),                     // 48: user.JWKSResponse
+	(*IntrospectTokenRequest)(nil),           // 49: user.IntrospectTokenRequest
+	(*IntrospectTokenResponse)(nil),          // 50: user.IntrospectTokenResponse
+	(*ListUsersRequest)(nil),                 // 51: user.ListUsersRequest
+	(*ListUsersResponse)(nil),                // 52: user.ListUsersResponse
+	(*AdminUserRequest)(nil),                 // 53: user.AdminUserRequest
+	(*SuspendUserRequest)(nil),               // 54: user.SuspendUserRequest
+	(*BanUserRequest)(nil),                   // 55: user.BanUserRequest
+	(*SetRoleRequest)(nil),                   // 56: user.SetRoleRequest
+	(*ImpersonateRequest)(nil),               // 57: user.ImpersonateRequest
+	(*ImpersonateResponse)(nil),              // 58: user.ImpersonateResponse
+	(*Empty)(nil),                            // 59: user.Empty
+	(*AuthResponse)(nil),                     // 60: user.AuthResponse
+	(*timestamppb.Timestamp)(nil),            // 61: google.protobuf.Timestamp
 }
 var file_protos_user_user_proto_depIdxs = []int32{
-	16, // 0: user.User.registered_at:type_name -> google.protobuf.Timestamp
-	16, // 1: user.User.updated_at:type_name -> google.protobuf.Timestamp
-	16, // 2: user.Session.last_seen:type_name -> google.protobuf.Timestamp
-	12, // 3: user.SessionList.sessions:type_name -> user.Session
-	0,  // 4: user.AuthResponse.user:type_name -> user.User
-	1,  // 5: user.UserService.Register:input_type -> user.RegisterRequest
-	2,  // 6: user.UserService.Login:input_type -> user.LoginRequest
-	3,  // 7: user.UserService.RefreshToken:input_type -> user.RefreshTokenRequest
-	14, // 8: user.UserService.Logout:input_type -> user.Empty
-	14, // 9: user.UserService.GetProfile:input_type -> user.Empty
-	7,  // 10: user.UserService.UpdateUsername:input_type -> user.UpdateUsernameRequest
-	8,  // 11: user.UserService.UpdateEmail:input_type -> user.UpdateEmailRequest
-	9,  // 12: user.UserService.UpdateFullName:input_type -> user.UpdateFullNameRequest
-	10, // 13: user.UserService.UpdateAvatar:input_type -> user.UpdateAvatarRequest
-	11, // 14: user.UserService.UpdateLanguage:input_type -> user.UpdateLanguageRequest
-	4,  // 15: user.UserService.ChangePassword:input_type -> user.ChangePasswordRequest
-	5,  // 16: user.UserService.ForgotPassword:input_type -> user.ForgotPasswordRequest
-	6,  // 17: user.UserService.ResetPassword:input_type -> user.ResetPasswordRequest
-	14, // 18: user.UserService.DeleteAccount:input_type -> user.Empty
-	14, // 19: user.UserService.GetSessions:input_type -> user.Empty
-	15, // 20: user.UserService.Register:output_type -> user.AuthResponse
-	15, // 21: user.UserService.Login:output_type -> user.AuthResponse
-	15, // 22: user.UserService.RefreshToken:output_type -> user.AuthResponse
-	14, // 23: user.UserService.Logout:output_type -> user.Empty
-	0,  // 24: user.UserService.GetProfile:output_type -> user.User
-	0,  // 25: user.UserService.UpdateUsername:output_type -> user.User
-	0,  // 26: user.UserService.UpdateEmail:output_type -> user.User
-	0,  // 27: user.UserService.UpdateFullName:output_type -> user.User
-	0,  // 28: user.UserService.UpdateAvatar:output_type -> user.User
-	0,  // 29: user.UserService.UpdateLanguage:output_type -> user.User
-	14, // 30: user.UserService.ChangePassword:output_type -> user.Empty
-	14, // 31: user.UserService.ForgotPassword:output_type -> user.Empty
-	14, // 32: user.UserService.ResetPassword:output_type -> user.Empty
-	14, // 33: user.UserService.DeleteAccount:output_type -> user.Empty
-	13, // 34: user.UserService.GetSessions:output_type -> user.SessionList
-	20, // [20:35] is the sub-list for method output_type
-	5,  // [5:20] is the sub-list for method input_type
-	5,  // [5:5] is the sub-list for extension type_name
-	5,  // [5:5] is the sub-list for extension extendee
-	0,  // [0:5] is the sub-list for field type_name
+	61, // 0: user.User.registered_at:type_name -> google.protobuf.Timestamp
+	61, // 1: user.User.updated_at:type_name -> google.protobuf.Timestamp
+	61, // 2: user.User.suspended_until:type_name -> google.protobuf.Timestamp
+	1,  // 3: user.User.privacy:type_name -> user.PrivacySettings
+	61, // 4: user.ReauthenticateResponse.auth_time:type_name -> google.protobuf.Timestamp
+	61, // 5: user.ReauthenticateResponse.valid_until:type_name -> google.protobuf.Timestamp
+	61, // 6: user.Identity.created_at:type_name -> google.protobuf.Timestamp
+	22, // 7: user.IdentityList.identities:type_name -> user.Identity
+	2,  // 8: user.BatchGetUsersResponse.users:type_name -> user.PublicProfile
+	2,  // 9: user.SearchUsersResponse.users:type_name -> user.PublicProfile
+	61, // 10: user.Session.last_seen:type_name -> google.protobuf.Timestamp
+	61, // 11: user.Session.created_at:type_name -> google.protobuf.Timestamp
+	61, // 12: user.Session.auth_time:type_name -> google.protobuf.Timestamp
+	37, // 13: user.SessionList.sessions:type_name -> user.Session
+	61, // 14: user.Presence.last_seen:type_name -> google.protobuf.Timestamp
+	61, // 15: user.LoginEvent.created_at:type_name -> google.protobuf.Timestamp
+	45, // 16: user.LoginHistory.events:type_name -> user.LoginEvent
+	47, // 17: user.JWKSResponse.keys:type_name -> user.JsonWebKey
+	61, // 18: user.IntrospectTokenResponse.issued_at:type_name -> google.protobuf.Timestamp
+	61, // 19: user.IntrospectTokenResponse.expires_at:type_name -> google.protobuf.Timestamp
+	0,  // 20: user.ListUsersResponse.users:type_name -> user.User
+	61, // 21: user.SuspendUserRequest.until:type_name -> google.protobuf.Timestamp
+	61, // 22: user.ImpersonateResponse.expires_at:type_name -> google.protobuf.Timestamp
+	0,  // 23: user.ImpersonateResponse.user:type_name -> user.User
+	0,  // 24: user.AuthResponse.user:type_name -> user.User
+	3,  // 25: user.UserService.Register:input_type -> user.RegisterRequest
+	4,  // 26: user.UserService.Login:input_type -> user.LoginRequest
+	7,  // 27: user.UserService.RefreshToken:input_type -> user.RefreshTokenRequest
+	59, // 28: user.UserService.Logout:input_type -> user.Empty
+	14, // 29: user.UserService.VerifyMFA:input_type -> user.VerifyMFARequest
+	5,  // 30: user.UserService.RequestLoginCode:input_type -> user.RequestLoginCodeRequest
+	6,  // 31: user.UserService.VerifyLoginCode:input_type -> user.VerifyLoginCodeRequest
+	19, // 32: user.UserService.LoginWithProvider:input_type -> user.ProviderLoginRequest
+	20, // 33: user.UserService.LinkIdentity:input_type -> user.LinkIdentityRequest
+	21, // 34: user.UserService.UnlinkIdentity:input_type -> user.UnlinkIdentityRequest
+	59, // 35: user.UserService.GetIdentities:input_type -> user.Empty
+	59, // 36: user.UserService.GetProfile:input_type -> user.Empty
+	24, // 37: user.UserService.UpdateUsername:input_type -> user.UpdateUsernameRequest
+	31, // 38: user.UserService.CheckUsernameAvailability:input_type -> user.CheckUsernameAvailabilityRequest
+	33, // 39: user.UserService.UpdateEmail:input_type -> user.UpdateEmailRequest
+	34, // 40: user.UserService.UpdateFullName:input_type -> user.UpdateFullNameRequest
+	35, // 41: user.UserService.UpdateAvatar:input_type -> user.UpdateAvatarRequest
+	36, // 42: user.UserService.UpdateLanguage:input_type -> user.UpdateLanguageRequest
+	25, // 43: user.UserService.UpdatePrivacySettings:input_type -> user.UpdatePrivacySettingsRequest
+	26, // 44: user.UserService.GetUserProfile:input_type -> user.GetUserProfileRequest
+	29, // 45: user.UserService.SearchUsers:input_type -> user.SearchUsersRequest
+	8,  // 46: user.UserService.Reauthenticate:input_type -> user.ReauthenticateRequest
+	10, // 47: user.UserService.ChangePassword:input_type -> user.ChangePasswordRequest
+	11, // 48: user.UserService.ForgotPassword:input_type -> user.ForgotPasswordRequest
+	12, // 49: user.UserService.ResetPassword:input_type -> user.ResetPasswordRequest
+	59, // 50: user.UserService.SendVerificationEmail:input_type -> user.Empty
+	13, // 51: user.UserService.VerifyEmail:input_type -> user.VerifyEmailRequest
+	59, // 52: user.UserService.EnableTOTP:input_type -> user.Empty
+	16, // 53: user.UserService.ConfirmTOTP:input_type -> user.ConfirmTOTPRequest
+	18, // 54: user.UserService.DisableTOTP:input_type -> user.DisableTOTPRequest
+	59, // 55: user.UserService.DeleteAccount:input_type -> user.Empty
+	59, // 56: user.UserService.GetSessions:input_type -> user.Empty
+	39, // 57: user.UserService.RevokeSession:input_type -> user.RevokeSessionRequest
+	59, // 58: user.UserService.RevokeAllOtherSessions:input_type -> user.Empty
+	44, // 59: user.UserService.GetLoginHistory:input_type -> user.GetLoginHistoryRequest
+	40, // 60: user.UserService.Heartbeat:input_type -> user.HeartbeatRequest
+	42, // 61: user.UserService.WatchPresence:input_type -> user.WatchPresenceRequest
+	59, // 62: user.UserService.GetJWKS:input_type -> user.Empty
+	49, // 63: user.UserService.IntrospectToken:input_type -> user.IntrospectTokenRequest
+	27, // 64: user.UserService.BatchGetUsers:input_type -> user.BatchGetUsersRequest
+	51, // 65: user.AdminService.ListUsers:input_type -> user.ListUsersRequest
+	53, // 66: user.AdminService.GetUser:input_type -> user.AdminUserRequest
+	54, // 67: user.AdminService.SuspendUser:input_type -> user.SuspendUserRequest
+	55, // 68: user.AdminService.BanUser:input_type -> user.BanUserRequest
+	53, // 69: user.AdminService.UnsuspendUser:input_type -> user.AdminUserRequest
+	53, // 70: user.AdminService.ForceLogout:input_type -> user.AdminUserRequest
+	56, // 71: user.AdminService.SetRole:input_type -> user.SetRoleRequest
+	57, // 72: user.AdminService.Impersonate:input_type -> user.ImpersonateRequest
+	60, // 73: user.UserService.Register:output_type -> user.AuthResponse
+	60, // 74: user.UserService.Login:output_type -> user.AuthResponse
+	60, // 75: user.UserService.RefreshToken:output_type -> user.AuthResponse
+	59, // 76: user.UserService.Logout:output_type -> user.Empty
+	60, // 77: user.UserService.VerifyMFA:output_type -> user.AuthResponse
+	59, // 78: user.UserService.RequestLoginCode:output_type -> user.Empty
+	60, // 79: user.UserService.VerifyLoginCode:output_type -> user.AuthResponse
+	60, // 80: user.UserService.LoginWithProvider:output_type -> user.AuthResponse
+	22, // 81: user.UserService.LinkIdentity:output_type -> user.Identity
+	59, // 82: user.UserService.UnlinkIdentity:output_type -> user.Empty
+	23, // 83: user.UserService.GetIdentities:output_type -> user.IdentityList
+	0,  // 84: user.UserService.GetProfile:output_type -> user.User
+	0,  // 85: user.UserService.UpdateUsername:output_type -> user.User
+	32, // 86: user.UserService.CheckUsernameAvailability:output_type -> user.UsernameAvailability
+	0,  // 87: user.UserService.UpdateEmail:output_type -> user.User
+	0,  // 88: user.UserService.UpdateFullName:output_type -> user.User
+	0,  // 89: user.UserService.UpdateAvatar:output_type -> user.User
+	0,  // 90: user.UserService.UpdateLanguage:output_type -> user.User
+	0,  // 91: user.UserService.UpdatePrivacySettings:output_type -> user.User
+	2,  // 92: user.UserService.GetUserProfile:output_type -> user.PublicProfile
+	30, // 93: user.UserService.SearchUsers:output_type -> user.SearchUsersResponse
+	9,  // 94: user.UserService.Reauthenticate:output_type -> user.ReauthenticateResponse
+	59, // 95: user.UserService.ChangePassword:output_type -> user.Empty
+	59, // 96: user.UserService.ForgotPassword:output_type -> user.Empty
+	59, // 97: user.UserService.ResetPassword:output_type -> user.Empty
+	59, // 98: user.UserService.SendVerificationEmail:output_type -> user.Empty
+	59, // 99: user.UserService.VerifyEmail:output_type -> user.Empty
+	15, // 100: user.UserService.EnableTOTP:output_type -> user.EnableTOTPResponse
+	17, // 101: user.UserService.ConfirmTOTP:output_type -> user.RecoveryCodesResponse
+	59, // 102: user.UserService.DisableTOTP:output_type -> user.Empty
+	59, // 103: user.UserService.DeleteAccount:output_type -> user.Empty
+	38, // 104: user.UserService.GetSessions:output_type -> user.SessionList
+	59, // 105: user.UserService.RevokeSession:output_type -> user.Empty
+	59, // 106: user.UserService.RevokeAllOtherSessions:output_type -> user.Empty
+	46, // 107: user.UserService.GetLoginHistory:output_type -> user.LoginHistory
+	41, // 108: user.UserService.Heartbeat:output_type -> user.HeartbeatResponse
+	43, // 109: user.UserService.WatchPresence:output_type -> user.Presence
+	48, // 110: user.UserService.GetJWKS:output_type -> user.JWKSResponse
+	50, // 111: user.UserService.IntrospectToken:output_type -> user.IntrospectTokenResponse
+	28, // 112: user.UserService.BatchGetUsers:output_type -> user.BatchGetUsersResponse
+	52, // 113: user.AdminService.ListUsers:output_type -> user.ListUsersResponse
+	0,  // 114: user.AdminService.GetUser:output_type -> user.User
+	0,  // 115: user.AdminService.SuspendUser:output_type -> user.User
+	0,  // 116: user.AdminService.BanUser:output_type -> user.User
+	0,  // 117: user.AdminService.UnsuspendUser:output_type -> user.User
+	59, // 118: user.AdminService.ForceLogout:output_type -> user.Empty
+	0,  // 119: user.AdminService.SetRole:output_type -> user.User
+	58, // 120: user.AdminService.Impersonate:output_type -> user.ImpersonateResponse
+	73, // [73:121] is the sub-list for method output_type
+	25, // [25:73] is the sub-list for method input_type
+	25, // [25:25] is the sub-list for extension type_name
+	25, // [25:25] is the sub-list for extension extendee
+	0,  // [0:25] is the sub-list for field type_name
 }
 
 func init() { file_protos_user_user_proto_init() }
@@ -1127,15 +4258,24 @@ func file_protos_user_user_proto_init() {
 	if File_protos_user_user_proto != nil {
 		return
 	}
+	file_protos_user_user_proto_msgTypes[8].OneofWrappers = []any{
+		(*ReauthenticateRequest_Password)(nil),
+		(*ReauthenticateRequest_TotpCode)(nil),
+	}
+	file_protos_user_user_proto_msgTypes[25].OneofWrappers = []any{}
+	file_protos_user_user_proto_msgTypes[26].OneofWrappers = []any{
+		(*GetUserProfileRequest_Id)(nil),
+		(*GetUserProfileRequest_Username)(nil),
+	}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_protos_user_user_proto_rawDesc), len(file_protos_user_user_proto_rawDesc)),
 			NumEnums:      0,
-			NumMessages:   16,
+			NumMessages:   61,
 			NumExtensions: 0,
-			NumServices:   1,
+			NumServices:   2,
 		},
 		GoTypes:           file_protos_user_user_proto_goTypes,
 		DependencyIndexes: file_protos_user_user_proto_depIdxs,